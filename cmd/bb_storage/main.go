@@ -3,6 +3,7 @@ package main
 import (
 	"log"
 	"os"
+	"time"
 
 	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
 	"github.com/buildbarn/bb-storage/pkg/auth"
@@ -10,6 +11,9 @@ import (
 	blobstore_configuration "github.com/buildbarn/bb-storage/pkg/blobstore/configuration"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/grpcservers"
 	"github.com/buildbarn/bb-storage/pkg/builder"
+	"github.com/buildbarn/bb-storage/pkg/capabilities"
+	"github.com/buildbarn/bb-storage/pkg/clock"
+	"github.com/buildbarn/bb-storage/pkg/filesystem"
 	"github.com/buildbarn/bb-storage/pkg/global"
 	bb_grpc "github.com/buildbarn/bb-storage/pkg/grpc"
 	"github.com/buildbarn/bb-storage/pkg/proto/configuration/bb_storage"
@@ -88,6 +92,49 @@ func main() {
 		}
 	}
 
+	// Optional: Staging of partial uploads, so that ByteStream
+	// uploads that are interrupted may be resumed.
+	var partialUploadStore grpcservers.PartialUploadStore
+	var minimumPartialUploadSize int64
+	if partialUploads := configuration.PartialUploads; partialUploads != nil {
+		directory, err := filesystem.NewLocalDirectory(partialUploads.DirectoryPath)
+		if err != nil {
+			log.Fatalf("Failed to open partial uploads directory %#v: %s", partialUploads.DirectoryPath, err)
+		}
+		if err := partialUploads.Retention.CheckValid(); err != nil {
+			log.Fatal("Failed to parse partial uploads retention: ", err)
+		}
+		retention := partialUploads.Retention.AsDuration()
+		if retention <= 0 {
+			log.Fatal("Partial uploads retention must be positive")
+		}
+		if partialUploads.MaximumSizeBytes <= 0 {
+			log.Fatal("Partial uploads maximum size must be positive")
+		}
+		if partialUploads.MinimumSizeBytes < 0 {
+			log.Fatal("Partial uploads minimum size must not be negative")
+		}
+		minimumPartialUploadSize = partialUploads.MinimumSizeBytes
+		partialUploadStore, err = grpcservers.NewDirectoryBackedPartialUploadStore(
+			directory,
+			clock.SystemClock,
+			retention,
+			partialUploads.MaximumSizeBytes)
+		if err != nil {
+			log.Fatal("Failed to create partial upload store: ", err)
+		}
+
+		// Periodically remove data of uploads that have been
+		// abandoned by clients.
+		go func() {
+			ticker := time.NewTicker(retention)
+			defer ticker.Stop()
+			for range ticker.C {
+				partialUploadStore.RemoveExpiredUploads()
+			}
+		}()
+	}
+
 	// Create a demultiplexing build queue that forwards traffic to
 	// one or more schedulers specified in the configuration file.
 	buildQueue, err := builder.NewDemultiplexingBuildQueueFromConfiguration(
@@ -98,8 +145,6 @@ func main() {
 		log.Fatal(err)
 	}
 
-	buildQueue = builder.NewUpdateEnabledTogglingBuildQueue(buildQueue, acPutAuthorizer)
-
 	executeAuthorizer, err := auth.DefaultAuthorizerFactory.NewAuthorizerFromConfiguration(configuration.GetExecuteAuthorizer())
 	if err != nil {
 		log.Fatal("Failed to create execute authorizer: ", err)
@@ -113,21 +158,29 @@ func main() {
 			bb_grpc.NewServersFromConfigurationAndServe(
 				configuration.GrpcServers,
 				func(s grpc.ServiceRegistrar) {
+					// The REv2 services are generated against
+					// *grpc.Server instead of grpc.ServiceRegistrar.
+					grpcServer := s.(*grpc.Server)
 					remoteexecution.RegisterActionCacheServer(
-						s,
+						grpcServer,
 						grpcservers.NewActionCacheServer(
 							actionCache,
+							contentAddressableStorage,
 							int(configuration.MaximumMessageSizeBytes)))
 					remoteexecution.RegisterContentAddressableStorageServer(
-						s,
+						grpcServer,
 						grpcservers.NewContentAddressableStorageServer(
 							contentAddressableStorage,
-							configuration.MaximumMessageSizeBytes))
+							configuration.MaximumMessageSizeBytes,
+							configuration.MaximumBatchConcurrency))
 					bytestream.RegisterByteStreamServer(
 						s,
 						grpcservers.NewByteStreamServer(
 							contentAddressableStorage,
-							1<<16))
+							1<<16,
+							partialUploadStore,
+							minimumPartialUploadSize,
+							configuration.CheckExistenceBeforeWrite))
 					if indirectContentAddressableStorage != nil {
 						icas.RegisterIndirectContentAddressableStorageServer(
 							s,
@@ -142,8 +195,15 @@ func main() {
 								initialSizeClassCache,
 								int(configuration.MaximumMessageSizeBytes)))
 					}
-					remoteexecution.RegisterCapabilitiesServer(s, buildQueue)
-					remoteexecution.RegisterExecutionServer(s, buildQueue)
+					remoteexecution.RegisterCapabilitiesServer(
+						grpcServer,
+						capabilities.NewServer(
+							capabilities.NewMergingProvider([]capabilities.Provider{
+								contentAddressableStorage,
+								actionCache,
+								buildQueue,
+							})))
+					remoteexecution.RegisterExecutionServer(grpcServer, buildQueue)
 				}))
 	}()
 
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"io"
+	"io/ioutil"
+	"log"
+	"math"
+	"os"
+
+	"github.com/buildbarn/bb-storage/pkg/blobstore/local"
+	"github.com/buildbarn/bb-storage/pkg/blockdevice"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/filesystem"
+	"github.com/buildbarn/bb-storage/pkg/filesystem/path"
+	local_pb "github.com/buildbarn/bb-storage/pkg/proto/blobstore/local"
+	"github.com/buildbarn/bb-storage/pkg/proto/configuration/bb_storage_fsck"
+	blockdevice_pb "github.com/buildbarn/bb-storage/pkg/proto/configuration/blockdevice"
+	"github.com/buildbarn/bb-storage/pkg/util"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// This tool reads the persistent state file and block devices used by
+// a local storage backend that has persistency enabled, and checks
+// every entry in the key-location map. Entries that refer to data that
+// was never persisted, or whose contents do not match their key, are
+// reported. These entries may optionally be invalidated.
+//
+// All files and devices are opened read-only, so that this tool cannot
+// create, resize or otherwise modify them. The only exception is the
+// key-location map, which is opened for writing if bad entries need to
+// be invalidated.
+func main() {
+	if len(os.Args) != 2 {
+		log.Fatal("Usage: bb_storage_fsck bb_storage_fsck.jsonnet")
+	}
+	var configuration bb_storage_fsck.ApplicationConfiguration
+	if err := util.UnmarshalConfigurationFromFile(os.Args[1], &configuration); err != nil {
+		log.Fatalf("Failed to read configuration from %s: %s", os.Args[1], err)
+	}
+	localConfiguration := configuration.Local
+	if localConfiguration == nil {
+		log.Fatal("No local storage backend configuration provided")
+	}
+	persistent := localConfiguration.Persistent
+	if persistent == nil {
+		log.Fatal("Local storage backend does not have persistency enabled")
+	}
+	blocksOnBlockDevice := localConfiguration.GetBlocksOnBlockDevice()
+	if blocksOnBlockDevice == nil {
+		log.Fatal("Local storage backend does not store blocks on a block device")
+	}
+	keyLocationMapOnBlockDevice := localConfiguration.GetKeyLocationMapOnBlockDevice()
+	if keyLocationMapOnBlockDevice == nil {
+		log.Fatal("Local storage backend does not store the key-location map on a block device")
+	}
+
+	// Read the persistent state file directly, as opposed to using
+	// DirectoryBackedPersistentStateStore. The latter reinitializes
+	// the persistent state if it's absent or corrupted.
+	persistentStateDirectory, err := filesystem.NewLocalDirectory(persistent.StateDirectoryPath)
+	if err != nil {
+		log.Fatal("Failed to open persistent state directory: ", err)
+	}
+	persistentStateFile, err := persistentStateDirectory.OpenRead(path.MustNewComponent("state"))
+	if err != nil {
+		log.Fatal("Failed to open persistent state file: ", err)
+	}
+	persistentStateData, err := ioutil.ReadAll(io.NewSectionReader(persistentStateFile, 0, math.MaxInt64))
+	persistentStateFile.Close()
+	if err != nil {
+		log.Fatal("Failed to read persistent state file: ", err)
+	}
+	var persistentState local_pb.PersistentState
+	if err := proto.Unmarshal(persistentStateData, &persistentState); err != nil {
+		log.Fatal("Failed to unmarshal persistent state file: ", err)
+	}
+
+	// Open the block devices using the same configuration as
+	// bb_storage, but without creating or resizing any files.
+	sources := blocksOnBlockDevice.Sources
+	if blocksOnBlockDevice.Source != nil {
+		if len(sources) > 0 {
+			log.Fatal("Options \"source\" and \"sources\" are mutually exclusive")
+		}
+		sources = []*blockdevice_pb.Configuration{blocksOnBlockDevice.Source}
+	}
+	blocksDevices, sectorSizeBytes, sectorCounts, err := blockdevice.NewReadOnlyBlockDevicesFromConfiguration(sources)
+	if err != nil {
+		log.Fatal("Failed to open blocks block device: ", err)
+	}
+	blockCount := blocksOnBlockDevice.SpareBlocks + localConfiguration.OldBlocks + localConfiguration.CurrentBlocks + localConfiguration.NewBlocks
+	blockSectorCount, blockCounts, err := local.GetBlockDeviceBackedBlockLayout(sectorCounts, int(blockCount))
+	if err != nil {
+		log.Fatal("Failed to compute layout of blocks block devices: ", err)
+	}
+	blocksReaders := make([]io.ReaderAt, 0, len(blocksDevices))
+	for _, blocksDevice := range blocksDevices {
+		blocksReaders = append(blocksReaders, blocksDevice)
+	}
+	var keyLocationMapDevice blockdevice.BlockDevice
+	var keyLocationMapSectorSizeBytes int
+	var keyLocationMapSectorCount int64
+	if configuration.InvalidateBadEntries {
+		keyLocationMapDevice, keyLocationMapSectorSizeBytes, keyLocationMapSectorCount, err = blockdevice.NewBlockDeviceFromConfiguration(keyLocationMapOnBlockDevice, false)
+	} else {
+		keyLocationMapDevice, keyLocationMapSectorSizeBytes, keyLocationMapSectorCount, err = blockdevice.NewReadOnlyBlockDeviceFromConfiguration(keyLocationMapOnBlockDevice)
+	}
+	if err != nil {
+		log.Fatal("Failed to open key-location map block device: ", err)
+	}
+	recordsCount := int((int64(keyLocationMapSectorSizeBytes) * keyLocationMapSectorCount) / local.BlockDeviceBackedLocationRecordSize)
+
+	var keyValidator local.KeyValidator
+	if configuration.ContentAddressableStorage {
+		var instanceNames []digest.InstanceName
+		for _, instanceNameString := range configuration.InstanceNames {
+			instanceName, err := digest.NewInstanceName(instanceNameString)
+			if err != nil {
+				log.Fatalf("Invalid instance name %#v: %s", instanceNameString, err)
+			}
+			instanceNames = append(instanceNames, instanceName)
+		}
+		keyValidator = local.NewCASKeyValidator(instanceNames)
+	}
+
+	checker, restoredBlockCount := local.NewPersistentStateChecker(
+		&persistentState,
+		blocksReaders,
+		sectorSizeBytes,
+		blockSectorCount,
+		blockCounts,
+		keyLocationMapDevice,
+		recordsCount,
+		keyValidator)
+	if restoredBlockCount < len(persistentState.Blocks) {
+		log.Printf("Persistent state references %d blocks, of which only the first %d match the layout of the block device. The remaining blocks will be discarded upon startup.", len(persistentState.Blocks), restoredBlockCount)
+	}
+
+	// Check all entries in the key-location map. Entries that are
+	// out of epoch, have a checksum mismatch or are misplaced are
+	// reported, but not considered to be bad. LocalBlobAccess
+	// already ignores these entries, and entries that are out of
+	// epoch may have been written after the persistent state was
+	// last updated. Only dangling entries and entries referring to
+	// corrupted blobs may cause LocalBlobAccess to return bad data.
+	var statusCounts [local.LocationRecordStatusBlobCorrupt + 1]int
+	badEntries := 0
+	for index := 0; index < recordsCount; index++ {
+		status, err := checker.CheckLocationRecord(index)
+		if err != nil {
+			log.Fatal(err)
+		}
+		statusCounts[status]++
+		switch status {
+		case local.LocationRecordStatusOutOfEpoch, local.LocationRecordStatusChecksumMismatch, local.LocationRecordStatusMisplaced:
+			log.Printf("Entry %d: %s (ignored by bb_storage)", index, status)
+		case local.LocationRecordStatusDangling, local.LocationRecordStatusBlobCorrupt:
+			log.Printf("Entry %d: %s", index, status)
+			badEntries++
+			if configuration.InvalidateBadEntries {
+				if err := local.InvalidateBlockDeviceBackedLocationRecord(keyLocationMapDevice, index); err != nil {
+					log.Fatalf("Failed to invalidate entry %d: %s", index, err)
+				}
+			}
+		}
+	}
+	if configuration.InvalidateBadEntries && badEntries > 0 {
+		if err := keyLocationMapDevice.Sync(); err != nil {
+			log.Fatal("Failed to synchronize key-location map block device: ", err)
+		}
+	}
+
+	log.Printf("Checked %d entries in the key-location map:", recordsCount)
+	for status, count := range statusCounts {
+		log.Printf("  %s: %d", local.LocationRecordStatus(status), count)
+	}
+	if badEntries > 0 && !configuration.InvalidateBadEntries {
+		os.Exit(1)
+	}
+}
+
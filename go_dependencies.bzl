@@ -125,8 +125,8 @@ def go_dependencies():
         name = "com_github_bazelbuild_remote_apis",
         importpath = "github.com/bazelbuild/remote-apis",
         patches = ["@com_github_buildbarn_bb_storage//:patches/com_github_bazelbuild_remote_apis/golang.diff"],
-        sum = "h1:2GUS7QocpkOOone/q7st8Jo0QE2Q9fkw1mMisD7EJl0=",
-        version = "v0.0.0-20211004185116-636121a32fa7",
+        sum = "h1:Lj8uXWW95oXyYguUSdQDvzywQb4f0jbJWsoLPQWAKTY=",
+        version = "v0.0.0-20230411132548-35aee1c4a425",
     )
     go_repository(
         name = "com_github_beorn7_perks",
@@ -412,6 +412,18 @@ def go_dependencies():
         sum = "h1:P76CopJELS0TiO2mebmnzgWaajssP/EszplttgQxcgc=",
         version = "v1.13.6",
     )
+    go_repository(
+        name = "com_github_klauspost_cpuid",
+        importpath = "github.com/klauspost/cpuid",
+        sum = "h1:5JNjFYYQrZeKRJ0734q51WCEEn2huer72Dc7K+R/b6s=",
+        version = "v1.3.1",
+    )
+    go_repository(
+        name = "com_github_klauspost_reedsolomon",
+        importpath = "github.com/klauspost/reedsolomon",
+        sum = "h1:N/VzgeMfHmLc+KHMD1UL/tNkfXAt8FnUqlgXGIduwAY=",
+        version = "v1.9.3",
+    )
     go_repository(
         name = "com_github_konsorten_go_windows_terminal_sequences",
         importpath = "github.com/konsorten/go-windows-terminal-sequences",
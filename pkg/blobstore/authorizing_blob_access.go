@@ -3,10 +3,14 @@ package blobstore
 import (
 	"context"
 
+	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
 	"github.com/buildbarn/bb-storage/pkg/auth"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
 	"github.com/buildbarn/bb-storage/pkg/digest"
 	"github.com/buildbarn/bb-storage/pkg/util"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type authorizingBlobAccess struct {
@@ -59,3 +63,29 @@ func (ba *authorizingBlobAccess) FindMissing(ctx context.Context, digests digest
 	}
 	return ba.BlobAccess.FindMissing(ctx, digests)
 }
+
+func (ba *authorizingBlobAccess) GetCapabilities(ctx context.Context, instanceName digest.InstanceName) (*remoteexecution.ServerCapabilities, error) {
+	if err := auth.AuthorizeSingleInstanceName(ctx, ba.getAuthorizer, instanceName); err != nil {
+		return nil, util.StatusWrap(err, "Authorization")
+	}
+	capabilities, err := ba.BlobAccess.GetCapabilities(ctx, instanceName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only announce that the Action Cache permits updates if the
+	// client is actually permitted to write into it.
+	if cacheCapabilities := capabilities.CacheCapabilities; cacheCapabilities.GetActionCacheUpdateCapabilities().GetUpdateEnabled() {
+		switch err := auth.AuthorizeSingleInstanceName(ctx, ba.putAuthorizer, instanceName); status.Code(err) {
+		case codes.OK:
+			// Nothing to do.
+		case codes.PermissionDenied:
+			cacheCapabilities.ActionCacheUpdateCapabilities = &remoteexecution.ActionCacheUpdateCapabilities{
+				UpdateEnabled: false,
+			}
+		default:
+			return nil, util.StatusWrap(err, "Authorization")
+		}
+	}
+	return capabilities, nil
+}
@@ -7,6 +7,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
 	"github.com/buildbarn/bb-storage/internal/mock"
 	"github.com/buildbarn/bb-storage/pkg/blobstore"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
@@ -89,4 +90,57 @@ func TestAuthorizingBlobAccess(t *testing.T) {
 		_, err := ba.FindMissing(ctx, digests)
 		testutil.RequireEqualStatus(t, status.Error(codes.PermissionDenied, "Authorization of instance name \"bop/bip\": You shall not pass"), err)
 	})
+	t.Run("GetCapabilities-Denied", func(t *testing.T) {
+		getAuthorizer.EXPECT().Authorize(ctx, beepSlice).Return([]error{status.Error(codes.PermissionDenied, "You shall not pass")})
+
+		_, err := ba.GetCapabilities(ctx, beep)
+		testutil.RequireEqualStatus(t, status.Error(codes.PermissionDenied, "Authorization: You shall not pass"), err)
+	})
+
+	t.Run("GetCapabilities-UpdateAllowed", func(t *testing.T) {
+		getAuthorizer.EXPECT().Authorize(ctx, beepSlice).Return([]error{nil})
+		baseBlobAccess.EXPECT().GetCapabilities(ctx, beep).Return(&remoteexecution.ServerCapabilities{
+			CacheCapabilities: &remoteexecution.CacheCapabilities{
+				ActionCacheUpdateCapabilities: &remoteexecution.ActionCacheUpdateCapabilities{
+					UpdateEnabled: true,
+				},
+			},
+		}, nil)
+		putAuthorizer.EXPECT().Authorize(ctx, beepSlice).Return([]error{nil})
+
+		capabilities, err := ba.GetCapabilities(ctx, beep)
+		require.NoError(t, err)
+		testutil.RequireEqualProto(t, &remoteexecution.ServerCapabilities{
+			CacheCapabilities: &remoteexecution.CacheCapabilities{
+				ActionCacheUpdateCapabilities: &remoteexecution.ActionCacheUpdateCapabilities{
+					UpdateEnabled: true,
+				},
+			},
+		}, capabilities)
+	})
+
+	t.Run("GetCapabilities-UpdateDenied", func(t *testing.T) {
+		// Clients that are not permitted to write into the
+		// Action Cache should be informed that updates are
+		// disabled.
+		getAuthorizer.EXPECT().Authorize(ctx, beepSlice).Return([]error{nil})
+		baseBlobAccess.EXPECT().GetCapabilities(ctx, beep).Return(&remoteexecution.ServerCapabilities{
+			CacheCapabilities: &remoteexecution.CacheCapabilities{
+				ActionCacheUpdateCapabilities: &remoteexecution.ActionCacheUpdateCapabilities{
+					UpdateEnabled: true,
+				},
+			},
+		}, nil)
+		putAuthorizer.EXPECT().Authorize(ctx, beepSlice).Return([]error{status.Error(codes.PermissionDenied, "You shall not pass")})
+
+		capabilities, err := ba.GetCapabilities(ctx, beep)
+		require.NoError(t, err)
+		testutil.RequireEqualProto(t, &remoteexecution.ServerCapabilities{
+			CacheCapabilities: &remoteexecution.CacheCapabilities{
+				ActionCacheUpdateCapabilities: &remoteexecution.ActionCacheUpdateCapabilities{
+					UpdateEnabled: false,
+				},
+			},
+		}, capabilities)
+	})
 }
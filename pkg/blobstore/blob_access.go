@@ -4,13 +4,21 @@ import (
 	"context"
 
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/buildbarn/bb-storage/pkg/capabilities"
 	"github.com/buildbarn/bb-storage/pkg/digest"
 )
 
 // BlobAccess is an abstraction for a data store that can be used to
 // hold both a Bazel Action Cache (AC) and Content Addressable Storage
 // (CAS).
+//
+// Implementations also report the REv2 cache capabilities they
+// support, so that the Capabilities service can announce features
+// such as the supported digest functions based on the storage backends
+// that are actually configured.
 type BlobAccess interface {
+	capabilities.Provider
+
 	Get(ctx context.Context, digest digest.Digest) buffer.Buffer
 	Put(ctx context.Context, digest digest.Digest, b buffer.Buffer) error
 	FindMissing(ctx context.Context, digests digest.Set) (digest.Set, error)
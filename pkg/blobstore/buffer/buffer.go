@@ -55,7 +55,12 @@ type Buffer interface {
 	// Read the contents of the buffer, starting at a given offset,
 	// as a stream of byte slices. Normally used by the Content
 	// Addressable Storage.
-	ToChunkReader(off int64, maximumChunkSizeBytes int) ChunkReader
+	//
+	// If limit is non-zero, at most limit bytes are returned. For
+	// buffers backed by streams, this permits the transfer to be
+	// terminated early. In that case the contents of the buffer
+	// cannot be validated against its checksum.
+	ToChunkReader(off, limit int64, maximumChunkSizeBytes int) ChunkReader
 	// Obtain a reader that returns the entire contents of the
 	// buffer.
 	ToReader() io.ReadCloser
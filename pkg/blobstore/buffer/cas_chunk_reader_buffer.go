@@ -48,12 +48,18 @@ func (b *casChunkReaderBuffer) ToByteSlice(maximumSizeBytes int) ([]byte, error)
 	return toByteSliceViaChunkReader(b.toValidatedChunkReader(), b.digest, maximumSizeBytes)
 }
 
-func (b *casChunkReaderBuffer) ToChunkReader(off int64, maximumChunkSizeBytes int) ChunkReader {
+func (b *casChunkReaderBuffer) ToChunkReader(off, limit int64, maximumChunkSizeBytes int) ChunkReader {
 	if err := validateReaderOffset(b.digest.GetSizeBytes(), off); err != nil {
 		b.Discard()
 		return newErrorChunkReader(err)
 	}
-	return newNormalizingChunkReader(newOffsetChunkReader(b.toValidatedChunkReader(), off), maximumChunkSizeBytes)
+	if err := validateReaderLimit(limit); err != nil {
+		b.Discard()
+		return newErrorChunkReader(err)
+	}
+	return newLimitedChunkReader(
+		newNormalizingChunkReader(newOffsetChunkReader(b.toValidatedChunkReader(), off), maximumChunkSizeBytes),
+		limit)
 }
 
 func (b *casChunkReaderBuffer) ToReader() io.ReadCloser {
@@ -0,0 +1,134 @@
+package buffer
+
+import (
+	"io"
+
+	"github.com/buildbarn/bb-storage/pkg/digest"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ChunkReaderFactory is a callback that is used by
+// NewCASBufferFromChunkReaderFactory() to open a ChunkReader for a
+// range of an object. A limit of zero indicates that all data up to
+// the end of the object should be returned.
+type ChunkReaderFactory func(off, limit int64) (ChunkReader, error)
+
+type casChunkReaderFactoryBuffer struct {
+	digest  digest.Digest
+	factory ChunkReaderFactory
+	source  Source
+}
+
+// NewCASBufferFromChunkReaderFactory creates a buffer for an object
+// stored in the Content Addressable Storage, whose contents may be
+// obtained by opening a ChunkReader for a given range of the object.
+//
+// Unlike NewCASBufferFromChunkReader(), the ChunkReader is only opened
+// once the contents of the buffer are accessed. This permits calls to
+// ToChunkReader() to only transfer the part of the object that is
+// requested, which includes reads that are resumed at a non-zero
+// offset. As checksum validation can only be performed when the object
+// is read in its entirety, partial reads are not validated. Reads of
+// the full object are validated.
+func NewCASBufferFromChunkReaderFactory(digest digest.Digest, factory ChunkReaderFactory, source Source) Buffer {
+	return &casChunkReaderFactoryBuffer{
+		digest:  digest,
+		factory: factory,
+		source:  source,
+	}
+}
+
+func (b *casChunkReaderFactoryBuffer) openChunkReader(off, limit int64) ChunkReader {
+	r, err := b.factory(off, limit)
+	if err != nil {
+		return newErrorChunkReader(err)
+	}
+	return r
+}
+
+// toBuffer opens a ChunkReader for the entire object, and converts it
+// to a buffer that performs checksum validation.
+func (b *casChunkReaderFactoryBuffer) toBuffer() Buffer {
+	r, err := b.factory(0, 0)
+	if err != nil {
+		return NewBufferFromError(err)
+	}
+	return NewCASBufferFromChunkReader(b.digest, r, b.source)
+}
+
+func (b *casChunkReaderFactoryBuffer) GetSizeBytes() (int64, error) {
+	return b.digest.GetSizeBytes(), nil
+}
+
+func (b *casChunkReaderFactoryBuffer) IntoWriter(w io.Writer) error {
+	return b.toBuffer().IntoWriter(w)
+}
+
+func (b *casChunkReaderFactoryBuffer) ReadAt(p []byte, off int64) (int, error) {
+	return b.toBuffer().ReadAt(p, off)
+}
+
+func (b *casChunkReaderFactoryBuffer) ToProto(m proto.Message, maximumSizeBytes int) (proto.Message, error) {
+	return toProtoViaByteSlice(b, m, maximumSizeBytes)
+}
+
+func (b *casChunkReaderFactoryBuffer) ToByteSlice(maximumSizeBytes int) ([]byte, error) {
+	return b.toBuffer().ToByteSlice(maximumSizeBytes)
+}
+
+func (b *casChunkReaderFactoryBuffer) ToChunkReader(off, limit int64, maximumChunkSizeBytes int) ChunkReader {
+	sizeBytes := b.digest.GetSizeBytes()
+	if err := validateReaderOffset(sizeBytes, off); err != nil {
+		return newErrorChunkReader(err)
+	}
+	if err := validateReaderLimit(limit); err != nil {
+		return newErrorChunkReader(err)
+	}
+	if off == 0 && (limit == 0 || limit >= sizeBytes) {
+		// The object is requested in its entirety. Read it
+		// with checksum validation enabled.
+		return b.toBuffer().ToChunkReader(off, limit, maximumChunkSizeBytes)
+	}
+
+	// Only part of the object is requested. Only transfer that
+	// part, at the cost of not being able to validate the data.
+	if limit == 0 || limit > sizeBytes-off {
+		limit = sizeBytes - off
+	}
+	return newLimitedChunkReader(
+		newNormalizingChunkReader(b.openChunkReader(off, limit), maximumChunkSizeBytes),
+		limit)
+}
+
+func (b *casChunkReaderFactoryBuffer) ToReader() io.ReadCloser {
+	return b.toBuffer().ToReader()
+}
+
+func (b *casChunkReaderFactoryBuffer) CloneCopy(maximumSizeBytes int) (Buffer, Buffer) {
+	return b.toBuffer().CloneCopy(maximumSizeBytes)
+}
+
+func (b *casChunkReaderFactoryBuffer) CloneStream() (Buffer, Buffer) {
+	return b.toBuffer().CloneStream()
+}
+
+func (b *casChunkReaderFactoryBuffer) Discard() {
+	// No ChunkReader has been opened yet. There is nothing to
+	// release.
+}
+
+func (b *casChunkReaderFactoryBuffer) applyErrorHandler(errorHandler ErrorHandler) (Buffer, bool) {
+	// For stream-backed buffers, it is not yet known whether they
+	// may be read successfully. Wrap the buffer into one that
+	// handles I/O errors upon access.
+	return newCASErrorHandlingBuffer(b, errorHandler, b.digest, b.source), false
+}
+
+func (b *casChunkReaderFactoryBuffer) toUnvalidatedChunkReader(off int64, maximumChunkSizeBytes int) ChunkReader {
+	return newNormalizingChunkReader(b.openChunkReader(off, 0), maximumChunkSizeBytes)
+}
+
+func (b *casChunkReaderFactoryBuffer) toUnvalidatedReader(off int64) io.ReadCloser {
+	return newChunkReaderBackedReader(b.openChunkReader(off, 0))
+}
@@ -64,7 +64,7 @@ func (b *casClonedBuffer) toChunkReader(needsValidation bool, maximumChunkSizeBy
 		// validation, we use checksum validation for everyone.
 		var r ChunkReader
 		if b.needsValidation {
-			r = b.base.ToChunkReader(0, b.maximumChunkSizeBytes)
+			r = b.base.ToChunkReader(0, 0, b.maximumChunkSizeBytes)
 		} else {
 			r = b.base.toUnvalidatedChunkReader(0, b.maximumChunkSizeBytes)
 		}
@@ -103,8 +103,12 @@ func (b *casClonedBuffer) ToByteSlice(maximumSizeBytes int) ([]byte, error) {
 	return toByteSliceViaChunkReader(b.toChunkReader(true, defaultChunkSizeBytes), b.digest, maximumSizeBytes)
 }
 
-func (b *casClonedBuffer) ToChunkReader(off int64, maximumChunkSizeBytes int) ChunkReader {
-	return newOffsetChunkReader(b.toChunkReader(true, maximumChunkSizeBytes), off)
+func (b *casClonedBuffer) ToChunkReader(off, limit int64, maximumChunkSizeBytes int) ChunkReader {
+	if err := validateReaderLimit(limit); err != nil {
+		b.Discard()
+		return newErrorChunkReader(err)
+	}
+	return newLimitedChunkReader(newOffsetChunkReader(b.toChunkReader(true, maximumChunkSizeBytes), off), limit)
 }
 
 func (b *casClonedBuffer) ToReader() io.ReadCloser {
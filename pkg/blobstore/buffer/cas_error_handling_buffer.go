@@ -95,12 +95,16 @@ func (b *casErrorHandlingBuffer) ToByteSlice(maximumSizeBytes int) (data []byte,
 	return
 }
 
-func (b *casErrorHandlingBuffer) ToChunkReader(off int64, maximumChunkSizeBytes int) ChunkReader {
+func (b *casErrorHandlingBuffer) ToChunkReader(off, limit int64, maximumChunkSizeBytes int) ChunkReader {
 	if err := validateReaderOffset(b.digest.GetSizeBytes(), off); err != nil {
 		b.Discard()
 		return newErrorChunkReader(err)
 	}
-	return newOffsetChunkReader(b.toValidatedChunkReader(maximumChunkSizeBytes), off)
+	if err := validateReaderLimit(limit); err != nil {
+		b.Discard()
+		return newErrorChunkReader(err)
+	}
+	return newLimitedChunkReader(newOffsetChunkReader(b.toValidatedChunkReader(maximumChunkSizeBytes), off), limit)
 }
 
 func (b *casErrorHandlingBuffer) ToReader() io.ReadCloser {
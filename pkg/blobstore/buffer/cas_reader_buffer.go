@@ -84,18 +84,22 @@ func (b *casReaderBuffer) ToByteSlice(maximumSizeBytes int) ([]byte, error) {
 	return ioutil.ReadAll(r)
 }
 
-func (b *casReaderBuffer) ToChunkReader(off int64, maximumChunkSizeBytes int) ChunkReader {
+func (b *casReaderBuffer) ToChunkReader(off, limit int64, maximumChunkSizeBytes int) ChunkReader {
 	if err := validateReaderOffset(b.digest.GetSizeBytes(), off); err != nil {
 		b.r.Close()
 		return newErrorChunkReader(err)
 	}
+	if err := validateReaderLimit(limit); err != nil {
+		b.r.Close()
+		return newErrorChunkReader(err)
+	}
 
 	r := b.toValidatedReader()
 	if err := discardFromReader(r, off); err != nil {
 		r.Close()
 		return newErrorChunkReader(err)
 	}
-	return newReaderBackedChunkReader(r, maximumChunkSizeBytes)
+	return newLimitedChunkReader(newReaderBackedChunkReader(r, maximumChunkSizeBytes), limit)
 }
 
 func (b *casReaderBuffer) ToReader() io.ReadCloser {
@@ -27,3 +27,12 @@ func validateReaderOffset(length, requested int64) error {
 	}
 	return nil
 }
+
+// validateReaderLimit is used by ToChunkReader() to validate the limit
+// that is provided. Zero indicates that no limit should be applied.
+func validateReaderLimit(limit int64) error {
+	if limit < 0 {
+		return status.Errorf(codes.InvalidArgument, "Negative read limit: %d", limit)
+	}
+	return nil
+}
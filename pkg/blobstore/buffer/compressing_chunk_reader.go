@@ -0,0 +1,115 @@
+package buffer
+
+import (
+	"bytes"
+	"compress/flate"
+	"io"
+
+	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
+	"github.com/buildbarn/bb-storage/pkg/util"
+	"github.com/klauspost/compress/zstd"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SupportedCompressors is the list of compression algorithms that are
+// supported by NewCompressingChunkReader() and
+// NewCASBufferFromCompressedChunkReader(), excluding IDENTITY. As
+// compression and decompression are performed on the fly, these
+// algorithms can be offered to clients, regardless of the storage
+// backend that is used.
+var SupportedCompressors = []remoteexecution.Compressor_Value{
+	remoteexecution.Compressor_ZSTD,
+	remoteexecution.Compressor_DEFLATE,
+}
+
+type compressingChunkReader struct {
+	r                     ChunkReader
+	maximumChunkSizeBytes int
+
+	encoder    io.WriteCloser
+	compressed bytes.Buffer
+	finished   bool
+}
+
+// NewCompressingChunkReader creates a decorator for ChunkReader that
+// compresses all data returned by the underlying ChunkReader, using
+// one of the compression algorithms that may be used by the
+// "compressed-blobs" ByteStream resources of REv2. Chunks returned by
+// this ChunkReader are at most maximumChunkSizeBytes in size.
+//
+// Compression is performed on the fly, meaning that only a small
+// amount of compressed data is buffered at any point in time.
+func NewCompressingChunkReader(r ChunkReader, compressor remoteexecution.Compressor_Value, maximumChunkSizeBytes int) ChunkReader {
+	if compressor == remoteexecution.Compressor_IDENTITY {
+		return newNormalizingChunkReader(r, maximumChunkSizeBytes)
+	}
+
+	cr := &compressingChunkReader{
+		r:                     r,
+		maximumChunkSizeBytes: maximumChunkSizeBytes,
+	}
+	switch compressor {
+	case remoteexecution.Compressor_ZSTD:
+		// Disable concurrency, as the default is to use
+		// GOMAXPROCS. We should just use a single thread,
+		// because many BlobAccess operations may run in
+		// parallel.
+		encoder, err := zstd.NewWriter(&cr.compressed, zstd.WithEncoderConcurrency(1), zstd.WithLowerEncoderMem(true))
+		if err != nil {
+			r.Close()
+			return newErrorChunkReader(util.StatusWrapWithCode(err, codes.Internal, "Failed to create Zstandard encoder"))
+		}
+		cr.encoder = encoder
+	case remoteexecution.Compressor_DEFLATE:
+		encoder, err := flate.NewWriter(&cr.compressed, flate.DefaultCompression)
+		if err != nil {
+			r.Close()
+			return newErrorChunkReader(util.StatusWrapWithCode(err, codes.Internal, "Failed to create DEFLATE encoder"))
+		}
+		cr.encoder = encoder
+	default:
+		r.Close()
+		return newErrorChunkReader(status.Errorf(codes.Unimplemented, "Unsupported compressor: %s", compressor))
+	}
+	return cr
+}
+
+func (r *compressingChunkReader) Read() ([]byte, error) {
+	// Feed data into the encoder until it yields output.
+	for r.compressed.Len() == 0 {
+		if r.finished {
+			return nil, io.EOF
+		}
+		chunk, err := r.r.Read()
+		if err == io.EOF {
+			// Flush any data buffered by the encoder.
+			if err := r.encoder.Close(); err != nil {
+				return nil, util.StatusWrapWithCode(err, codes.Internal, "Failed to finalize compressed stream")
+			}
+			r.finished = true
+		} else if err != nil {
+			return nil, err
+		} else if _, err := r.encoder.Write(chunk); err != nil {
+			return nil, util.StatusWrapWithCode(err, codes.Internal, "Failed to compress data")
+		}
+	}
+
+	// Return a copy of the compressed data, as the slice returned
+	// by Next() is invalidated by subsequent calls to the encoder.
+	n := r.compressed.Len()
+	if n > r.maximumChunkSizeBytes {
+		n = r.maximumChunkSizeBytes
+	}
+	chunk := make([]byte, n)
+	copy(chunk, r.compressed.Next(n))
+	return chunk, nil
+}
+
+func (r *compressingChunkReader) Close() {
+	if !r.finished {
+		r.encoder.Close()
+	}
+	r.r.Close()
+}
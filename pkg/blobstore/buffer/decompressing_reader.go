@@ -0,0 +1,102 @@
+package buffer
+
+import (
+	"compress/flate"
+	"io"
+
+	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/util"
+	"github.com/klauspost/compress/zstd"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewCASBufferFromCompressedChunkReader creates a buffer for an object
+// stored in the Content Addressable Storage, backed by a ChunkReader
+// that yields data in compressed form. Data is decompressed on the fly,
+// and is validated against the digest of the uncompressed object.
+//
+// This function may be used to process the "compressed-blobs"
+// ByteStream resources of REv2.
+func NewCASBufferFromCompressedChunkReader(digest digest.Digest, r ChunkReader, compressor remoteexecution.Compressor_Value, source Source) Buffer {
+	if compressor == remoteexecution.Compressor_IDENTITY {
+		return NewCASBufferFromChunkReader(digest, r, source)
+	}
+	decompressedReader, err := newDecompressingReader(newChunkReaderBackedReader(r), compressor, source)
+	if err != nil {
+		return NewBufferFromError(err)
+	}
+	return NewCASBufferFromReader(digest, decompressedReader, source)
+}
+
+// errorRecordingReader is a decorator for io.ReadCloser that keeps
+// track of the last error returned by the underlying stream. This
+// allows decompressingReader to distinguish I/O errors from errors
+// caused by corrupted compressed data.
+type errorRecordingReader struct {
+	io.ReadCloser
+	err error
+}
+
+func (r *errorRecordingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if err != nil && err != io.EOF {
+		r.err = err
+	}
+	return n, err
+}
+
+type decompressingReader struct {
+	decoder          io.Reader
+	closeDecoder     func()
+	compressedReader *errorRecordingReader
+	source           Source
+}
+
+// newDecompressingReader creates a decorator for io.ReadCloser that
+// decompresses its contents. Errors caused by the compressed data
+// being malformed are reported to the Source, so that corrupted
+// objects may be repaired.
+func newDecompressingReader(r io.ReadCloser, compressor remoteexecution.Compressor_Value, source Source) (io.ReadCloser, error) {
+	dr := &decompressingReader{
+		compressedReader: &errorRecordingReader{ReadCloser: r},
+		source:           source,
+	}
+	switch compressor {
+	case remoteexecution.Compressor_ZSTD:
+		// Disable concurrency, as the default is to use
+		// GOMAXPROCS. We should just use a single thread,
+		// because many BlobAccess operations may run in
+		// parallel.
+		decoder, err := zstd.NewReader(dr.compressedReader, zstd.WithDecoderConcurrency(1), zstd.WithDecoderLowmem(true))
+		if err != nil {
+			r.Close()
+			return nil, util.StatusWrapWithCode(err, codes.Internal, "Failed to create Zstandard decoder")
+		}
+		dr.decoder = decoder
+		dr.closeDecoder = decoder.Close
+	case remoteexecution.Compressor_DEFLATE:
+		decoder := flate.NewReader(dr.compressedReader)
+		dr.decoder = decoder
+		dr.closeDecoder = func() { decoder.Close() }
+	default:
+		r.Close()
+		return nil, status.Errorf(codes.Unimplemented, "Unsupported compressor: %s", compressor)
+	}
+	return dr, nil
+}
+
+func (r *decompressingReader) Read(p []byte) (int, error) {
+	n, err := r.decoder.Read(p)
+	if err != nil && err != io.EOF && err != r.compressedReader.err {
+		return n, r.source.notifyCASDecompressionFailure(err)
+	}
+	return n, err
+}
+
+func (r *decompressingReader) Close() error {
+	r.closeDecoder()
+	return r.compressedReader.Close()
+}
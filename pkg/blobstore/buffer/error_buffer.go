@@ -38,7 +38,7 @@ func (b errorBuffer) ToByteSlice(maximumSizeBytes int) ([]byte, error) {
 	return nil, b.err
 }
 
-func (b errorBuffer) ToChunkReader(off int64, maximumChunkSizeBytes int) ChunkReader {
+func (b errorBuffer) ToChunkReader(off, limit int64, maximumChunkSizeBytes int) ChunkReader {
 	return newErrorChunkReader(b.err)
 }
 
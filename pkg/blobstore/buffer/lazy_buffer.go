@@ -0,0 +1,117 @@
+package buffer
+
+import (
+	"io"
+
+	"google.golang.org/protobuf/proto"
+)
+
+type lazyBuffer struct {
+	factory       func() Buffer
+	errorHandlers []ErrorHandler
+	resolved      Buffer
+}
+
+// NewLazyBuffer creates a Buffer whose contents are only obtained by
+// calling a factory function once the buffer is accessed. This permits
+// implementations of BlobAccess to defer work that may block, such as
+// waiting for data to arrive, until the point where the caller
+// actually needs the data.
+//
+// The factory function is called at most once. It is not called at all
+// if the buffer is discarded without being accessed.
+func NewLazyBuffer(factory func() Buffer) Buffer {
+	return &lazyBuffer{
+		factory: factory,
+	}
+}
+
+// get calls into the factory function to obtain the underlying buffer,
+// and applies any error handlers that were attached in the meantime.
+func (b *lazyBuffer) get() Buffer {
+	if b.resolved == nil {
+		resolved := b.factory()
+		for _, errorHandler := range b.errorHandlers {
+			resolved = WithErrorHandler(resolved, errorHandler)
+		}
+		b.resolved = resolved
+		b.factory = nil
+		b.errorHandlers = nil
+	}
+	return b.resolved
+}
+
+func (b *lazyBuffer) GetSizeBytes() (int64, error) {
+	return b.get().GetSizeBytes()
+}
+
+func (b *lazyBuffer) IntoWriter(w io.Writer) error {
+	return b.get().IntoWriter(w)
+}
+
+func (b *lazyBuffer) ReadAt(p []byte, off int64) (int, error) {
+	return b.get().ReadAt(p, off)
+}
+
+func (b *lazyBuffer) ToProto(m proto.Message, maximumSizeBytes int) (proto.Message, error) {
+	return b.get().ToProto(m, maximumSizeBytes)
+}
+
+func (b *lazyBuffer) ToByteSlice(maximumSizeBytes int) ([]byte, error) {
+	return b.get().ToByteSlice(maximumSizeBytes)
+}
+
+func (b *lazyBuffer) ToChunkReader(off, limit int64, maximumChunkSizeBytes int) ChunkReader {
+	return b.get().ToChunkReader(off, limit, maximumChunkSizeBytes)
+}
+
+func (b *lazyBuffer) ToReader() io.ReadCloser {
+	return b.get().ToReader()
+}
+
+func (b *lazyBuffer) CloneCopy(maximumSizeBytes int) (Buffer, Buffer) {
+	return b.get().CloneCopy(maximumSizeBytes)
+}
+
+func (b *lazyBuffer) CloneStream() (Buffer, Buffer) {
+	return b.get().CloneStream()
+}
+
+func (b *lazyBuffer) Discard() {
+	if b.resolved != nil {
+		b.resolved.Discard()
+		return
+	}
+
+	// The buffer was never accessed. There is no need to call into
+	// the factory function. Terminate any attached error handlers.
+	for _, errorHandler := range b.errorHandlers {
+		errorHandler.Done()
+	}
+	b.factory = nil
+	b.errorHandlers = nil
+}
+
+func (b *lazyBuffer) applyErrorHandler(errorHandler ErrorHandler) (Buffer, bool) {
+	if b.resolved != nil {
+		// The buffer has already been resolved. Let the error
+		// handler be applied to the underlying buffer.
+		return b.resolved, true
+	}
+
+	// Defer attaching the error handler until the buffer is
+	// resolved, as doing so may cause the error handler to be
+	// invoked immediately.
+	return &lazyBuffer{
+		factory:       b.factory,
+		errorHandlers: append(append([]ErrorHandler(nil), b.errorHandlers...), errorHandler),
+	}, false
+}
+
+func (b *lazyBuffer) toUnvalidatedChunkReader(off int64, maximumChunkSizeBytes int) ChunkReader {
+	return b.get().toUnvalidatedChunkReader(off, maximumChunkSizeBytes)
+}
+
+func (b *lazyBuffer) toUnvalidatedReader(off int64) io.ReadCloser {
+	return b.get().toUnvalidatedReader(off)
+}
@@ -0,0 +1,71 @@
+package buffer_test
+
+import (
+	"testing"
+
+	"github.com/buildbarn/bb-storage/internal/mock"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewLazyBuffer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	t.Run("ToByteSlice", func(t *testing.T) {
+		// The factory function should only be called once,
+		// even if multiple operations are performed.
+		calls := 0
+		b := buffer.NewLazyBuffer(func() buffer.Buffer {
+			calls++
+			return buffer.NewValidatedBufferFromByteSlice([]byte("Hello"))
+		})
+		require.Equal(t, 0, calls)
+
+		sizeBytes, err := b.GetSizeBytes()
+		require.NoError(t, err)
+		require.Equal(t, int64(5), sizeBytes)
+		data, err := b.ToByteSlice(100)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("Discard", func(t *testing.T) {
+		// Discarding the buffer without accessing it should
+		// not cause the factory function to be called. Error
+		// handlers should still be terminated.
+		errorHandler := mock.NewMockErrorHandler(ctrl)
+		errorHandler.EXPECT().Done()
+
+		b := buffer.WithErrorHandler(
+			buffer.NewLazyBuffer(func() buffer.Buffer {
+				t.Fatal("Factory function should not be called")
+				return nil
+			}),
+			errorHandler)
+		b.Discard()
+	})
+
+	t.Run("ErrorHandler", func(t *testing.T) {
+		// Error handlers should only be applied once the
+		// buffer is resolved.
+		errorHandler := mock.NewMockErrorHandler(ctrl)
+		b := buffer.WithErrorHandler(
+			buffer.NewLazyBuffer(func() buffer.Buffer {
+				return buffer.NewBufferFromError(status.Error(codes.NotFound, "Object not found"))
+			}),
+			errorHandler)
+
+		errorHandler.EXPECT().OnError(status.Error(codes.NotFound, "Object not found")).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello")), nil)
+		errorHandler.EXPECT().Done()
+
+		data, err := b.ToByteSlice(100)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+	})
+}
@@ -0,0 +1,41 @@
+package buffer
+
+import (
+	"io"
+)
+
+type limitedChunkReader struct {
+	ChunkReader
+	bytesRemaining int64
+}
+
+// newLimitedChunkReader creates a decorator for ChunkReader that
+// returns at most a given number of bytes. Once the limit has been
+// reached, io.EOF is returned without reading any further data from
+// the underlying ChunkReader. A limit of zero indicates that no limit
+// should be applied, which is consistent with the ReadLimit field of
+// the ByteStream protocol.
+func newLimitedChunkReader(r ChunkReader, limit int64) ChunkReader {
+	if limit == 0 {
+		return r
+	}
+	return &limitedChunkReader{
+		ChunkReader:    r,
+		bytesRemaining: limit,
+	}
+}
+
+func (r *limitedChunkReader) Read() ([]byte, error) {
+	if r.bytesRemaining == 0 {
+		return nil, io.EOF
+	}
+	chunk, err := r.ChunkReader.Read()
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(chunk)) > r.bytesRemaining {
+		chunk = chunk[:r.bytesRemaining]
+	}
+	r.bytesRemaining -= int64(len(chunk))
+	return chunk, nil
+}
@@ -41,6 +41,7 @@ func TestNewBufferFromErrorToByteSlice(t *testing.T) {
 func TestNewBufferFromErrorToChunkReader(t *testing.T) {
 	r := buffer.NewBufferFromError(status.Error(codes.Internal, "I/O error")).ToChunkReader(
 		/* offset = */ 12,
+		/* limit = */ 0,
 		/* chunk size = */ 10)
 
 	_, err := r.Read()
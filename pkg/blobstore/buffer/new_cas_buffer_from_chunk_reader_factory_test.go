@@ -0,0 +1,154 @@
+package buffer_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/buildbarn/bb-storage/internal/mock"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/testutil"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewCASBufferFromChunkReaderFactory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	helloDigest := digest.MustNewDigest("foo", "3e25960a79dbc69b674cd4ec67a72c62", 11)
+
+	t.Run("ToByteSlice", func(t *testing.T) {
+		// Reading the object in its entirety should cause the
+		// ChunkReader to be opened without an offset or limit.
+		// Checksum validation should be performed.
+		chunkReader := mock.NewMockChunkReader(ctrl)
+		chunkReader.EXPECT().Read().Return([]byte("Hello world"), nil)
+		chunkReader.EXPECT().Read().Return(nil, io.EOF)
+		chunkReader.EXPECT().Close()
+		dataIntegrityCallback := mock.NewMockDataIntegrityCallback(ctrl)
+		dataIntegrityCallback.EXPECT().Call(true)
+
+		data, err := buffer.NewCASBufferFromChunkReaderFactory(
+			helloDigest,
+			func(off, limit int64) (buffer.ChunkReader, error) {
+				require.Equal(t, int64(0), off)
+				require.Equal(t, int64(0), limit)
+				return chunkReader, nil
+			},
+			buffer.BackendProvided(dataIntegrityCallback.Call)).ToByteSlice(100)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello world"), data)
+	})
+
+	t.Run("ToChunkReaderPartial", func(t *testing.T) {
+		// Partial reads should be forwarded to the factory, so
+		// that only the requested part is transferred.
+		chunkReader := mock.NewMockChunkReader(ctrl)
+		chunkReader.EXPECT().Read().Return([]byte("lo w"), nil)
+		chunkReader.EXPECT().Close()
+		dataIntegrityCallback := mock.NewMockDataIntegrityCallback(ctrl)
+
+		r := buffer.NewCASBufferFromChunkReaderFactory(
+			helloDigest,
+			func(off, limit int64) (buffer.ChunkReader, error) {
+				require.Equal(t, int64(3), off)
+				require.Equal(t, int64(4), limit)
+				return chunkReader, nil
+			},
+			buffer.BackendProvided(dataIntegrityCallback.Call)).ToChunkReader(
+			/* offset = */ 3,
+			/* limit = */ 4,
+			/* chunk size = */ 10)
+		chunk, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, []byte("lo w"), chunk)
+		_, err = r.Read()
+		require.Equal(t, io.EOF, err)
+		r.Close()
+	})
+
+	t.Run("ToChunkReaderUntilEnd", func(t *testing.T) {
+		// Reads that start at a non-zero offset and extend to
+		// the end of the object (e.g., when resuming a
+		// transfer) should also only transfer the part that is
+		// requested.
+		chunkReader := mock.NewMockChunkReader(ctrl)
+		chunkReader.EXPECT().Read().Return([]byte("world"), nil)
+		chunkReader.EXPECT().Close()
+		dataIntegrityCallback := mock.NewMockDataIntegrityCallback(ctrl)
+
+		r := buffer.NewCASBufferFromChunkReaderFactory(
+			helloDigest,
+			func(off, limit int64) (buffer.ChunkReader, error) {
+				require.Equal(t, int64(6), off)
+				require.Equal(t, int64(5), limit)
+				return chunkReader, nil
+			},
+			buffer.BackendProvided(dataIntegrityCallback.Call)).ToChunkReader(
+			/* offset = */ 6,
+			/* limit = */ 0,
+			/* chunk size = */ 10)
+		chunk, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, []byte("world"), chunk)
+		_, err = r.Read()
+		require.Equal(t, io.EOF, err)
+		r.Close()
+	})
+
+	t.Run("ToChunkReaderEntireObject", func(t *testing.T) {
+		// Reads of the entire object should be validated, so
+		// that corruption can be detected.
+		chunkReader := mock.NewMockChunkReader(ctrl)
+		chunkReader.EXPECT().Read().Return([]byte("Hello wrold"), nil)
+		chunkReader.EXPECT().Read().Return(nil, io.EOF)
+		chunkReader.EXPECT().Close()
+		dataIntegrityCallback := mock.NewMockDataIntegrityCallback(ctrl)
+		dataIntegrityCallback.EXPECT().Call(false)
+
+		r := buffer.NewCASBufferFromChunkReaderFactory(
+			helloDigest,
+			func(off, limit int64) (buffer.ChunkReader, error) {
+				require.Equal(t, int64(0), off)
+				require.Equal(t, int64(0), limit)
+				return chunkReader, nil
+			},
+			buffer.BackendProvided(dataIntegrityCallback.Call)).ToChunkReader(
+			/* offset = */ 0,
+			/* limit = */ 0,
+			/* chunk size = */ 10)
+		_, err := r.Read()
+		testutil.RequireEqualStatus(t, status.Error(codes.Internal, "Buffer has checksum e2ce40e8e943037704eb2b37ef98f741, while 3e25960a79dbc69b674cd4ec67a72c62 was expected"), err)
+		r.Close()
+	})
+
+	t.Run("ToChunkReaderFactoryFailure", func(t *testing.T) {
+		r := buffer.NewCASBufferFromChunkReaderFactory(
+			helloDigest,
+			func(off, limit int64) (buffer.ChunkReader, error) {
+				return nil, status.Error(codes.Unavailable, "Server on fire")
+			},
+			buffer.BackendProvided(buffer.Irreparable(helloDigest))).ToChunkReader(
+			/* offset = */ 3,
+			/* limit = */ 0,
+			/* chunk size = */ 10)
+		_, err := r.Read()
+		testutil.RequireEqualStatus(t, status.Error(codes.Unavailable, "Server on fire"), err)
+		r.Close()
+	})
+
+	t.Run("Discard", func(t *testing.T) {
+		// Discarding the buffer should not cause the factory to
+		// be called.
+		buffer.NewCASBufferFromChunkReaderFactory(
+			helloDigest,
+			func(off, limit int64) (buffer.ChunkReader, error) {
+				t.Fatal("Factory should not have been called")
+				return nil, nil
+			},
+			buffer.BackendProvided(buffer.Irreparable(helloDigest))).Discard()
+	})
+}
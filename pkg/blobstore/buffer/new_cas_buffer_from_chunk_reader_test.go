@@ -395,6 +395,7 @@ func TestNewCASBufferFromChunkReaderToChunkReader(t *testing.T) {
 			chunkReader,
 			buffer.BackendProvided(dataIntegrityCallback.Call)).ToChunkReader(
 			/* offset = */ 3,
+			/* limit = */ 0,
 			/* chunk size = */ 2)
 		chunk, err := r.Read()
 		require.NoError(t, err)
@@ -418,6 +419,34 @@ func TestNewCASBufferFromChunkReaderToChunkReader(t *testing.T) {
 		r.Close()
 	})
 
+	t.Run("WithLimit", func(t *testing.T) {
+		// Reads with a limit should terminate the underlying
+		// stream as soon as the limit is reached. As the
+		// object is not read in its entirety, no checksum
+		// validation is performed.
+		chunkReader := mock.NewMockChunkReader(ctrl)
+		chunkReader.EXPECT().Read().Return([]byte("Hello "), nil)
+		chunkReader.EXPECT().Close()
+		dataIntegrityCallback := mock.NewMockDataIntegrityCallback(ctrl)
+
+		r := buffer.NewCASBufferFromChunkReader(
+			helloDigest,
+			chunkReader,
+			buffer.BackendProvided(dataIntegrityCallback.Call)).ToChunkReader(
+			/* offset = */ 1,
+			/* limit = */ 3,
+			/* chunk size = */ 2)
+		chunk, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, []byte("el"), chunk)
+		chunk, err = r.Read()
+		require.NoError(t, err)
+		require.Equal(t, []byte("l"), chunk)
+		_, err = r.Read()
+		require.Equal(t, io.EOF, err)
+		r.Close()
+	})
+
 	t.Run("AtTheEnd", func(t *testing.T) {
 		chunkReader := mock.NewMockChunkReader(ctrl)
 		chunkReader.EXPECT().Read().Return([]byte("Hello world"), nil)
@@ -433,6 +462,7 @@ func TestNewCASBufferFromChunkReaderToChunkReader(t *testing.T) {
 			chunkReader,
 			buffer.BackendProvided(dataIntegrityCallback.Call)).ToChunkReader(
 			/* offset = */ 11,
+			/* limit = */ 0,
 			/* chunk size = */ 2)
 		_, err := r.Read()
 		require.Equal(t, io.EOF, err)
@@ -449,6 +479,7 @@ func TestNewCASBufferFromChunkReaderToChunkReader(t *testing.T) {
 			chunkReader,
 			buffer.BackendProvided(dataIntegrityCallback.Call)).ToChunkReader(
 			/* offset = */ -1,
+			/* limit = */ 0,
 			/* chunk size = */ 2)
 		_, err := r.Read()
 		require.Equal(t, status.Error(codes.InvalidArgument, "Negative read offset: -1"), err)
@@ -465,6 +496,7 @@ func TestNewCASBufferFromChunkReaderToChunkReader(t *testing.T) {
 			chunkReader,
 			buffer.BackendProvided(dataIntegrityCallback.Call)).ToChunkReader(
 			/* offset = */ 12,
+			/* limit = */ 0,
 			/* chunk size = */ 2)
 		_, err := r.Read()
 		require.Equal(t, status.Error(codes.InvalidArgument, "Buffer is 11 bytes in size, while a read at offset 12 was requested"), err)
@@ -487,6 +519,7 @@ func TestNewCASBufferFromChunkReaderToChunkReader(t *testing.T) {
 			chunkReader,
 			buffer.BackendProvided(dataIntegrityCallback.Call)).ToChunkReader(
 			/* offset = */ 0,
+			/* limit = */ 0,
 			/* chunk size = */ 10)
 		chunk, err := r.Read()
 		require.NoError(t, err)
@@ -0,0 +1,227 @@
+package buffer_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/md5"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
+	"github.com/buildbarn/bb-storage/internal/mock"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/testutil"
+	"github.com/golang/mock/gomock"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func compressZstd(t *testing.T, data []byte) []byte {
+	encoder, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	return encoder.EncodeAll(data, nil)
+}
+
+func compressDeflate(t *testing.T, data []byte) []byte {
+	var b bytes.Buffer
+	w, err := flate.NewWriter(&b, flate.BestCompression)
+	require.NoError(t, err)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return b.Bytes()
+}
+
+func TestNewCASBufferFromCompressedChunkReaderToByteSlice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	helloDigest := digest.MustNewDigest("foo", "8b1a9953c4611296a827abf8c47804d7", 5)
+
+	t.Run("Identity", func(t *testing.T) {
+		chunkReader := mock.NewMockChunkReader(ctrl)
+		chunkReader.EXPECT().Read().Return([]byte("Hello"), nil)
+		chunkReader.EXPECT().Read().Return(nil, io.EOF)
+		chunkReader.EXPECT().Close()
+		dataIntegrityCallback := mock.NewMockDataIntegrityCallback(ctrl)
+		dataIntegrityCallback.EXPECT().Call(true)
+
+		data, err := buffer.NewCASBufferFromCompressedChunkReader(
+			helloDigest,
+			chunkReader,
+			remoteexecution.Compressor_IDENTITY,
+			buffer.BackendProvided(dataIntegrityCallback.Call)).ToByteSlice(10)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+	})
+
+	t.Run("ZstdSuccess", func(t *testing.T) {
+		compressed := compressZstd(t, []byte("Hello"))
+		chunkReader := mock.NewMockChunkReader(ctrl)
+		chunkReader.EXPECT().Read().Return(compressed[:3], nil)
+		chunkReader.EXPECT().Read().Return(compressed[3:], nil)
+		chunkReader.EXPECT().Read().Return(nil, io.EOF).AnyTimes()
+		chunkReader.EXPECT().Close()
+		dataIntegrityCallback := mock.NewMockDataIntegrityCallback(ctrl)
+		dataIntegrityCallback.EXPECT().Call(true)
+
+		data, err := buffer.NewCASBufferFromCompressedChunkReader(
+			helloDigest,
+			chunkReader,
+			remoteexecution.Compressor_ZSTD,
+			buffer.BackendProvided(dataIntegrityCallback.Call)).ToByteSlice(10)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+	})
+
+	t.Run("DeflateSuccess", func(t *testing.T) {
+		chunkReader := mock.NewMockChunkReader(ctrl)
+		chunkReader.EXPECT().Read().Return(compressDeflate(t, []byte("Hello")), nil)
+		chunkReader.EXPECT().Read().Return(nil, io.EOF).AnyTimes()
+		chunkReader.EXPECT().Close()
+		dataIntegrityCallback := mock.NewMockDataIntegrityCallback(ctrl)
+		dataIntegrityCallback.EXPECT().Call(true)
+
+		data, err := buffer.NewCASBufferFromCompressedChunkReader(
+			helloDigest,
+			chunkReader,
+			remoteexecution.Compressor_DEFLATE,
+			buffer.BackendProvided(dataIntegrityCallback.Call)).ToByteSlice(10)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+	})
+
+	t.Run("ChecksumFailure", func(t *testing.T) {
+		// Data may be decompressed properly, while its checksum
+		// still doesn't match.
+		chunkReader := mock.NewMockChunkReader(ctrl)
+		chunkReader.EXPECT().Read().Return(compressZstd(t, []byte("Jello")), nil)
+		chunkReader.EXPECT().Read().Return(nil, io.EOF).AnyTimes()
+		chunkReader.EXPECT().Close()
+		dataIntegrityCallback := mock.NewMockDataIntegrityCallback(ctrl)
+		dataIntegrityCallback.EXPECT().Call(false)
+
+		_, err := buffer.NewCASBufferFromCompressedChunkReader(
+			helloDigest,
+			chunkReader,
+			remoteexecution.Compressor_ZSTD,
+			buffer.BackendProvided(dataIntegrityCallback.Call)).ToByteSlice(10)
+		testutil.RequireEqualStatus(t, status.Error(codes.Internal, "Buffer has checksum bedad9eef4de4b391cc5aeb8ddbe6387, while 8b1a9953c4611296a827abf8c47804d7 was expected"), err)
+	})
+
+	t.Run("DecompressionFailure", func(t *testing.T) {
+		chunkReader := mock.NewMockChunkReader(ctrl)
+		chunkReader.EXPECT().Read().Return([]byte("This is not DEFLATE compressed data"), nil)
+		chunkReader.EXPECT().Read().Return(nil, io.EOF).AnyTimes()
+		chunkReader.EXPECT().Close()
+
+		_, err := buffer.NewCASBufferFromCompressedChunkReader(
+			helloDigest,
+			chunkReader,
+			remoteexecution.Compressor_DEFLATE,
+			buffer.UserProvided).ToByteSlice(10)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.Contains(t, status.Convert(err).Message(), "Failed to decompress data: ")
+	})
+
+	t.Run("IOError", func(t *testing.T) {
+		// I/O errors should be propagated as is, without
+		// treating them as data corruption.
+		chunkReader := mock.NewMockChunkReader(ctrl)
+		chunkReader.EXPECT().Read().Return(nil, status.Error(codes.Unavailable, "Server on fire"))
+		chunkReader.EXPECT().Close()
+		dataIntegrityCallback := mock.NewMockDataIntegrityCallback(ctrl)
+
+		_, err := buffer.NewCASBufferFromCompressedChunkReader(
+			helloDigest,
+			chunkReader,
+			remoteexecution.Compressor_DEFLATE,
+			buffer.BackendProvided(dataIntegrityCallback.Call)).ToByteSlice(10)
+		testutil.RequireEqualStatus(t, status.Error(codes.Unavailable, "Server on fire"), err)
+	})
+}
+
+func TestNewCompressingChunkReader(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	data := bytes.Repeat([]byte("Hello world! "), 1000)
+
+	t.Run("Identity", func(t *testing.T) {
+		chunkReader := mock.NewMockChunkReader(ctrl)
+		chunkReader.EXPECT().Read().Return([]byte("Hello"), nil)
+		chunkReader.EXPECT().Read().Return(nil, io.EOF)
+		chunkReader.EXPECT().Close()
+
+		r := buffer.NewCompressingChunkReader(chunkReader, remoteexecution.Compressor_IDENTITY, 3)
+		chunk, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hel"), chunk)
+		chunk, err = r.Read()
+		require.NoError(t, err)
+		require.Equal(t, []byte("lo"), chunk)
+		_, err = r.Read()
+		require.Equal(t, io.EOF, err)
+		r.Close()
+	})
+
+	for _, compressor := range []remoteexecution.Compressor_Value{
+		remoteexecution.Compressor_ZSTD,
+		remoteexecution.Compressor_DEFLATE,
+	} {
+		t.Run(compressor.String(), func(t *testing.T) {
+			// Compress data, and decompress it once more by
+			// feeding it into NewCASBufferFromCompressedChunkReader().
+			chunkReader := mock.NewMockChunkReader(ctrl)
+			chunkReader.EXPECT().Read().Return(data[:5000], nil)
+			chunkReader.EXPECT().Read().Return(data[5000:], nil)
+			chunkReader.EXPECT().Read().Return(nil, io.EOF)
+			chunkReader.EXPECT().Close()
+
+			r := buffer.NewCompressingChunkReader(chunkReader, compressor, 100)
+			var compressed [][]byte
+			for {
+				chunk, err := r.Read()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				require.LessOrEqual(t, len(chunk), 100)
+				compressed = append(compressed, chunk)
+			}
+			r.Close()
+			require.Less(t, len(compressed), len(data)/100)
+
+			decompressingChunkReader := mock.NewMockChunkReader(ctrl)
+			for _, chunk := range compressed {
+				decompressingChunkReader.EXPECT().Read().Return(chunk, nil)
+			}
+			decompressingChunkReader.EXPECT().Read().Return(nil, io.EOF).AnyTimes()
+			decompressingChunkReader.EXPECT().Close()
+
+			dataHash := md5.Sum(data)
+			dataDigest := digest.MustNewDigest("foo", hex.EncodeToString(dataHash[:]), int64(len(data)))
+			decompressed, err := buffer.NewCASBufferFromCompressedChunkReader(
+				dataDigest,
+				decompressingChunkReader,
+				compressor,
+				buffer.UserProvided).ToByteSlice(len(data))
+			require.NoError(t, err)
+			require.Equal(t, data, decompressed)
+		})
+	}
+
+	t.Run("IOError", func(t *testing.T) {
+		chunkReader := mock.NewMockChunkReader(ctrl)
+		chunkReader.EXPECT().Read().Return(nil, status.Error(codes.Internal, "Storage backend on fire"))
+		chunkReader.EXPECT().Close()
+
+		r := buffer.NewCompressingChunkReader(chunkReader, remoteexecution.Compressor_ZSTD, 100)
+		_, err := r.Read()
+		testutil.RequireEqualStatus(t, status.Error(codes.Internal, "Storage backend on fire"), err)
+		r.Close()
+	})
+}
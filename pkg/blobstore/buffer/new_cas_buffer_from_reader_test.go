@@ -347,6 +347,7 @@ func TestNewCASBufferFromReaderToChunkReader(t *testing.T) {
 			reader,
 			buffer.BackendProvided(dataIntegrityCallback.Call)).ToChunkReader(
 			/* offset = */ 3,
+			/* limit = */ 0,
 			/* chunk size = */ 2)
 		chunk, err := r.Read()
 		require.NoError(t, err)
@@ -379,6 +380,7 @@ func TestNewCASBufferFromReaderToChunkReader(t *testing.T) {
 			reader,
 			buffer.BackendProvided(dataIntegrityCallback.Call)).ToChunkReader(
 			/* offset = */ 11,
+			/* limit = */ 0,
 			/* chunk size = */ 2)
 		_, err := r.Read()
 		require.Equal(t, io.EOF, err)
@@ -395,6 +397,7 @@ func TestNewCASBufferFromReaderToChunkReader(t *testing.T) {
 			reader,
 			buffer.BackendProvided(dataIntegrityCallback.Call)).ToChunkReader(
 			/* offset = */ -1,
+			/* limit = */ 0,
 			/* chunk size = */ 2)
 		_, err := r.Read()
 		require.Equal(t, status.Error(codes.InvalidArgument, "Negative read offset: -1"), err)
@@ -411,6 +414,7 @@ func TestNewCASBufferFromReaderToChunkReader(t *testing.T) {
 			reader,
 			buffer.BackendProvided(dataIntegrityCallback.Call)).ToChunkReader(
 			/* offset = */ 12,
+			/* limit = */ 0,
 			/* chunk size = */ 2)
 		_, err := r.Read()
 		require.Equal(t, status.Error(codes.InvalidArgument, "Buffer is 11 bytes in size, while a read at offset 12 was requested"), err)
@@ -429,6 +433,7 @@ func TestNewCASBufferFromReaderToChunkReader(t *testing.T) {
 			reader,
 			buffer.BackendProvided(dataIntegrityCallback.Call)).ToChunkReader(
 			/* offset = */ 0,
+			/* limit = */ 0,
 			/* chunk size = */ 10)
 		chunk, err := r.Read()
 		require.NoError(t, err)
@@ -119,6 +119,7 @@ func TestNewProtoBufferFromProtoToChunkReader(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		r := buffer.NewProtoBufferFromProto(&exampleActionResultMessage, buffer.UserProvided).ToChunkReader(
 			/* offset = */ 12,
+			/* limit = */ 0,
 			/* chunk size = */ 10)
 
 		off := 12
@@ -143,6 +144,7 @@ func TestNewProtoBufferFromProtoToChunkReader(t *testing.T) {
 		// return an end-of-file immediately.
 		r := buffer.NewProtoBufferFromProto(&exampleActionResultMessage, buffer.UserProvided).ToChunkReader(
 			/* offset = */ int64(len(exampleActionResultBytes)),
+			/* limit = */ 0,
 			/* chunk size = */ 10)
 		_, err := r.Read()
 		require.Equal(t, io.EOF, err)
@@ -152,6 +154,7 @@ func TestNewProtoBufferFromProtoToChunkReader(t *testing.T) {
 	t.Run("NegativeOffset", func(t *testing.T) {
 		r := buffer.NewProtoBufferFromProto(&exampleActionResultMessage, buffer.UserProvided).ToChunkReader(
 			/* offset = */ -123,
+			/* limit = */ 0,
 			/* chunk size = */ 1024)
 
 		_, err := r.Read()
@@ -163,6 +166,7 @@ func TestNewProtoBufferFromProtoToChunkReader(t *testing.T) {
 	t.Run("TooFar", func(t *testing.T) {
 		r := buffer.NewProtoBufferFromProto(&exampleActionResultMessage, buffer.UserProvided).ToChunkReader(
 			/* offset = */ int64(len(exampleActionResultBytes)+1),
+			/* limit = */ 0,
 			/* chunk size = */ 100)
 
 		_, err := r.Read()
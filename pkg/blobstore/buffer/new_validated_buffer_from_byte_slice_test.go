@@ -97,6 +97,7 @@ func TestNewValidatedBufferFromByteSliceToChunkReader(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		r := buffer.NewValidatedBufferFromByteSlice([]byte("Hello")).ToChunkReader(
 			/* offset = */ 1,
+			/* limit = */ 0,
 			/* chunk size = */ 2)
 
 		data, err := r.Read()
@@ -121,6 +122,7 @@ func TestNewValidatedBufferFromByteSliceToChunkReader(t *testing.T) {
 		// return an end-of-file immediately.
 		r := buffer.NewValidatedBufferFromByteSlice([]byte("Hello")).ToChunkReader(
 			/* offset = */ 5,
+			/* limit = */ 0,
 			/* chunk size = */ 2)
 		_, err := r.Read()
 		require.Equal(t, io.EOF, err)
@@ -130,6 +132,7 @@ func TestNewValidatedBufferFromByteSliceToChunkReader(t *testing.T) {
 	t.Run("NegativeOffset", func(t *testing.T) {
 		r := buffer.NewValidatedBufferFromByteSlice([]byte("Hello")).ToChunkReader(
 			/* offset = */ -123,
+			/* limit = */ 0,
 			/* chunk size = */ 1024)
 
 		_, err := r.Read()
@@ -141,6 +144,7 @@ func TestNewValidatedBufferFromByteSliceToChunkReader(t *testing.T) {
 	t.Run("TooFar", func(t *testing.T) {
 		r := buffer.NewValidatedBufferFromByteSlice([]byte("Hello")).ToChunkReader(
 			/* offset = */ 6,
+			/* limit = */ 0,
 			/* chunk size = */ 1024)
 
 		_, err := r.Read()
@@ -191,6 +191,7 @@ func TestNewValidatedBufferFromReaderAtToChunkReader(t *testing.T) {
 		// large.
 		r := buffer.NewValidatedBufferFromReaderAt(reader, 11).ToChunkReader(
 			/* offset = */ 3,
+			/* limit = */ 0,
 			/* chunk size = */ 2)
 		chunk, err := r.Read()
 		require.NoError(t, err)
@@ -219,18 +220,56 @@ func TestNewValidatedBufferFromReaderAtToChunkReader(t *testing.T) {
 		// return an end-of-file immediately.
 		r := buffer.NewValidatedBufferFromReaderAt(reader, 11).ToChunkReader(
 			/* offset = */ 11,
+			/* limit = */ 0,
 			/* chunk size = */ 2)
 		_, err := r.Read()
 		require.Equal(t, io.EOF, err)
 		r.Close()
 	})
 
+	t.Run("WithLimit", func(t *testing.T) {
+		reader := mock.NewMockReadAtCloser(ctrl)
+		gomock.InOrder(
+			reader.EXPECT().ReadAt(gomock.Len(5), int64(3)).DoAndReturn(func(p []byte, off int64) (int, error) {
+				return copy(p, []byte("lo wo")), nil
+			}),
+			reader.EXPECT().Close(),
+		)
+
+		// Only the requested part of the underlying storage
+		// should be read.
+		r := buffer.NewValidatedBufferFromReaderAt(reader, 11).ToChunkReader(
+			/* offset = */ 3,
+			/* limit = */ 5,
+			/* chunk size = */ 10)
+		chunk, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, []byte("lo wo"), chunk)
+		_, err = r.Read()
+		require.Equal(t, io.EOF, err)
+		r.Close()
+	})
+
+	t.Run("NegativeLimit", func(t *testing.T) {
+		reader := mock.NewMockReadAtCloser(ctrl)
+		reader.EXPECT().Close()
+
+		r := buffer.NewValidatedBufferFromReaderAt(reader, 11).ToChunkReader(
+			/* offset = */ 3,
+			/* limit = */ -1,
+			/* chunk size = */ 2)
+		_, err := r.Read()
+		require.Equal(t, status.Error(codes.InvalidArgument, "Negative read limit: -1"), err)
+		r.Close()
+	})
+
 	t.Run("NegativeOffset", func(t *testing.T) {
 		reader := mock.NewMockReadAtCloser(ctrl)
 		reader.EXPECT().Close()
 
 		r := buffer.NewValidatedBufferFromReaderAt(reader, 11).ToChunkReader(
 			/* offset = */ -1,
+			/* limit = */ 0,
 			/* chunk size = */ 2)
 		_, err := r.Read()
 		require.Equal(t, status.Error(codes.InvalidArgument, "Negative read offset: -1"), err)
@@ -243,6 +282,7 @@ func TestNewValidatedBufferFromReaderAtToChunkReader(t *testing.T) {
 
 		r := buffer.NewValidatedBufferFromReaderAt(reader, 11).ToChunkReader(
 			/* offset = */ 12,
+			/* limit = */ 0,
 			/* chunk size = */ 2)
 		_, err := r.Read()
 		require.Equal(t, status.Error(codes.InvalidArgument, "Buffer is 11 bytes in size, while a read at offset 12 was requested"), err)
@@ -258,6 +298,7 @@ func TestNewValidatedBufferFromReaderAtToChunkReader(t *testing.T) {
 
 		r := buffer.NewValidatedBufferFromReaderAt(reader, 11).ToChunkReader(
 			/* offset = */ 3,
+			/* limit = */ 0,
 			/* chunk size = */ 2)
 		_, err := r.Read()
 		require.Equal(t, status.Error(codes.Internal, "Storage backend on fire"), err)
@@ -82,8 +82,11 @@ func (b validatedByteSliceBuffer) ToByteSlice(maximumSizeBytes int) ([]byte, err
 	return b.data, nil
 }
 
-func (b validatedByteSliceBuffer) ToChunkReader(off int64, maximumChunkSizeBytes int) ChunkReader {
-	return b.toUnvalidatedChunkReader(off, maximumChunkSizeBytes)
+func (b validatedByteSliceBuffer) ToChunkReader(off, limit int64, maximumChunkSizeBytes int) ChunkReader {
+	if err := validateReaderLimit(limit); err != nil {
+		return newErrorChunkReader(err)
+	}
+	return newLimitedChunkReader(b.toUnvalidatedChunkReader(off, maximumChunkSizeBytes), limit)
 }
 
 func (b validatedByteSliceBuffer) ToReader() io.ReadCloser {
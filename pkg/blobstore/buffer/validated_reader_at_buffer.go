@@ -72,8 +72,27 @@ func (b *validatedReaderBuffer) ToByteSlice(maximumSizeBytes int) ([]byte, error
 	return ioutil.ReadAll(io.NewSectionReader(b.r, 0, b.sizeBytes))
 }
 
-func (b *validatedReaderBuffer) ToChunkReader(off int64, maximumChunkSizeBytes int) ChunkReader {
-	return b.toUnvalidatedChunkReader(off, maximumChunkSizeBytes)
+func (b *validatedReaderBuffer) ToChunkReader(off, limit int64, maximumChunkSizeBytes int) ChunkReader {
+	if err := validateReaderOffset(b.sizeBytes, off); err != nil {
+		b.Discard()
+		return newErrorChunkReader(err)
+	}
+	if err := validateReaderLimit(limit); err != nil {
+		b.Discard()
+		return newErrorChunkReader(err)
+	}
+
+	// Only read the requested part of the underlying storage.
+	sizeBytes := b.sizeBytes - off
+	if limit != 0 && limit < sizeBytes {
+		sizeBytes = limit
+	}
+	return newReaderBackedChunkReader(
+		&validatedReaderAtReader{
+			SectionReader: *io.NewSectionReader(b.r, off, sizeBytes),
+			b:             b,
+		},
+		maximumChunkSizeBytes)
 }
 
 func (b *validatedReaderBuffer) ToReader() io.ReadCloser {
@@ -0,0 +1,94 @@
+package buffer
+
+import (
+	"bufio"
+	"io"
+)
+
+// WaitForFirstChunk blocks until the first chunk of data of a buffer
+// is available, or until it is known that the buffer cannot be read.
+// This may, for example, be used to determine how long it takes for a
+// remote storage backend to start returning data.
+//
+// Upon success, a buffer is returned that yields the same data as the
+// original buffer. It retains the type and data integrity checking of
+// the original buffer, meaning that no additional checksum validation
+// is performed. Upon failure, the original buffer is released.
+//
+// Buffers whose contents are not obtained from a stream are returned
+// as is, as they are either held in memory or can be accessed without
+// delay.
+func WaitForFirstChunk(b Buffer) (Buffer, error) {
+	switch bTyped := b.(type) {
+	case errorBuffer:
+		return nil, bTyped.err
+	case *casChunkReaderBuffer:
+		var chunk []byte
+		var err error
+		for len(chunk) == 0 && err == nil {
+			chunk, err = bTyped.r.Read()
+		}
+		if err != nil && err != io.EOF {
+			bTyped.r.Close()
+			return nil, err
+		}
+		return &casChunkReaderBuffer{
+			digest: bTyped.digest,
+			r: &prefetchedChunkReader{
+				ChunkReader: bTyped.r,
+				chunk:       chunk,
+				err:         err,
+			},
+			source: bTyped.source,
+		}, nil
+	case *casReaderBuffer:
+		r := bufio.NewReader(bTyped.r)
+		if _, err := r.Peek(1); err != nil && err != io.EOF {
+			bTyped.r.Close()
+			return nil, err
+		}
+		return &casReaderBuffer{
+			digest: bTyped.digest,
+			r: struct {
+				io.Reader
+				io.Closer
+			}{
+				Reader: r,
+				Closer: bTyped.r,
+			},
+			source: bTyped.source,
+		}, nil
+	case *casErrorHandlingBuffer:
+		// Wait for the underlying buffer. Let the error handler
+		// process any errors, as it may provide a replacement
+		// buffer.
+		base, err := WaitForFirstChunk(bTyped.base)
+		if err != nil {
+			return WaitForFirstChunk(WithErrorHandler(NewBufferFromError(err), bTyped.errorHandler))
+		}
+		return WithErrorHandler(base, bTyped.errorHandler), nil
+	default:
+		return b, nil
+	}
+}
+
+// prefetchedChunkReader is a decorator for ChunkReader that returns the
+// results of a call to Read() that was already performed, prior to
+// reading from the underlying ChunkReader.
+type prefetchedChunkReader struct {
+	ChunkReader
+	chunk    []byte
+	err      error
+	consumed bool
+}
+
+func (r *prefetchedChunkReader) Read() ([]byte, error) {
+	if !r.consumed {
+		r.consumed = true
+		return r.chunk, r.err
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.ChunkReader.Read()
+}
@@ -0,0 +1,97 @@
+package buffer_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/buildbarn/bb-storage/internal/mock"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWaitForFirstChunk(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	helloDigest := digest.MustNewDigest("foo", "8b1a9953c4611296a827abf8c47804d7", 5)
+
+	t.Run("Error", func(t *testing.T) {
+		_, err := buffer.WaitForFirstChunk(buffer.NewBufferFromError(status.Error(codes.NotFound, "Object not found")))
+		require.Equal(t, status.Error(codes.NotFound, "Object not found"), err)
+	})
+
+	t.Run("ChunkReaderSuccess", func(t *testing.T) {
+		// The first chunk should be read immediately. It should
+		// be returned when the buffer is accessed, followed by
+		// the remaining data. Data integrity checking should
+		// be performed using the original source.
+		chunkReader := mock.NewMockChunkReader(ctrl)
+		chunkReader.EXPECT().Read().Return([]byte("Hel"), nil)
+		dataIntegrityCallback := mock.NewMockDataIntegrityCallback(ctrl)
+
+		b, err := buffer.WaitForFirstChunk(buffer.NewCASBufferFromChunkReader(
+			helloDigest,
+			chunkReader,
+			buffer.BackendProvided(dataIntegrityCallback.Call)))
+		require.NoError(t, err)
+
+		chunkReader.EXPECT().Read().Return([]byte("lo"), nil)
+		chunkReader.EXPECT().Read().Return(nil, io.EOF)
+		chunkReader.EXPECT().Close()
+		dataIntegrityCallback.EXPECT().Call(true)
+
+		data, err := b.ToByteSlice(100)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+	})
+
+	t.Run("ChunkReaderFailure", func(t *testing.T) {
+		chunkReader := mock.NewMockChunkReader(ctrl)
+		chunkReader.EXPECT().Read().Return(nil, status.Error(codes.Unavailable, "Server offline"))
+		chunkReader.EXPECT().Close()
+
+		_, err := buffer.WaitForFirstChunk(buffer.NewCASBufferFromChunkReader(
+			helloDigest,
+			chunkReader,
+			buffer.BackendProvided(buffer.Irreparable(helloDigest))))
+		require.Equal(t, status.Error(codes.Unavailable, "Server offline"), err)
+	})
+
+	t.Run("ErrorHandlerReplacement", func(t *testing.T) {
+		// Errors that occur while waiting should be passed to
+		// the error handler, which may provide a replacement.
+		chunkReader := mock.NewMockChunkReader(ctrl)
+		chunkReader.EXPECT().Read().Return(nil, status.Error(codes.Unavailable, "Server offline"))
+		chunkReader.EXPECT().Close()
+		errorHandler := mock.NewMockErrorHandler(ctrl)
+		errorHandler.EXPECT().OnError(status.Error(codes.Unavailable, "Server offline")).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello")), nil)
+		errorHandler.EXPECT().Done()
+
+		b, err := buffer.WaitForFirstChunk(buffer.WithErrorHandler(
+			buffer.NewCASBufferFromChunkReader(
+				helloDigest,
+				chunkReader,
+				buffer.BackendProvided(buffer.Irreparable(helloDigest))),
+			errorHandler))
+		require.NoError(t, err)
+
+		data, err := b.ToByteSlice(100)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+	})
+
+	t.Run("ValidatedByteSlice", func(t *testing.T) {
+		// Buffers backed by memory should be returned as is.
+		b, err := buffer.WaitForFirstChunk(buffer.NewValidatedBufferFromByteSlice([]byte("Hello")))
+		require.NoError(t, err)
+
+		data, err := b.ToByteSlice(100)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+	})
+}
@@ -106,8 +106,8 @@ func (b *bufferWithBackgroundTask) ToByteSlice(maximumSizeBytes int) ([]byte, er
 	return data, b.task.err
 }
 
-func (b *bufferWithBackgroundTask) ToChunkReader(off int64, maximumChunkSizeBytes int) ChunkReader {
-	return b.decorateChunkReader(b.base.ToChunkReader(off, maximumChunkSizeBytes))
+func (b *bufferWithBackgroundTask) ToChunkReader(off, limit int64, maximumChunkSizeBytes int) ChunkReader {
+	return b.decorateChunkReader(b.base.ToChunkReader(off, limit, maximumChunkSizeBytes))
 }
 
 func (b *bufferWithBackgroundTask) ToReader() io.ReadCloser {
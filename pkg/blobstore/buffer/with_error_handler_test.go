@@ -329,6 +329,7 @@ func TestWithErrorHandlerOnCASBuffersToChunkReader(t *testing.T) {
 
 		r := buffer.WithErrorHandler(b1, errorHandler).ToChunkReader(
 			/* offset = */ 2,
+			/* limit = */ 0,
 			/* chunk size = */ 10)
 		chunk, err := r.Read()
 		require.NoError(t, err)
@@ -358,6 +359,7 @@ func TestWithErrorHandlerOnCASBuffersToChunkReader(t *testing.T) {
 		// already written is not a problem.
 		r := buffer.WithErrorHandler(b1, errorHandler).ToChunkReader(
 			/* offset = */ 4,
+			/* limit = */ 0,
 			/* chunk size = */ 3)
 		chunk, err := r.Read()
 		require.NoError(t, err)
@@ -397,6 +399,7 @@ func TestWithErrorHandlerOnCASBuffersToChunkReader(t *testing.T) {
 		// stream.
 		r := buffer.WithErrorHandler(b1, errorHandler).ToChunkReader(
 			/* offset = */ 0,
+			/* limit = */ 0,
 			/* chunk size = */ 1000)
 		chunk, err := r.Read()
 		require.NoError(t, err)
@@ -0,0 +1,173 @@
+package blobstore
+
+import (
+	"sync"
+	"time"
+
+	"github.com/buildbarn/bb-storage/pkg/clock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	circuitBreakerPrometheusMetrics sync.Once
+
+	circuitBreakerNotClosed = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "buildbarn",
+			Subsystem: "blobstore",
+			Name:      "circuit_breaker_not_closed",
+			Help:      "Number of circuit breakers that are currently in the open or half-open state.",
+		},
+		[]string{"storage_type", "backend_type"})
+	circuitBreakerTransitions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "buildbarn",
+			Subsystem: "blobstore",
+			Name:      "circuit_breaker_transitions_total",
+			Help:      "Number of times circuit breakers transitioned to a given state.",
+		},
+		[]string{"storage_type", "backend_type", "state"})
+	circuitBreakerRejectedRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "buildbarn",
+			Subsystem: "blobstore",
+			Name:      "circuit_breaker_rejected_requests_total",
+			Help:      "Number of requests that were rejected, because the circuit breaker was open.",
+		},
+		[]string{"storage_type", "backend_type"})
+)
+
+// CircuitBreaker is used by RetryingBlobAccess to prevent requests
+// from being sent to backends that are known to be failing. This
+// allows requests to fail quickly, and gives backends the opportunity
+// to recover.
+type CircuitBreaker interface {
+	// Allow returns an error if requests may currently not be sent
+	// to the backend.
+	Allow() error
+	// ReportResult must be called with the outcome of every request
+	// that was allowed to be sent.
+	ReportResult(err error)
+}
+
+type noCircuitBreaker struct{}
+
+func (cb noCircuitBreaker) Allow() error {
+	return nil
+}
+
+func (cb noCircuitBreaker) ReportResult(err error) {}
+
+// NoCircuitBreaker is an implementation of CircuitBreaker that always
+// allows requests to be sent.
+var NoCircuitBreaker CircuitBreaker = noCircuitBreaker{}
+
+type circuitBreakerState int
+
+const (
+	circuitBreakerStateClosed circuitBreakerState = iota
+	circuitBreakerStateOpen
+	circuitBreakerStateHalfOpen
+)
+
+type consecutiveFailuresCircuitBreaker struct {
+	clock            clock.Clock
+	failureThreshold int
+	openDuration     time.Duration
+
+	lock                sync.Mutex
+	state               circuitBreakerState
+	consecutiveFailures int
+	openUntil           time.Time
+	probeInFlight       bool
+
+	notClosed             prometheus.Gauge
+	transitionsToOpen     prometheus.Counter
+	transitionsToHalfOpen prometheus.Counter
+	transitionsToClosed   prometheus.Counter
+	rejectedRequests      prometheus.Counter
+}
+
+// NewConsecutiveFailuresCircuitBreaker creates a CircuitBreaker that
+// opens after a given number of consecutive requests fail with
+// UNAVAILABLE. While open, all requests are rejected. Once the provided
+// duration has passed, the circuit breaker becomes half-open, meaning
+// a single probe request is permitted. Other requests continue to be
+// rejected until the probe completes. If the probe fails, the circuit
+// breaker opens again. Otherwise, it closes.
+//
+// The state of the circuit breaker is exposed through Prometheus
+// metrics, using the same labels as MetricsBlobAccess.
+func NewConsecutiveFailuresCircuitBreaker(clock clock.Clock, failureThreshold int, openDuration time.Duration, storageType, backendType string) CircuitBreaker {
+	circuitBreakerPrometheusMetrics.Do(func() {
+		prometheus.MustRegister(circuitBreakerNotClosed)
+		prometheus.MustRegister(circuitBreakerTransitions)
+		prometheus.MustRegister(circuitBreakerRejectedRequests)
+	})
+
+	return &consecutiveFailuresCircuitBreaker{
+		clock:            clock,
+		failureThreshold: failureThreshold,
+		openDuration:     openDuration,
+
+		notClosed:             circuitBreakerNotClosed.WithLabelValues(storageType, backendType),
+		transitionsToOpen:     circuitBreakerTransitions.WithLabelValues(storageType, backendType, "Open"),
+		transitionsToHalfOpen: circuitBreakerTransitions.WithLabelValues(storageType, backendType, "HalfOpen"),
+		transitionsToClosed:   circuitBreakerTransitions.WithLabelValues(storageType, backendType, "Closed"),
+		rejectedRequests:      circuitBreakerRejectedRequests.WithLabelValues(storageType, backendType),
+	}
+}
+
+func (cb *consecutiveFailuresCircuitBreaker) Allow() error {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+
+	switch cb.state {
+	case circuitBreakerStateOpen:
+		if cb.clock.Now().Before(cb.openUntil) {
+			cb.rejectedRequests.Inc()
+			return status.Errorf(codes.Unavailable, "Circuit breaker is open after %d consecutive failures", cb.consecutiveFailures)
+		}
+		// Let this request probe whether the backend has
+		// recovered.
+		cb.state = circuitBreakerStateHalfOpen
+		cb.transitionsToHalfOpen.Inc()
+		cb.probeInFlight = true
+	case circuitBreakerStateHalfOpen:
+		if cb.probeInFlight {
+			cb.rejectedRequests.Inc()
+			return status.Errorf(codes.Unavailable, "Circuit breaker is half-open after %d consecutive failures, and is waiting for a probe request to complete", cb.consecutiveFailures)
+		}
+		cb.probeInFlight = true
+	}
+	return nil
+}
+
+func (cb *consecutiveFailuresCircuitBreaker) ReportResult(err error) {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+
+	cb.probeInFlight = false
+	if status.Code(err) != codes.Unavailable {
+		cb.consecutiveFailures = 0
+		if cb.state != circuitBreakerStateClosed {
+			cb.state = circuitBreakerStateClosed
+			cb.notClosed.Dec()
+			cb.transitionsToClosed.Inc()
+		}
+		return
+	}
+
+	cb.consecutiveFailures++
+	if (cb.state == circuitBreakerStateClosed && cb.consecutiveFailures >= cb.failureThreshold) || cb.state == circuitBreakerStateHalfOpen {
+		if cb.state == circuitBreakerStateClosed {
+			cb.notClosed.Inc()
+		}
+		cb.state = circuitBreakerStateOpen
+		cb.openUntil = cb.clock.Now().Add(cb.openDuration)
+		cb.transitionsToOpen.Inc()
+	}
+}
@@ -0,0 +1,62 @@
+package blobstore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/buildbarn/bb-storage/internal/mock"
+	"github.com/buildbarn/bb-storage/pkg/blobstore"
+	"github.com/buildbarn/bb-storage/pkg/testutil"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestConsecutiveFailuresCircuitBreaker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	clock := mock.NewMockClock(ctrl)
+	circuitBreaker := blobstore.NewConsecutiveFailuresCircuitBreaker(clock, 3, time.Minute, "cas", "grpc")
+
+	// Errors other than UNAVAILABLE should not count as failures.
+	require.NoError(t, circuitBreaker.Allow())
+	circuitBreaker.ReportResult(status.Error(codes.Unavailable, "Server offline"))
+	require.NoError(t, circuitBreaker.Allow())
+	circuitBreaker.ReportResult(status.Error(codes.Unavailable, "Server offline"))
+	require.NoError(t, circuitBreaker.Allow())
+	circuitBreaker.ReportResult(status.Error(codes.NotFound, "Object not found"))
+
+	// Three consecutive failures should open the circuit breaker.
+	for i := 0; i < 3; i++ {
+		require.NoError(t, circuitBreaker.Allow())
+		if i == 2 {
+			clock.EXPECT().Now().Return(time.Unix(1000, 0))
+		}
+		circuitBreaker.ReportResult(status.Error(codes.Unavailable, "Server offline"))
+	}
+	clock.EXPECT().Now().Return(time.Unix(1059, 0))
+	testutil.RequireEqualStatus(t, status.Error(codes.Unavailable, "Circuit breaker is open after 3 consecutive failures"), circuitBreaker.Allow())
+
+	// After the open duration has passed, a single probe request
+	// should be permitted. Other requests should be rejected until
+	// the probe completes. If the probe fails, the circuit breaker
+	// should open again immediately.
+	clock.EXPECT().Now().Return(time.Unix(1060, 0))
+	require.NoError(t, circuitBreaker.Allow())
+	testutil.RequireEqualStatus(t, status.Error(codes.Unavailable, "Circuit breaker is half-open after 3 consecutive failures, and is waiting for a probe request to complete"), circuitBreaker.Allow())
+	clock.EXPECT().Now().Return(time.Unix(1061, 0))
+	circuitBreaker.ReportResult(status.Error(codes.Unavailable, "Server offline"))
+	clock.EXPECT().Now().Return(time.Unix(1062, 0))
+	testutil.RequireEqualStatus(t, status.Error(codes.Unavailable, "Circuit breaker is open after 4 consecutive failures"), circuitBreaker.Allow())
+
+	// A successful request should close the circuit breaker.
+	clock.EXPECT().Now().Return(time.Unix(1121, 0))
+	require.NoError(t, circuitBreaker.Allow())
+	testutil.RequireEqualStatus(t, status.Error(codes.Unavailable, "Circuit breaker is half-open after 4 consecutive failures, and is waiting for a probe request to complete"), circuitBreaker.Allow())
+	circuitBreaker.ReportResult(nil)
+	require.NoError(t, circuitBreaker.Allow())
+	circuitBreaker.ReportResult(status.Error(codes.Unavailable, "Server offline"))
+	require.NoError(t, circuitBreaker.Allow())
+}
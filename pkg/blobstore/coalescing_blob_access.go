@@ -0,0 +1,215 @@
+package blobstore
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"sync"
+
+	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/util"
+)
+
+type coalescingBlobAccess struct {
+	BlobAccess
+	readBufferFactory ReadBufferFactory
+
+	lock       sync.Mutex
+	getFlights map[digest.Digest]*coalescingGetFlight
+	putFlights map[digest.Digest]*coalescingPutFlight
+}
+
+// NewCoalescingBlobAccess creates a decorator for BlobAccess that
+// coalesces concurrent requests for the same object. This reduces the
+// load on the backend in case many clients request the same object at
+// the same time (e.g., a large number of CI workers fetching an object
+// that was just built).
+//
+// Calls to Get() that are made while another call for the same object
+// is still waiting for the backend to return data are not forwarded to
+// the backend. Instead, all of these calls obtain a stream of the same
+// data by using Buffer.CloneStream(). As all consumers of such a stream
+// progress at the same pace, a slow client may slow down other clients
+// requesting the same object.
+//
+// If all callers of Get() for the same object go away before the
+// backend has returned any data, the call against the backend is
+// cancelled.
+//
+// Calls to Put() that are made while another call for the same object
+// is still in progress are not forwarded to the backend. These calls
+// return success if the call in progress succeeds. If it fails, they
+// are retried using their own copy of the data, as the failure may
+// have been caused by the data provided by the original caller.
+func NewCoalescingBlobAccess(base BlobAccess, readBufferFactory ReadBufferFactory) BlobAccess {
+	return &coalescingBlobAccess{
+		BlobAccess:        base,
+		readBufferFactory: readBufferFactory,
+		getFlights:        map[digest.Digest]*coalescingGetFlight{},
+		putFlights:        map[digest.Digest]*coalescingPutFlight{},
+	}
+}
+
+// coalescingGetFlight keeps track of all callers of Get() that are
+// waiting for the same object to be returned by the backend.
+type coalescingGetFlight struct {
+	waiters []chan buffer.Buffer
+	cancel  context.CancelFunc
+}
+
+// coalescingPutFlight keeps track of a Put() call that is in progress,
+// so that duplicate calls can wait for it to complete.
+type coalescingPutFlight struct {
+	done chan struct{}
+	err  error
+}
+
+// flightContext is the Context that is used by Get() calls forwarded
+// to the backend. It provides the values of the Context of the caller
+// that initiated the call (e.g., gRPC metadata), but is not cancelled
+// when that caller goes away, as other callers may still be waiting.
+type flightContext struct {
+	context.Context
+	values context.Context
+}
+
+func (ctx flightContext) Value(key interface{}) interface{} {
+	return ctx.values.Value(key)
+}
+
+// flightReader is the reader of the data returned by the backend. As
+// the first byte of data has already been read, a bufio.Reader is used
+// to return it once more. Closing the reader cancels the Context used
+// by the backend.
+type flightReader struct {
+	*bufio.Reader
+	closer io.Closer
+	cancel context.CancelFunc
+}
+
+func (r *flightReader) Close() error {
+	err := r.closer.Close()
+	r.cancel()
+	return err
+}
+
+func (ba *coalescingBlobAccess) Get(ctx context.Context, digest digest.Digest) buffer.Buffer {
+	c := make(chan buffer.Buffer, 1)
+	ba.lock.Lock()
+	flight, ok := ba.getFlights[digest]
+	if !ok {
+		backendCtx, cancel := context.WithCancel(context.Background())
+		flight = &coalescingGetFlight{cancel: cancel}
+		ba.getFlights[digest] = flight
+		go ba.runGetFlight(flightContext{Context: backendCtx, values: ctx}, digest, flight)
+	}
+	flight.waiters = append(flight.waiters, c)
+	ba.lock.Unlock()
+
+	select {
+	case b := <-c:
+		return b
+	case <-ctx.Done():
+		ba.lock.Lock()
+		if ba.getFlights[digest] == flight {
+			// The backend has not returned any data yet.
+			// Stop waiting for it. Cancel the call against
+			// the backend if no other callers remain.
+			for i, waiter := range flight.waiters {
+				if waiter == c {
+					flight.waiters = append(flight.waiters[:i], flight.waiters[i+1:]...)
+					break
+				}
+			}
+			if len(flight.waiters) == 0 {
+				delete(ba.getFlights, digest)
+				flight.cancel()
+			}
+		} else {
+			// Other callers may still be waiting for the
+			// same stream. Make sure our copy is released.
+			go func() {
+				(<-c).Discard()
+			}()
+		}
+		ba.lock.Unlock()
+		return buffer.NewBufferFromError(util.StatusFromContext(ctx))
+	}
+}
+
+func (ba *coalescingBlobAccess) runGetFlight(ctx context.Context, digest digest.Digest, flight *coalescingGetFlight) {
+	// Wait for the backend to return the first byte of data. Until
+	// that point, additional callers may join this flight.
+	r := ba.BlobAccess.Get(ctx, digest).ToReader()
+	br := bufio.NewReader(r)
+	_, err := br.Peek(1)
+
+	ba.lock.Lock()
+	if ba.getFlights[digest] != flight {
+		// All callers went away while waiting for the backend.
+		ba.lock.Unlock()
+		r.Close()
+		return
+	}
+	delete(ba.getFlights, digest)
+	waiters := flight.waiters
+	ba.lock.Unlock()
+
+	if err != nil && err != io.EOF {
+		r.Close()
+		flight.cancel()
+		for _, c := range waiters {
+			c <- buffer.NewBufferFromError(err)
+		}
+		return
+	}
+
+	// Hand out a stream of the data to every caller.
+	b := ba.readBufferFactory.NewBufferFromReader(
+		digest,
+		&flightReader{Reader: br, closer: r, cancel: flight.cancel},
+		buffer.Irreparable(digest))
+	for _, c := range waiters[:len(waiters)-1] {
+		var bCopy buffer.Buffer
+		bCopy, b = b.CloneStream()
+		c <- bCopy
+	}
+	waiters[len(waiters)-1] <- b
+}
+
+func (ba *coalescingBlobAccess) Put(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+	ba.lock.Lock()
+	if flight, ok := ba.putFlights[digest]; ok {
+		// The same object is already being written. Wait for
+		// that call to complete.
+		ba.lock.Unlock()
+		select {
+		case <-flight.done:
+			if flight.err == nil {
+				b.Discard()
+				return nil
+			}
+			// The call in progress failed, which may have
+			// been caused by the data provided by its
+			// caller. Retry using our own copy.
+			return ba.Put(ctx, digest, b)
+		case <-ctx.Done():
+			b.Discard()
+			return util.StatusFromContext(ctx)
+		}
+	}
+	flight := &coalescingPutFlight{
+		done: make(chan struct{}),
+	}
+	ba.putFlights[digest] = flight
+	ba.lock.Unlock()
+
+	flight.err = ba.BlobAccess.Put(ctx, digest, b)
+
+	ba.lock.Lock()
+	delete(ba.putFlights, digest)
+	ba.lock.Unlock()
+	close(flight.done)
+	return flight.err
+}
@@ -0,0 +1,265 @@
+package blobstore_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/buildbarn/bb-storage/internal/mock"
+	"github.com/buildbarn/bb-storage/pkg/blobstore"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/testutil"
+	"github.com/buildbarn/bb-storage/pkg/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// doneSignalingContext is a Context that closes a channel as soon as
+// Done() is called. CoalescingBlobAccess only calls Done() after a
+// request has been registered, allowing tests to determine when it is
+// waiting for another request to complete.
+type doneSignalingContext struct {
+	context.Context
+	once   sync.Once
+	called chan struct{}
+}
+
+func newDoneSignalingContext(ctx context.Context) *doneSignalingContext {
+	return &doneSignalingContext{
+		Context: ctx,
+		called:  make(chan struct{}),
+	}
+}
+
+func (ctx *doneSignalingContext) Done() <-chan struct{} {
+	ctx.once.Do(func() { close(ctx.called) })
+	return ctx.Context.Done()
+}
+
+func TestCoalescingBlobAccessGet(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	baseBlobAccess := mock.NewMockBlobAccess(ctrl)
+	blobAccess := blobstore.NewCoalescingBlobAccess(baseBlobAccess, blobstore.CASReadBufferFactory)
+
+	helloDigest := digest.MustNewDigest("example", "8b1a9953c4611296a827abf8c47804d7", 5)
+
+	type getResult struct {
+		data []byte
+		err  error
+	}
+	startGets := func(count int) []chan getResult {
+		results := make([]chan getResult, 0, count)
+		for i := 0; i < count; i++ {
+			ctxSignaling := newDoneSignalingContext(ctx)
+			result := make(chan getResult, 1)
+			go func() {
+				data, err := blobAccess.Get(ctxSignaling, helloDigest).ToByteSlice(1000)
+				result <- getResult{data: data, err: err}
+			}()
+			<-ctxSignaling.called
+			results = append(results, result)
+		}
+		return results
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		// Concurrent calls should only cause a single call
+		// against the backend. All callers should receive the
+		// same data.
+		release := make(chan struct{})
+		baseBlobAccess.EXPECT().Get(gomock.Any(), helloDigest).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest) buffer.Buffer {
+				<-release
+				return buffer.NewValidatedBufferFromByteSlice([]byte("Hello"))
+			})
+
+		results := startGets(3)
+		close(release)
+		for _, result := range results {
+			r := <-result
+			require.NoError(t, r.err)
+			require.Equal(t, []byte("Hello"), r.data)
+		}
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		// Errors should be propagated to all callers.
+		release := make(chan struct{})
+		baseBlobAccess.EXPECT().Get(gomock.Any(), helloDigest).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest) buffer.Buffer {
+				<-release
+				return buffer.NewBufferFromError(status.Error(codes.NotFound, "Object not found"))
+			})
+
+		results := startGets(2)
+		close(release)
+		for _, result := range results {
+			testutil.RequireEqualStatus(t, status.Error(codes.NotFound, "Object not found"), (<-result).err)
+		}
+	})
+
+	t.Run("PartiallyAbandoned", func(t *testing.T) {
+		// If one of the callers goes away, the others should
+		// still receive the data.
+		release := make(chan struct{})
+		baseBlobAccess.EXPECT().Get(gomock.Any(), helloDigest).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest) buffer.Buffer {
+				<-release
+				return buffer.NewValidatedBufferFromByteSlice([]byte("Hello"))
+			})
+
+		ctxCancelable, cancel := context.WithCancel(ctx)
+		ctxSignaling := newDoneSignalingContext(ctxCancelable)
+		abandonedResult := make(chan error, 1)
+		go func() {
+			_, err := blobAccess.Get(ctxSignaling, helloDigest).ToByteSlice(1000)
+			abandonedResult <- err
+		}()
+		<-ctxSignaling.called
+
+		results := startGets(1)
+		cancel()
+		testutil.RequireEqualStatus(t, status.Error(codes.Canceled, "context canceled"), <-abandonedResult)
+
+		close(release)
+		r := <-results[0]
+		require.NoError(t, r.err)
+		require.Equal(t, []byte("Hello"), r.data)
+	})
+
+	t.Run("Abandoned", func(t *testing.T) {
+		// If all callers go away before the backend returns
+		// any data, the call against the backend should be
+		// cancelled, so that it does not block subsequent
+		// calls.
+		backendCanceled := make(chan struct{})
+		baseBlobAccess.EXPECT().Get(gomock.Any(), helloDigest).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest) buffer.Buffer {
+				<-ctx.Done()
+				close(backendCanceled)
+				return buffer.NewBufferFromError(util.StatusFromContext(ctx))
+			})
+
+		ctxCancelable, cancel := context.WithCancel(ctx)
+		ctxSignaling := newDoneSignalingContext(ctxCancelable)
+		abandonedResult := make(chan error, 1)
+		go func() {
+			_, err := blobAccess.Get(ctxSignaling, helloDigest).ToByteSlice(1000)
+			abandonedResult <- err
+		}()
+		<-ctxSignaling.called
+
+		cancel()
+		testutil.RequireEqualStatus(t, status.Error(codes.Canceled, "context canceled"), <-abandonedResult)
+		<-backendCanceled
+
+		baseBlobAccess.EXPECT().Get(gomock.Any(), helloDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello")))
+		data, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+	})
+
+	t.Run("Sequential", func(t *testing.T) {
+		// Calls that are not concurrent should not be
+		// coalesced.
+		baseBlobAccess.EXPECT().Get(gomock.Any(), helloDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello"))).
+			Times(2)
+
+		for i := 0; i < 2; i++ {
+			data, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+			require.NoError(t, err)
+			require.Equal(t, []byte("Hello"), data)
+		}
+	})
+}
+
+func TestCoalescingBlobAccessPut(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	baseBlobAccess := mock.NewMockBlobAccess(ctrl)
+	blobAccess := blobstore.NewCoalescingBlobAccess(baseBlobAccess, blobstore.CASReadBufferFactory)
+
+	helloDigest := digest.MustNewDigest("example", "8b1a9953c4611296a827abf8c47804d7", 5)
+
+	t.Run("Success", func(t *testing.T) {
+		// A duplicate call to Put() should not be forwarded to
+		// the backend, but return the result of the call in
+		// progress.
+		called := make(chan struct{})
+		release := make(chan struct{})
+		baseBlobAccess.EXPECT().Put(ctx, helloDigest, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+				close(called)
+				data, err := b.ToByteSlice(1000)
+				require.NoError(t, err)
+				require.Equal(t, []byte("Hello"), data)
+				<-release
+				return nil
+			})
+
+		firstResult := make(chan error, 1)
+		go func() {
+			firstResult <- blobAccess.Put(ctx, helloDigest, buffer.NewValidatedBufferFromByteSlice([]byte("Hello")))
+		}()
+		<-called
+
+		ctxSignaling := newDoneSignalingContext(ctx)
+		secondResult := make(chan error, 1)
+		go func() {
+			secondResult <- blobAccess.Put(ctxSignaling, helloDigest, buffer.NewValidatedBufferFromByteSlice([]byte("Hello")))
+		}()
+		<-ctxSignaling.called
+
+		close(release)
+		require.NoError(t, <-firstResult)
+		require.NoError(t, <-secondResult)
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		// If the call in progress fails, the duplicate call
+		// should be retried using its own copy of the data, as
+		// the failure may have been caused by the data of the
+		// original caller.
+		called := make(chan struct{})
+		release := make(chan struct{})
+		baseBlobAccess.EXPECT().Put(ctx, helloDigest, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+				close(called)
+				b.Discard()
+				<-release
+				return status.Error(codes.Unavailable, "Server offline")
+			})
+
+		firstResult := make(chan error, 1)
+		go func() {
+			firstResult <- blobAccess.Put(ctx, helloDigest, buffer.NewValidatedBufferFromByteSlice([]byte("Hello")))
+		}()
+		<-called
+
+		ctxSignaling := newDoneSignalingContext(ctx)
+		secondResult := make(chan error, 1)
+		go func() {
+			secondResult <- blobAccess.Put(ctxSignaling, helloDigest, buffer.NewCASBufferFromByteSlice(helloDigest, []byte("Hello"), buffer.UserProvided))
+		}()
+		<-ctxSignaling.called
+
+		baseBlobAccess.EXPECT().Put(ctxSignaling, helloDigest, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+				data, err := b.ToByteSlice(1000)
+				require.NoError(t, err)
+				require.Equal(t, []byte("Hello"), data)
+				return nil
+			})
+
+		close(release)
+		testutil.RequireEqualStatus(t, status.Error(codes.Unavailable, "Server offline"), <-firstResult)
+		require.NoError(t, <-secondResult)
+	})
+}
@@ -57,7 +57,7 @@ func TestCompletenessCheckingBlobAccess(t *testing.T) {
 				buffer.BackendProvided(dataIntegrityCallback.Call)))
 
 		_, err := completenessCheckingBlobAccess.Get(ctx, actionDigest).ToProto(&remoteexecution.ActionResult{}, 1000)
-		require.Equal(t, err, status.Error(codes.NotFound, "Action result contained malformed digest: Unknown digest hash length: 24 characters"))
+		require.Equal(t, err, status.Error(codes.NotFound, "Action result contained malformed digest: Digest hash has length 24, while 32 characters were expected"))
 	})
 
 	t.Run("MissingInput", func(t *testing.T) {
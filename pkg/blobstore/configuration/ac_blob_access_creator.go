@@ -1,9 +1,11 @@
 package configuration
 
 import (
+	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
 	"github.com/buildbarn/bb-storage/pkg/blobstore"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/completenesschecking"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/grpcclients"
+	"github.com/buildbarn/bb-storage/pkg/capabilities"
 	"github.com/buildbarn/bb-storage/pkg/digest"
 	"github.com/buildbarn/bb-storage/pkg/grpc"
 	pb "github.com/buildbarn/bb-storage/pkg/proto/configuration/blobstore"
@@ -28,6 +30,18 @@ func NewACBlobAccessCreator(contentAddressableStorage BlobAccessInfo, grpcClient
 	}
 }
 
+var acCapabilitiesProvider = capabilities.NewStaticProvider(&remoteexecution.ServerCapabilities{
+	CacheCapabilities: &remoteexecution.CacheCapabilities{
+		ActionCacheUpdateCapabilities: &remoteexecution.ActionCacheUpdateCapabilities{
+			UpdateEnabled: true,
+		},
+	},
+})
+
+func (bac *acBlobAccessCreator) GetDefaultCapabilitiesProvider() capabilities.Provider {
+	return acCapabilitiesProvider
+}
+
 func (bac *acBlobAccessCreator) GetReadBufferFactory() blobstore.ReadBufferFactory {
 	return blobstore.ACReadBufferFactory
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/buildbarn/bb-storage/pkg/blobstore"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/local"
+	"github.com/buildbarn/bb-storage/pkg/capabilities"
 	"github.com/buildbarn/bb-storage/pkg/digest"
 	pb "github.com/buildbarn/bb-storage/pkg/proto/configuration/blobstore"
 )
@@ -24,6 +25,12 @@ type BlobAccessCreator interface {
 	// return digest.KeyWithoutInstance, so that identical objects
 	// are only stored once.
 	GetBaseDigestKeyFormat() digest.KeyFormat
+	// GetDefaultCapabilitiesProvider() returns a provider of REv2
+	// capabilities that leaf instances of BlobAccess (e.g.,
+	// LocalBlobAccess) should report. These capabilities
+	// correspond to what this process is capable of supporting
+	// for this storage type.
+	GetDefaultCapabilitiesProvider() capabilities.Provider
 	// GetReadBufferFactory() returns operations that can be used by
 	// BlobAccess to create Buffer objects to return data.
 	GetReadBufferFactory() blobstore.ReadBufferFactory
@@ -37,6 +44,11 @@ type BlobAccessCreator interface {
 	// BlobAccess suitable for storing data on the local system that
 	// uses hierarchical instance names.
 	NewHierarchicalInstanceNamesLocalBlobAccess(keyLocationMap local.KeyLocationMap, locationBlobMap local.LocationBlobMap, globalLock *sync.RWMutex) (blobstore.BlobAccess, error)
+	// NewLocalKeyValidator() creates a KeyValidator that can be
+	// used by LocalBlobAccess's scrubber to check whether the
+	// contents of blobs correspond to the keys under which they
+	// are stored.
+	NewLocalKeyValidator() (local.KeyValidator, error)
 	// NewCustomBlobAccess() can be used as a fallback to create
 	// BlobAccess instances that only apply to this storage type.
 	// For example, CompletenessCheckingBlobAccess is only
@@ -5,9 +5,14 @@ import (
 	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
 	"github.com/buildbarn/bb-storage/pkg/blobstore"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/erasurecoding"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/grpcclients"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/local"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/sharding"
+	"github.com/buildbarn/bb-storage/pkg/capabilities"
 	"github.com/buildbarn/bb-storage/pkg/cloud/aws"
 	"github.com/buildbarn/bb-storage/pkg/digest"
 	"github.com/buildbarn/bb-storage/pkg/grpc"
@@ -24,6 +29,7 @@ type casBlobAccessCreator struct {
 	casBlobReplicatorCreator
 
 	maximumMessageSizeBytes int
+	capabilitiesProvider    capabilities.Provider
 }
 
 // NewCASBlobAccessCreator creates a BlobAccessCreator that can be
@@ -36,6 +42,14 @@ func NewCASBlobAccessCreator(grpcClientFactory grpc.ClientFactory, maximumMessag
 			grpcClientFactory: grpcClientFactory,
 		},
 		maximumMessageSizeBytes: maximumMessageSizeBytes,
+		capabilitiesProvider: capabilities.NewStaticProvider(&remoteexecution.ServerCapabilities{
+			CacheCapabilities: &remoteexecution.CacheCapabilities{
+				DigestFunctions:             digest.SupportedDigestFunctions,
+				MaxBatchTotalSizeBytes:      int64(maximumMessageSizeBytes),
+				SymlinkAbsolutePathStrategy: remoteexecution.SymlinkAbsolutePathStrategy_ALLOWED,
+				SupportedCompressors:        buffer.SupportedCompressors,
+			},
+		}),
 	}
 }
 
@@ -43,6 +57,10 @@ func (bac *casBlobAccessCreator) GetBaseDigestKeyFormat() digest.KeyFormat {
 	return digest.KeyWithoutInstance
 }
 
+func (bac *casBlobAccessCreator) GetDefaultCapabilitiesProvider() capabilities.Provider {
+	return bac.capabilitiesProvider
+}
+
 func (bac *casBlobAccessCreator) GetReadBufferFactory() blobstore.ReadBufferFactory {
 	return blobstore.CASReadBufferFactory
 }
@@ -56,11 +74,52 @@ func (bac *casBlobAccessCreator) NewBlockListGrowthPolicy(currentBlocks, newBloc
 }
 
 func (bac *casBlobAccessCreator) NewHierarchicalInstanceNamesLocalBlobAccess(keyLocationMap local.KeyLocationMap, locationBlobMap local.LocationBlobMap, globalLock *sync.RWMutex) (blobstore.BlobAccess, error) {
-	return local.NewHierarchicalCASBlobAccess(keyLocationMap, locationBlobMap, globalLock), nil
+	return local.NewHierarchicalCASBlobAccess(keyLocationMap, locationBlobMap, globalLock, bac.capabilitiesProvider), nil
+}
+
+func (bac *casBlobAccessCreator) NewLocalKeyValidator() (local.KeyValidator, error) {
+	return local.NewCASKeyValidator(nil), nil
 }
 
 func (bac *casBlobAccessCreator) NewCustomBlobAccess(configuration *pb.BlobAccessConfiguration) (BlobAccessInfo, string, error) {
 	switch backend := configuration.Backend.(type) {
+	case *pb.BlobAccessConfiguration_ErasureCoding:
+		dataShards, parityShards := int(backend.ErasureCoding.DataShards), int(backend.ErasureCoding.ParityShards)
+		if dataShards < 1 {
+			return BlobAccessInfo{}, "", status.Error(codes.InvalidArgument, "The number of data shards must be at least 1")
+		}
+
+		// Fragments cannot be validated against the digest of
+		// the object, which is why backends are created using
+		// a separate BlobAccessCreator.
+		fragmentCreator := newFragmentBlobAccessCreator()
+		backends := make([]blobstore.BlobAccess, 0, len(backend.ErasureCoding.Backends))
+		weights := make([]uint32, 0, len(backend.ErasureCoding.Backends))
+		combinedDigestKeyFormat := digest.KeyWithoutInstance
+		for i, backendConfiguration := range backend.ErasureCoding.Backends {
+			backend, err := NewNestedBlobAccess(backendConfiguration, fragmentCreator)
+			if err != nil {
+				return BlobAccessInfo{}, "", util.StatusWrapf(err, "Backend %d", i)
+			}
+			backends = append(backends, backend.BlobAccess)
+			weights = append(weights, 1)
+			combinedDigestKeyFormat = combinedDigestKeyFormat.Combine(backend.DigestKeyFormat)
+		}
+		blobAccess, err := erasurecoding.NewErasureCodingBlobAccess(
+			backends,
+			sharding.NewWeightedShardPermuter(weights),
+			backend.ErasureCoding.HashInitialization,
+			dataShards,
+			parityShards,
+			backend.ErasureCoding.MaximumBlobSizeBytes,
+			bac.capabilitiesProvider)
+		if err != nil {
+			return BlobAccessInfo{}, "", err
+		}
+		return BlobAccessInfo{
+			BlobAccess:      blobAccess,
+			DigestKeyFormat: combinedDigestKeyFormat,
+		}, "erasure_coding", nil
 	case *pb.BlobAccessConfiguration_ExistenceCaching:
 		base, err := NewNestedBlobAccess(backend.ExistenceCaching.Backend, bac)
 		if err != nil {
@@ -82,7 +141,7 @@ func (bac *casBlobAccessCreator) NewCustomBlobAccess(configuration *pb.BlobAcces
 		// TODO: Should we provide a configuration option, so
 		// that digest.KeyWithoutInstance can be used?
 		return BlobAccessInfo{
-			BlobAccess:      grpcclients.NewCASBlobAccess(client, uuid.NewRandom, 65536),
+			BlobAccess:      grpcclients.NewCASBlobAccess(client, uuid.NewRandom, 65536, backend.Grpc.ByteStreamCompressor),
 			DigestKeyFormat: digest.KeyWithInstance,
 		}, "grpc", nil
 	case *pb.BlobAccessConfiguration_ReferenceExpanding:
@@ -113,7 +172,8 @@ func (bac *casBlobAccessCreator) NewCustomBlobAccess(configuration *pb.BlobAcces
 				base.BlobAccess,
 				&http.Client{Transport: roundTripper},
 				s3.NewFromConfig(cfg),
-				bac.maximumMessageSizeBytes),
+				bac.maximumMessageSizeBytes,
+				bac.capabilitiesProvider),
 			DigestKeyFormat: base.DigestKeyFormat,
 		}, "reference_expanding", nil
 	default:
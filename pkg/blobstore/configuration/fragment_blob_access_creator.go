@@ -0,0 +1,45 @@
+package configuration
+
+import (
+	"github.com/buildbarn/bb-storage/pkg/blobstore"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/local"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	pb "github.com/buildbarn/bb-storage/pkg/proto/configuration/blobstore"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fragmentBlobAccessCreator struct {
+	protoBlobAccessCreator
+}
+
+// newFragmentBlobAccessCreator creates a BlobAccessCreator that is
+// used to construct the backends of ErasureCodingBlobAccess. These
+// backends store fragments of CAS objects under the digest of the
+// object to which they belong.
+func newFragmentBlobAccessCreator() BlobAccessCreator {
+	return &fragmentBlobAccessCreator{}
+}
+
+func (bac *fragmentBlobAccessCreator) GetBaseDigestKeyFormat() digest.KeyFormat {
+	return digest.KeyWithoutInstance
+}
+
+func (bac *fragmentBlobAccessCreator) GetReadBufferFactory() blobstore.ReadBufferFactory {
+	return blobstore.FragmentReadBufferFactory
+}
+
+func (bac *fragmentBlobAccessCreator) GetStorageTypeName() string {
+	return "fragment"
+}
+
+func (bac *fragmentBlobAccessCreator) NewBlockListGrowthPolicy(currentBlocks, newBlocks int) (local.BlockListGrowthPolicy, error) {
+	// Fragments of a given object never change, as the backends
+	// in which they are stored are chosen deterministically.
+	return local.NewImmutableBlockListGrowthPolicy(currentBlocks, newBlocks), nil
+}
+
+func (bac *fragmentBlobAccessCreator) NewCustomBlobAccess(configuration *pb.BlobAccessConfiguration) (BlobAccessInfo, string, error) {
+	return BlobAccessInfo{}, "", status.Error(codes.InvalidArgument, "Configuration did not contain a supported storage backend")
+}
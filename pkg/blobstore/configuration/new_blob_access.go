@@ -6,10 +6,13 @@ import (
 	"time"
 
 	"github.com/buildbarn/bb-storage/pkg/blobstore"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/hedged"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/local"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/mirrored"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/quorum"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/readcaching"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/readfallback"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/replication"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/sharding"
 	"github.com/buildbarn/bb-storage/pkg/blockdevice"
 	"github.com/buildbarn/bb-storage/pkg/clock"
@@ -18,6 +21,7 @@ import (
 	"github.com/buildbarn/bb-storage/pkg/grpc"
 	bb_http "github.com/buildbarn/bb-storage/pkg/http"
 	pb "github.com/buildbarn/bb-storage/pkg/proto/configuration/blobstore"
+	blockdevice_pb "github.com/buildbarn/bb-storage/pkg/proto/configuration/blockdevice"
 	"github.com/buildbarn/bb-storage/pkg/random"
 	"github.com/buildbarn/bb-storage/pkg/util"
 	"github.com/go-redis/redis/extra/redisotel"
@@ -41,6 +45,56 @@ func newRedisClient(opt *redis.Options) *redis.Client {
 	return client
 }
 
+// newLatencyTrackerFromConfiguration creates a LatencyTracker that is
+// used by HedgedBlobAccess to determine when to send hedged requests.
+func newLatencyTrackerFromConfiguration(configuration *pb.HedgingConfiguration) (hedged.LatencyTracker, error) {
+	if configuration.LatencyPercentile <= 0 || configuration.LatencyPercentile > 1 {
+		return nil, status.Error(codes.InvalidArgument, "Latency percentile must be in range (0, 1]")
+	}
+	if configuration.LatencyWindowSize == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Latency window size must be positive")
+	}
+	if err := configuration.MinimumDelay.CheckValid(); err != nil {
+		return nil, util.StatusWrap(err, "Failed to obtain minimum delay")
+	}
+	if err := configuration.MaximumDelay.CheckValid(); err != nil {
+		return nil, util.StatusWrap(err, "Failed to obtain maximum delay")
+	}
+	return hedged.NewPercentileLatencyTracker(
+		int(configuration.LatencyWindowSize),
+		configuration.LatencyPercentile,
+		configuration.MinimumDelay.AsDuration(),
+		configuration.MaximumDelay.AsDuration()), nil
+}
+
+// newFaultsFromConfiguration converts the faults that
+// FaultInjectionBlobAccess should inject into a single type of operation
+// from its configuration message.
+func newFaultsFromConfiguration(configuration *pb.FaultInjectionBlobAccessConfiguration_Faults) (blobstore.Faults, error) {
+	if configuration == nil {
+		return blobstore.Faults{}, nil
+	}
+	faults := blobstore.Faults{
+		LatencyProbability:    configuration.LatencyProbability,
+		ErrorProbability:      configuration.ErrorProbability,
+		TruncationProbability: configuration.TruncationProbability,
+		CorruptionProbability: configuration.CorruptionProbability,
+	}
+	if faults.LatencyProbability > 0 {
+		if err := configuration.Latency.CheckValid(); err != nil {
+			return blobstore.Faults{}, util.StatusWrap(err, "Failed to obtain latency")
+		}
+		faults.Latency = configuration.Latency.AsDuration()
+	}
+	if faults.ErrorProbability > 0 {
+		faults.Error = status.FromProto(configuration.Error)
+		if faults.Error.Code() == codes.OK {
+			return blobstore.Faults{}, status.Error(codes.InvalidArgument, "Error must have a non-zero status code")
+		}
+	}
+	return faults, nil
+}
+
 func newNestedBlobAccessBare(configuration *pb.BlobAccessConfiguration, creator BlobAccessCreator) (BlobAccessInfo, string, error) {
 	readBufferFactory := creator.GetReadBufferFactory()
 	storageTypeName := creator.GetStorageTypeName()
@@ -50,6 +104,126 @@ func newNestedBlobAccessBare(configuration *pb.BlobAccessConfiguration, creator
 			BlobAccess:      blobstore.NewErrorBlobAccess(status.ErrorProto(backend.Error)),
 			DigestKeyFormat: digest.KeyWithoutInstance,
 		}, "error", nil
+	case *pb.BlobAccessConfiguration_Coalescing:
+		base, err := NewNestedBlobAccess(backend.Coalescing, creator)
+		if err != nil {
+			return BlobAccessInfo{}, "", err
+		}
+		return BlobAccessInfo{
+			BlobAccess:      blobstore.NewCoalescingBlobAccess(base.BlobAccess, readBufferFactory),
+			DigestKeyFormat: base.DigestKeyFormat,
+		}, "coalescing", nil
+	case *pb.BlobAccessConfiguration_Retrying:
+		base, baseBackendType, err := newNestedBlobAccessWithBackendType(backend.Retrying.Backend, creator)
+		if err != nil {
+			return BlobAccessInfo{}, "", err
+		}
+		if backend.Retrying.MaximumAttempts == 0 {
+			return BlobAccessInfo{}, "", status.Error(codes.InvalidArgument, "Maximum number of attempts must be positive")
+		}
+		if err := backend.Retrying.InitialBackoff.CheckValid(); err != nil {
+			return BlobAccessInfo{}, "", util.StatusWrap(err, "Failed to obtain initial back-off")
+		}
+		if err := backend.Retrying.MaximumBackoff.CheckValid(); err != nil {
+			return BlobAccessInfo{}, "", util.StatusWrap(err, "Failed to obtain maximum back-off")
+		}
+		circuitBreaker := blobstore.NoCircuitBreaker
+		if circuitBreakerConfiguration := backend.Retrying.CircuitBreaker; circuitBreakerConfiguration != nil {
+			if circuitBreakerConfiguration.FailureThreshold == 0 {
+				return BlobAccessInfo{}, "", status.Error(codes.InvalidArgument, "Circuit breaker failure threshold must be positive")
+			}
+			if err := circuitBreakerConfiguration.OpenDuration.CheckValid(); err != nil {
+				return BlobAccessInfo{}, "", util.StatusWrap(err, "Failed to obtain circuit breaker open duration")
+			}
+			circuitBreaker = blobstore.NewConsecutiveFailuresCircuitBreaker(
+				clock.SystemClock,
+				int(circuitBreakerConfiguration.FailureThreshold),
+				circuitBreakerConfiguration.OpenDuration.AsDuration(),
+				storageTypeName,
+				baseBackendType)
+		}
+		// Only objects stored in the CAS are immutable. Retrying
+		// Put() calls for other storage types may cause newer
+		// objects to be overwritten by older ones.
+		var maximumPutRetrySizeBytes int64
+		if storageTypeName == "cas" {
+			maximumPutRetrySizeBytes = backend.Retrying.MaximumPutRetrySizeBytes
+		}
+		return BlobAccessInfo{
+			BlobAccess: blobstore.NewRetryingBlobAccess(
+				base.BlobAccess,
+				clock.SystemClock,
+				random.FastThreadSafeGenerator,
+				int(backend.Retrying.MaximumAttempts),
+				backend.Retrying.InitialBackoff.AsDuration(),
+				backend.Retrying.MaximumBackoff.AsDuration(),
+				maximumPutRetrySizeBytes,
+				circuitBreaker),
+			DigestKeyFormat: base.DigestKeyFormat,
+		}, "retrying", nil
+	case *pb.BlobAccessConfiguration_FaultInjection:
+		base, err := NewNestedBlobAccess(backend.FaultInjection.Backend, creator)
+		if err != nil {
+			return BlobAccessInfo{}, "", err
+		}
+		getFaults, err := newFaultsFromConfiguration(backend.FaultInjection.Get)
+		if err != nil {
+			return BlobAccessInfo{}, "", util.StatusWrap(err, "Get")
+		}
+		putFaults, err := newFaultsFromConfiguration(backend.FaultInjection.Put)
+		if err != nil {
+			return BlobAccessInfo{}, "", util.StatusWrap(err, "Put")
+		}
+		findMissingFaults, err := newFaultsFromConfiguration(backend.FaultInjection.FindMissing)
+		if err != nil {
+			return BlobAccessInfo{}, "", util.StatusWrap(err, "FindMissing")
+		}
+		filter := blobstore.FaultInjectionFilter{
+			MinimumSizeBytes: backend.FaultInjection.MinimumSizeBytes,
+			MaximumSizeBytes: backend.FaultInjection.MaximumSizeBytes,
+		}
+		for _, k := range backend.FaultInjection.InstanceNamePrefixes {
+			instanceNamePrefix, err := digest.NewInstanceName(k)
+			if err != nil {
+				return BlobAccessInfo{}, "", util.StatusWrapf(err, "Invalid instance name %#v", k)
+			}
+			filter.InstanceNamePrefixes = append(filter.InstanceNamePrefixes, instanceNamePrefix)
+		}
+		return BlobAccessInfo{
+			BlobAccess: blobstore.NewFaultInjectionBlobAccess(
+				base.BlobAccess,
+				readBufferFactory,
+				clock.SystemClock,
+				random.FastThreadSafeGenerator,
+				filter,
+				getFaults,
+				putFaults,
+				findMissingFaults),
+			DigestKeyFormat: base.DigestKeyFormat,
+		}, "fault_injection", nil
+	case *pb.BlobAccessConfiguration_RateLimiting:
+		base, err := NewNestedBlobAccess(backend.RateLimiting.Backend, creator)
+		if err != nil {
+			return BlobAccessInfo{}, "", err
+		}
+		limits := make([]blobstore.RateLimits, 0, len(backend.RateLimiting.InstanceNamePrefixes))
+		for k, l := range backend.RateLimiting.InstanceNamePrefixes {
+			instanceNamePrefix, err := digest.NewInstanceName(k)
+			if err != nil {
+				return BlobAccessInfo{}, "", util.StatusWrapf(err, "Invalid instance name %#v", k)
+			}
+			limits = append(limits, blobstore.RateLimits{
+				InstanceNamePrefix:   instanceNamePrefix,
+				RequestsPerSecond:    l.RequestsPerSecond,
+				ReadBytesPerSecond:   l.ReadBytesPerSecond,
+				WriteBytesPerSecond:  l.WriteBytesPerSecond,
+				MaximumBlobSizeBytes: l.MaximumBlobSizeBytes,
+			})
+		}
+		return BlobAccessInfo{
+			BlobAccess:      blobstore.NewRateLimitingBlobAccess(base.BlobAccess, clock.SystemClock, limits, creator.GetStorageTypeName() == "cas"),
+			DigestKeyFormat: base.DigestKeyFormat,
+		}, "rate_limiting", nil
 	case *pb.BlobAccessConfiguration_ReadCaching:
 		slow, err := NewNestedBlobAccess(backend.ReadCaching.Slow, creator)
 		if err != nil {
@@ -166,7 +340,8 @@ func newNestedBlobAccessBare(configuration *pb.BlobAccessConfiguration, creator
 				readBufferFactory,
 				digestKeyFormat,
 				backend.Redis.ReplicationCount,
-				replicationTimeout),
+				replicationTimeout,
+				creator.GetDefaultCapabilitiesProvider()),
 			DigestKeyFormat: digestKeyFormat,
 		}, "redis", nil
 	case *pb.BlobAccessConfiguration_Http:
@@ -179,7 +354,8 @@ func newNestedBlobAccessBare(configuration *pb.BlobAccessConfiguration, creator
 				backend.Http.Address,
 				storageTypeName,
 				readBufferFactory,
-				&http.Client{Transport: roundTripper}),
+				&http.Client{Transport: roundTripper},
+				creator.GetDefaultCapabilitiesProvider()),
 			DigestKeyFormat: digest.KeyWithInstance,
 		}, "remote", nil
 	case *pb.BlobAccessConfiguration_Sharding:
@@ -213,11 +389,51 @@ func newNestedBlobAccessBare(configuration *pb.BlobAccessConfiguration, creator
 		if combinedDigestKeyFormat == nil {
 			return BlobAccessInfo{}, "", status.Errorf(codes.InvalidArgument, "Cannot create sharding blob access without any undrained backends")
 		}
+		replicationFactor := 1
+		if backend.Sharding.ReplicationFactor > 1 {
+			replicationFactor = int(backend.Sharding.ReplicationFactor)
+			undrainedBackends := 0
+			for _, shardBackend := range backends {
+				if shardBackend != nil {
+					undrainedBackends++
+				}
+			}
+			if replicationFactor > undrainedBackends {
+				return BlobAccessInfo{}, "", status.Errorf(codes.InvalidArgument, "Replication factor %d exceeds the number of undrained backends (%d)", replicationFactor, undrainedBackends)
+			}
+		}
+		var healthTracker sharding.ShardHealthTracker
+		if failover := backend.Sharding.Failover; failover != nil {
+			if failover.MaximumConsecutiveFailures == 0 {
+				return BlobAccessInfo{}, "", status.Error(codes.InvalidArgument, "Maximum number of consecutive failures must be positive")
+			}
+			if err := failover.MinimumBackoff.CheckValid(); err != nil {
+				return BlobAccessInfo{}, "", util.StatusWrap(err, "Failed to obtain minimum back-off")
+			}
+			if err := failover.MaximumBackoff.CheckValid(); err != nil {
+				return BlobAccessInfo{}, "", util.StatusWrap(err, "Failed to obtain maximum back-off")
+			}
+			minimumBackoff := failover.MinimumBackoff.AsDuration()
+			maximumBackoff := failover.MaximumBackoff.AsDuration()
+			if minimumBackoff > maximumBackoff {
+				return BlobAccessInfo{}, "", status.Error(codes.InvalidArgument, "Minimum back-off must not exceed the maximum back-off")
+			}
+			healthTracker = sharding.NewBackoffShardHealthTracker(
+				clock.SystemClock,
+				len(backends),
+				int(failover.MaximumConsecutiveFailures),
+				minimumBackoff,
+				maximumBackoff)
+		}
 		return BlobAccessInfo{
 			BlobAccess: sharding.NewShardingBlobAccess(
 				backends,
 				sharding.NewWeightedShardPermuter(weights),
-				backend.Sharding.HashInitialization),
+				backend.Sharding.HashInitialization,
+				replicationFactor,
+				backend.Sharding.ReadRepair,
+				healthTracker,
+				util.DefaultErrorLogger),
 			DigestKeyFormat: *combinedDigestKeyFormat,
 		}, "sharding", nil
 	case *pb.BlobAccessConfiguration_SizeDistinguishing:
@@ -250,10 +466,77 @@ func newNestedBlobAccessBare(configuration *pb.BlobAccessConfiguration, creator
 		if err != nil {
 			return BlobAccessInfo{}, "", err
 		}
+		maximumJournalSize := 0
+		if degradedMode := backend.Mirrored.DegradedMode; degradedMode != nil {
+			if degradedMode.MaximumJournalSize == 0 {
+				return BlobAccessInfo{}, "", status.Error(codes.InvalidArgument, "Maximum journal size for degraded mode must be positive")
+			}
+			maximumJournalSize = int(degradedMode.MaximumJournalSize)
+		}
+		blobAccessA, blobAccessB := backendA.BlobAccess, backendB.BlobAccess
+		if hedging := backend.Mirrored.Hedging; hedging != nil {
+			// Let each backend send hedged requests to the
+			// other backend.
+			latencyTrackerA, err := newLatencyTrackerFromConfiguration(hedging)
+			if err != nil {
+				return BlobAccessInfo{}, "", err
+			}
+			latencyTrackerB, err := newLatencyTrackerFromConfiguration(hedging)
+			if err != nil {
+				return BlobAccessInfo{}, "", err
+			}
+			blobAccessA = hedged.NewHedgedBlobAccess(backendA.BlobAccess, backendB.BlobAccess, clock.SystemClock, latencyTrackerA)
+			blobAccessB = hedged.NewHedgedBlobAccess(backendB.BlobAccess, backendA.BlobAccess, clock.SystemClock, latencyTrackerB)
+		}
 		return BlobAccessInfo{
-			BlobAccess:      mirrored.NewMirroredBlobAccess(backendA.BlobAccess, backendB.BlobAccess, replicatorAToB, replicatorBToA),
+			BlobAccess:      mirrored.NewMirroredBlobAccess(blobAccessA, blobAccessB, replicatorAToB, replicatorBToA, maximumJournalSize, clock.SystemClock, util.DefaultErrorLogger),
 			DigestKeyFormat: backendA.DigestKeyFormat.Combine(backendB.DigestKeyFormat),
 		}, "mirrored", nil
+	case *pb.BlobAccessConfiguration_Quorum:
+		backendCount := len(backend.Quorum.Backends)
+		readQuorum, writeQuorum := int(backend.Quorum.ReadQuorum), int(backend.Quorum.WriteQuorum)
+		if readQuorum < 1 || readQuorum > backendCount {
+			return BlobAccessInfo{}, "", status.Errorf(codes.InvalidArgument, "Read quorum must be between 1 and %d", backendCount)
+		}
+		if writeQuorum < 1 || writeQuorum > backendCount {
+			return BlobAccessInfo{}, "", status.Errorf(codes.InvalidArgument, "Write quorum must be between 1 and %d", backendCount)
+		}
+		if readQuorum+writeQuorum <= backendCount {
+			return BlobAccessInfo{}, "", status.Errorf(codes.InvalidArgument, "Sum of read and write quorums must exceed the number of backends (%d)", backendCount)
+		}
+
+		backends := make([]BlobAccessInfo, 0, backendCount)
+		for i, backendConfiguration := range backend.Quorum.Backends {
+			backend, err := NewNestedBlobAccess(backendConfiguration, creator)
+			if err != nil {
+				return BlobAccessInfo{}, "", util.StatusWrapf(err, "Backend %d", i)
+			}
+			backends = append(backends, backend)
+		}
+
+		// Create a replicator for every pair of backends.
+		backendBlobAccesses := make([]blobstore.BlobAccess, 0, backendCount)
+		replicators := make([][]replication.BlobReplicator, 0, backendCount)
+		combinedDigestKeyFormat := backends[0].DigestKeyFormat
+		for i, source := range backends {
+			backendBlobAccesses = append(backendBlobAccesses, source.BlobAccess)
+			combinedDigestKeyFormat = combinedDigestKeyFormat.Combine(source.DigestKeyFormat)
+			replicatorsFromSource := make([]replication.BlobReplicator, backendCount)
+			for j, sink := range backends {
+				if i != j {
+					replicator, err := NewBlobReplicatorFromConfiguration(backend.Quorum.Replicator, source.BlobAccess, sink, creator)
+					if err != nil {
+						return BlobAccessInfo{}, "", util.StatusWrapf(err, "Replicator from backend %d to backend %d", i, j)
+					}
+					replicatorsFromSource[j] = replicator
+				}
+			}
+			replicators = append(replicators, replicatorsFromSource)
+		}
+		return BlobAccessInfo{
+			BlobAccess:      quorum.NewQuorumBlobAccess(backendBlobAccesses, readQuorum, writeQuorum, replicators, util.DefaultErrorLogger),
+			DigestKeyFormat: combinedDigestKeyFormat,
+		}, "quorum", nil
 	case *pb.BlobAccessConfiguration_Local:
 		digestKeyFormat := digest.KeyWithInstance
 		if !backend.Local.HierarchicalInstanceNames {
@@ -280,23 +563,39 @@ func newNestedBlobAccessBare(configuration *pb.BlobAccessConfiguration, creator
 			blockAllocator = local.NewInMemoryBlockAllocator(int(blocksBackend.BlocksInMemory.BlockSizeBytes))
 		case *pb.LocalBlobAccessConfiguration_BlocksOnBlockDevice_:
 			backendType = "local_block_device"
-			// Data may be stored on a block device that is
-			// memory mapped. Automatically determine the
-			// block size based on the size of the block
-			// device and the number of blocks.
+			// Data may be stored on one or more block
+			// devices that are memory mapped. Automatically
+			// determine the block size based on the size of
+			// the block devices and the number of blocks.
 			blocksOnBlockDevice := blocksBackend.BlocksOnBlockDevice
-			var blockDevice blockdevice.BlockDevice
-			var sectorCount int64
-			var err error
-			blockDevice, sectorSizeBytes, sectorCount, err = blockdevice.NewBlockDeviceFromConfiguration(
-				blocksOnBlockDevice.Source,
+			sources := blocksOnBlockDevice.Sources
+			if blocksOnBlockDevice.Source != nil {
+				if len(sources) > 0 {
+					return BlobAccessInfo{}, "", status.Error(codes.InvalidArgument, "Options \"source\" and \"sources\" are mutually exclusive")
+				}
+				sources = []*blockdevice_pb.Configuration{blocksOnBlockDevice.Source}
+			}
+			blockDevices, sectorSize, sectorCounts, err := blockdevice.NewBlockDevicesFromConfiguration(
+				sources,
 				persistent == nil)
 			if err != nil {
 				return BlobAccessInfo{}, "", util.StatusWrap(err, "Failed to open blocks block device")
 			}
-			dataSyncer = blockDevice.Sync
+			sectorSizeBytes = sectorSize
+			dataSyncer = func() error {
+				for _, blockDevice := range blockDevices {
+					if err := blockDevice.Sync(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
 			blockCount := blocksOnBlockDevice.SpareBlocks + backend.Local.OldBlocks + backend.Local.CurrentBlocks + backend.Local.NewBlocks
-			blockSectorCount = sectorCount / int64(blockCount)
+			var blockCounts []int
+			blockSectorCount, blockCounts, err = local.GetBlockDeviceBackedBlockLayout(sectorCounts, int(blockCount))
+			if err != nil {
+				return BlobAccessInfo{}, "", err
+			}
 
 			cachedReadBufferFactory := readBufferFactory
 			if cacheConfiguration := blocksOnBlockDevice.DataIntegrityValidationCache; cacheConfiguration != nil {
@@ -310,11 +609,12 @@ func newNestedBlobAccessBare(configuration *pb.BlobAccessConfiguration, creator
 			}
 
 			blockAllocator = local.NewBlockDeviceBackedBlockAllocator(
-				blockDevice,
+				blockDevices,
 				cachedReadBufferFactory,
 				sectorSizeBytes,
 				blockSectorCount,
-				int(blockCount))
+				blockCounts,
+				!blocksOnBlockDevice.DisableDiscarding)
 		default:
 			return BlobAccessInfo{}, "", status.Error(codes.InvalidArgument, "Blocks backend not specified")
 		}
@@ -322,6 +622,8 @@ func newNestedBlobAccessBare(configuration *pb.BlobAccessConfiguration, creator
 		var globalLock sync.RWMutex
 		var blockList local.BlockList
 		var keyLocationMapHashInitialization uint64
+		var periodicSyncer *local.PeriodicSyncer
+		keyLocationMapMigrationCompleted := false
 		initialBlockCount := 0
 		if persistent == nil {
 			// Persistency is disabled. Provide a simple
@@ -344,6 +646,7 @@ func newNestedBlobAccessBare(configuration *pb.BlobAccessConfiguration, creator
 				return BlobAccessInfo{}, "", util.StatusWrap(err, "Failed to reload persistent state")
 			}
 			keyLocationMapHashInitialization = persistentState.KeyLocationMapHashInitialization
+			keyLocationMapMigrationCompleted = persistentState.KeyLocationMapMigrationCompleted && backend.Local.KeyLocationMapMigrationSource != nil
 
 			// Create a persistent BlockList. This will
 			// attempt to reattach the old blocks. The
@@ -366,7 +669,7 @@ func newNestedBlobAccessBare(configuration *pb.BlobAccessConfiguration, creator
 				return BlobAccessInfo{}, "", util.StatusWrap(err, "Failed to obtain minimum epoch duration")
 			}
 			minimumEpochInterval := persistent.MinimumEpochInterval.AsDuration()
-			periodicSyncer := local.NewPeriodicSyncer(
+			periodicSyncer = local.NewPeriodicSyncer(
 				persistentBlockList,
 				&globalLock,
 				persistentStateStore,
@@ -375,6 +678,7 @@ func newNestedBlobAccessBare(configuration *pb.BlobAccessConfiguration, creator
 				10*time.Second,
 				minimumEpochInterval,
 				keyLocationMapHashInitialization,
+				keyLocationMapMigrationCompleted,
 				dataSyncer)
 			go func() {
 				for {
@@ -408,6 +712,7 @@ func newNestedBlobAccessBare(configuration *pb.BlobAccessConfiguration, creator
 		// Create the backing store for the key-location map.
 		var locationRecordArraySize int
 		var locationRecordArray local.LocationRecordArray
+		var keyLocationMapSyncer func() error
 		switch keyLocationMapBackend := backend.Local.KeyLocationMapBackend.(type) {
 		case *pb.LocalBlobAccessConfiguration_KeyLocationMapInMemory_:
 			locationRecordArraySize = int(keyLocationMapBackend.KeyLocationMapInMemory.Entries)
@@ -425,9 +730,43 @@ func newNestedBlobAccessBare(configuration *pb.BlobAccessConfiguration, creator
 			locationRecordArray = local.NewBlockDeviceBackedLocationRecordArray(
 				blockDevice,
 				locationBlobMap)
+			keyLocationMapSyncer = blockDevice.Sync
 		default:
 			return BlobAccessInfo{}, "", status.Errorf(codes.InvalidArgument, "Key-location map backend not specified")
 		}
+
+		// Create a scrubber that validates the contents of blobs
+		// in the background. It is only started once all other
+		// steps have succeeded.
+		var scrubber *local.Scrubber
+		if blocksOnBlockDevice := backend.Local.GetBlocksOnBlockDevice(); blocksOnBlockDevice != nil && blocksOnBlockDevice.Scrubbing != nil {
+			scrubbing := blocksOnBlockDevice.Scrubbing
+			if backend.Local.HierarchicalInstanceNames {
+				return BlobAccessInfo{}, "", status.Error(codes.InvalidArgument, "Scrubbing cannot be used in combination with hierarchical instance names, as keys of blobs cannot be derived from their contents")
+			}
+			keyValidator, err := creator.NewLocalKeyValidator()
+			if err != nil {
+				return BlobAccessInfo{}, "", err
+			}
+			if err := scrubbing.PassInterval.CheckValid(); err != nil {
+				return BlobAccessInfo{}, "", util.StatusWrap(err, "Failed to obtain scrubbing pass interval")
+			}
+			if scrubbing.PassInterval.AsDuration() <= 0 {
+				return BlobAccessInfo{}, "", status.Error(codes.InvalidArgument, "Scrubbing pass interval must be positive")
+			}
+			scrubber = local.NewScrubber(
+				&globalLock,
+				locationRecordArray,
+				locationRecordArraySize,
+				locationBlobMap,
+				keyValidator,
+				clock.SystemClock,
+				util.DefaultErrorLogger,
+				storageTypeName,
+				scrubbing.BytesPerSecond,
+				scrubbing.PassInterval.AsDuration())
+		}
+
 		keyLocationMap := local.NewHashingKeyLocationMap(
 			locationRecordArray,
 			locationRecordArraySize,
@@ -436,6 +775,45 @@ func newNestedBlobAccessBare(configuration *pb.BlobAccessConfiguration, creator
 			int(backend.Local.KeyLocationMapMaximumPutAttempts),
 			storageTypeName)
 
+		// Rehash the entries of a key-location map that was
+		// used previously, so that it may be resized without
+		// discarding any data. The migration source is opened
+		// read-only, so that it is left intact if migration
+		// fails. Completion is recorded in the persistent state,
+		// so that migration is only performed once.
+		if migrationSource := backend.Local.KeyLocationMapMigrationSource; migrationSource != nil {
+			if persistent == nil {
+				return BlobAccessInfo{}, "", status.Error(codes.InvalidArgument, "Key-location map migration can only be used if persistency is enabled")
+			}
+			if !keyLocationMapMigrationCompleted {
+				blockDevice, sectorSizeBytes, sectorCount, err := blockdevice.NewReadOnlyBlockDeviceFromConfiguration(migrationSource)
+				if err != nil {
+					return BlobAccessInfo{}, "", util.StatusWrap(err, "Failed to open key-location map migration source block device")
+				}
+				globalLock.Lock()
+				migrated, err := local.MigrateKeyLocationMap(
+					local.NewBlockDeviceBackedLocationRecordArray(blockDevice, locationBlobMap),
+					int((int64(sectorSizeBytes)*sectorCount)/local.BlockDeviceBackedLocationRecordSize),
+					keyLocationMapHashInitialization,
+					keyLocationMap)
+				globalLock.Unlock()
+				if err != nil {
+					return BlobAccessInfo{}, "", util.StatusWrap(err, "Failed to migrate key-location map")
+				}
+				if migrated == 0 {
+					return BlobAccessInfo{}, "", status.Error(codes.FailedPrecondition, "Key-location map migration source does not contain any valid entries")
+				}
+				if keyLocationMapSyncer != nil {
+					if err := keyLocationMapSyncer(); err != nil {
+						return BlobAccessInfo{}, "", util.StatusWrap(err, "Failed to synchronize migrated key-location map")
+					}
+				}
+				if err := periodicSyncer.MarkKeyLocationMapMigrationCompleted(); err != nil {
+					return BlobAccessInfo{}, "", util.StatusWrap(err, "Failed to record completion of key-location map migration")
+				}
+			}
+		}
+
 		var localBlobAccess blobstore.BlobAccess
 		if backend.Local.HierarchicalInstanceNames {
 			localBlobAccess, err = creator.NewHierarchicalInstanceNamesLocalBlobAccess(
@@ -458,7 +836,16 @@ func newNestedBlobAccessBare(configuration *pb.BlobAccessConfiguration, creator
 					locationBlobMap),
 				digestKeyFormat,
 				&globalLock,
-				storageTypeName)
+				storageTypeName,
+				creator.GetDefaultCapabilitiesProvider())
+		}
+
+		if scrubber != nil {
+			go func() {
+				for {
+					scrubber.ProcessLocationRecord()
+				}
+			}()
 		}
 		return BlobAccessInfo{
 			BlobAccess:      localBlobAccess,
@@ -477,8 +864,16 @@ func newNestedBlobAccessBare(configuration *pb.BlobAccessConfiguration, creator
 		if err != nil {
 			return BlobAccessInfo{}, "", err
 		}
+		primaryBlobAccess := primary.BlobAccess
+		if hedging := backend.ReadFallback.Hedging; hedging != nil {
+			latencyTracker, err := newLatencyTrackerFromConfiguration(hedging)
+			if err != nil {
+				return BlobAccessInfo{}, "", err
+			}
+			primaryBlobAccess = hedged.NewHedgedBlobAccess(primary.BlobAccess, secondary.BlobAccess, clock.SystemClock, latencyTracker)
+		}
 		return BlobAccessInfo{
-			BlobAccess:      readfallback.NewReadFallbackBlobAccess(primary.BlobAccess, secondary.BlobAccess, replicator),
+			BlobAccess:      readfallback.NewReadFallbackBlobAccess(primaryBlobAccess, secondary.BlobAccess, replicator),
 			DigestKeyFormat: primary.DigestKeyFormat.Combine(secondary.DigestKeyFormat),
 		}, "read_fallback", nil
 	case *pb.BlobAccessConfiguration_Demultiplexing:
@@ -530,18 +925,26 @@ func newNestedBlobAccessBare(configuration *pb.BlobAccessConfiguration, creator
 // BlobAccessCreator.NewCustomBlobAccess() to create BlobAccess
 // objects for instances nested inside the configuration.
 func NewNestedBlobAccess(configuration *pb.BlobAccessConfiguration, creator BlobAccessCreator) (BlobAccessInfo, error) {
+	backend, _, err := newNestedBlobAccessWithBackendType(configuration, creator)
+	return backend, err
+}
+
+// newNestedBlobAccessWithBackendType is identical to
+// NewNestedBlobAccess(), except that it also returns the type of the
+// backend that was created, as used in the labels of Prometheus metrics.
+func newNestedBlobAccessWithBackendType(configuration *pb.BlobAccessConfiguration, creator BlobAccessCreator) (BlobAccessInfo, string, error) {
 	if configuration == nil {
-		return BlobAccessInfo{}, status.Error(codes.InvalidArgument, "Storage configuration not specified")
+		return BlobAccessInfo{}, "", status.Error(codes.InvalidArgument, "Storage configuration not specified")
 	}
 
 	backend, backendType, err := newNestedBlobAccessBare(configuration, creator)
 	if err != nil {
-		return BlobAccessInfo{}, err
+		return BlobAccessInfo{}, "", err
 	}
 	return BlobAccessInfo{
 		BlobAccess:      blobstore.NewMetricsBlobAccess(backend.BlobAccess, clock.SystemClock, creator.GetStorageTypeName(), backendType),
 		DigestKeyFormat: backend.DigestKeyFormat,
-	}, nil
+	}, backendType, nil
 }
 
 // NewBlobAccessFromConfiguration creates a BlobAccess object based on a
@@ -3,8 +3,10 @@ package configuration
 import (
 	"sync"
 
+	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
 	"github.com/buildbarn/bb-storage/pkg/blobstore"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/local"
+	"github.com/buildbarn/bb-storage/pkg/capabilities"
 	"github.com/buildbarn/bb-storage/pkg/digest"
 	pb "github.com/buildbarn/bb-storage/pkg/proto/configuration/blobstore"
 
@@ -20,6 +22,14 @@ func (bac *protoBlobAccessCreator) GetBaseDigestKeyFormat() digest.KeyFormat {
 	return digest.KeyWithInstance
 }
 
+// emptyCapabilitiesProvider is used by storage types that are not part
+// of REv2, meaning there are no capabilities to report.
+var emptyCapabilitiesProvider = capabilities.NewStaticProvider(&remoteexecution.ServerCapabilities{})
+
+func (bac *protoBlobAccessCreator) GetDefaultCapabilitiesProvider() capabilities.Provider {
+	return emptyCapabilitiesProvider
+}
+
 func (bac *protoBlobAccessCreator) NewBlockListGrowthPolicy(currentBlocks, newBlocks int) (local.BlockListGrowthPolicy, error) {
 	if newBlocks != 1 {
 		return nil, status.Error(codes.InvalidArgument, "The number of \"new\" blocks must be set to 1 for this storage type, as objects cannot be updated reliably otherwise")
@@ -31,6 +41,10 @@ func (bac *protoBlobAccessCreator) NewHierarchicalInstanceNamesLocalBlobAccess(k
 	return nil, status.Error(codes.InvalidArgument, "The hierarchical instance names option can only be used for the Content Addressable Storage")
 }
 
+func (bac *protoBlobAccessCreator) NewLocalKeyValidator() (local.KeyValidator, error) {
+	return nil, status.Error(codes.InvalidArgument, "Scrubbing can only be used for the Content Addressable Storage")
+}
+
 func (bac *protoBlobAccessCreator) WrapTopLevelBlobAccess(blobAccess blobstore.BlobAccess) blobstore.BlobAccess {
 	return blobAccess
 }
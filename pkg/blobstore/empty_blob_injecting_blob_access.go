@@ -3,6 +3,7 @@ package blobstore
 import (
 	"context"
 
+	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
 	"github.com/buildbarn/bb-storage/pkg/digest"
 )
@@ -55,3 +56,7 @@ func (ba *emptyBlobInjectingBlobAccess) Put(ctx context.Context, digest digest.D
 func (ba *emptyBlobInjectingBlobAccess) FindMissing(ctx context.Context, digests digest.Set) (digest.Set, error) {
 	return ba.base.FindMissing(ctx, digests.RemoveEmptyBlob())
 }
+
+func (ba *emptyBlobInjectingBlobAccess) GetCapabilities(ctx context.Context, instanceName digest.InstanceName) (*remoteexecution.ServerCapabilities, error) {
+	return ba.base.GetCapabilities(ctx, instanceName)
+}
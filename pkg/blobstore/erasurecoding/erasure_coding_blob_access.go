@@ -0,0 +1,408 @@
+package erasurecoding
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"github.com/buildbarn/bb-storage/pkg/blobstore"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/sharding"
+	"github.com/buildbarn/bb-storage/pkg/capabilities"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/util"
+	"github.com/klauspost/reedsolomon"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type erasureCodingBlobAccess struct {
+	capabilities.Provider
+
+	backends             []blobstore.BlobAccess
+	shardPermuter        sharding.ShardPermuter
+	hashInitialization   uint64
+	dataShards           int
+	parityShards         int
+	maximumBlobSizeBytes int64
+	encoder              reedsolomon.Encoder
+}
+
+// NewErasureCodingBlobAccess creates a BlobAccess that splits blobs
+// into dataShards data fragments, and computes parityShards parity
+// fragments using Reed-Solomon coding. Each of these fragments is
+// stored in a different backend. A ShardPermuter is used to select the
+// backends for every blob, similar to ShardingBlobAccess.
+//
+// Blobs can be reconstructed from any dataShards fragments, meaning
+// that up to parityShards backends may be lost without losing data,
+// while only requiring (dataShards + parityShards) / dataShards times
+// the storage space of the original data.
+//
+// Fragments are stored under the digest of the blob to which they
+// belong. The backends must therefore not validate the contents of
+// the data against the digest. The integrity of blobs is validated
+// after they have been reconstructed. If validation fails, the blob is
+// reconstructed from other combinations of fragments, so that up to
+// parityShards corrupted fragments are tolerated.
+//
+// As blobs need to be held in memory for encoding and decoding, this
+// implementation only supports blobs up to a given size.
+//
+// Put() succeeds if at least dataShards fragments could be stored, as
+// that suffices to reconstruct the blob. Similarly, FindMissing()
+// considers fragments stored in backends that fail to be missing. The
+// empty blob is never stored, as it cannot be split into fragments.
+func NewErasureCodingBlobAccess(backends []blobstore.BlobAccess, shardPermuter sharding.ShardPermuter, hashInitialization uint64, dataShards, parityShards int, maximumBlobSizeBytes int64, capabilitiesProvider capabilities.Provider) (blobstore.BlobAccess, error) {
+	if len(backends) < dataShards+parityShards {
+		return nil, status.Errorf(codes.InvalidArgument, "Storing %d data shards and %d parity shards requires at least %d backends, while only %d were provided", dataShards, parityShards, dataShards+parityShards, len(backends))
+	}
+	if maximumBlobSizeBytes <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "The maximum blob size must be positive")
+	}
+	encoder, err := reedsolomon.New(dataShards, parityShards)
+	if err != nil {
+		return nil, util.StatusWrapWithCode(err, codes.InvalidArgument, "Failed to create Reed-Solomon encoder")
+	}
+	return &erasureCodingBlobAccess{
+		Provider:             capabilitiesProvider,
+		backends:             backends,
+		shardPermuter:        shardPermuter,
+		hashInitialization:   hashInitialization,
+		dataShards:           dataShards,
+		parityShards:         parityShards,
+		maximumBlobSizeBytes: maximumBlobSizeBytes,
+		encoder:              encoder,
+	}, nil
+}
+
+// getBackendIndices returns the indices of the backends in which the
+// fragments of a blob are stored. The data fragments are stored first,
+// followed by the parity fragments.
+func (ba *erasureCodingBlobAccess) getBackendIndices(blobDigest digest.Digest) []int {
+	// Hash the key using FNV-1a.
+	h := ba.hashInitialization
+	for _, c := range blobDigest.GetKey(digest.KeyWithoutInstance) {
+		h ^= uint64(c)
+		h *= 1099511628211
+	}
+
+	// Keep requesting backends until having obtained enough
+	// distinct ones. The permuter is permitted to return the same
+	// index multiple times.
+	totalShards := ba.dataShards + ba.parityShards
+	selectedIndices := make([]int, 0, totalShards)
+	ba.shardPermuter.GetShard(h, func(index int) bool {
+		for _, selectedIndex := range selectedIndices {
+			if selectedIndex == index {
+				return true
+			}
+		}
+		selectedIndices = append(selectedIndices, index)
+		return len(selectedIndices) < totalShards
+	})
+	return selectedIndices
+}
+
+func (ba *erasureCodingBlobAccess) checkBlobSize(blobDigest digest.Digest) error {
+	if sizeBytes := blobDigest.GetSizeBytes(); sizeBytes > ba.maximumBlobSizeBytes {
+		return status.Errorf(codes.InvalidArgument, "Blob is %d bytes in size, while this backend is limited to %d bytes", sizeBytes, ba.maximumBlobSizeBytes)
+	}
+	return nil
+}
+
+type fragmentResult struct {
+	shard int
+	data  []byte
+	err   error
+}
+
+func (ba *erasureCodingBlobAccess) Get(ctx context.Context, digest digest.Digest) buffer.Buffer {
+	if err := ba.checkBlobSize(digest); err != nil {
+		return buffer.NewBufferFromError(err)
+	}
+	if digest.GetSizeBytes() == 0 {
+		return buffer.NewCASBufferFromByteSlice(digest, nil, buffer.BackendProvided(buffer.Irreparable(digest)))
+	}
+	indices := ba.getBackendIndices(digest)
+	sizeBytes := digest.GetSizeBytes()
+	fragmentSizeBytes := (sizeBytes + int64(ba.dataShards) - 1) / int64(ba.dataShards)
+
+	// Request the data fragments first, as those can be joined
+	// without performing any decoding. For every fragment that
+	// cannot be obtained, request one of the parity fragments.
+	ctxWithCancel, cancel := context.WithCancel(ctx)
+	defer cancel()
+	totalShards := ba.dataShards + ba.parityShards
+	results := make(chan fragmentResult, totalShards)
+	fetchFragment := func(shard int) {
+		go func() {
+			data, err := ba.backends[indices[shard]].Get(ctxWithCancel, digest).ToByteSlice(int(fragmentSizeBytes))
+			if err == nil && int64(len(data)) != fragmentSizeBytes {
+				err = status.Errorf(codes.Internal, "Fragment is %d bytes in size, while %d bytes were expected", len(data), fragmentSizeBytes)
+			}
+			results <- fragmentResult{shard: shard, data: data, err: err}
+		}()
+	}
+	for shard := 0; shard < ba.dataShards; shard++ {
+		fetchFragment(shard)
+	}
+
+	shards := make([][]byte, totalShards)
+	nextShard, pendingShards := ba.dataShards, ba.dataShards
+	availableShards, missingShards := 0, 0
+	var firstErr error
+	for pendingShards > 0 && availableShards < ba.dataShards {
+		result := <-results
+		pendingShards--
+		if result.err == nil {
+			shards[result.shard] = result.data
+			availableShards++
+			continue
+		}
+		if status.Code(result.err) == codes.NotFound {
+			missingShards++
+		} else if firstErr == nil {
+			firstErr = util.StatusWrapf(result.err, "Fragment %d on backend %d", result.shard, indices[result.shard])
+		}
+		if nextShard < totalShards {
+			fetchFragment(nextShard)
+			nextShard++
+			pendingShards++
+		}
+	}
+	if availableShards < ba.dataShards {
+		if missingShards > ba.parityShards {
+			return buffer.NewBufferFromError(status.Errorf(codes.NotFound, "Only %d of %d fragments are present, while %d are needed to reconstruct the blob", totalShards-missingShards, totalShards, ba.dataShards))
+		}
+		return buffer.NewBufferFromError(firstErr)
+	}
+
+	// Reconstruct data fragments that could not be obtained, and
+	// join them to form the original blob. Reconstruction fills in
+	// absent fragments, so operate on a copy of the list.
+	data, err := ba.reconstructBlob(append([][]byte(nil), shards...), sizeBytes)
+	if err != nil {
+		return buffer.NewBufferFromError(err)
+	}
+	if ba.isValidBlob(digest, data) {
+		return buffer.NewValidatedBufferFromByteSlice(data)
+	}
+
+	// One or more of the fragments are corrupted. Fetch all
+	// remaining fragments, and attempt to reconstruct the blob
+	// from every combination of dataShards fragments. This permits
+	// recovery from up to parityShards corrupted fragments.
+	for ; nextShard < totalShards; nextShard++ {
+		fetchFragment(nextShard)
+		pendingShards++
+	}
+	for ; pendingShards > 0; pendingShards-- {
+		if result := <-results; result.err == nil {
+			shards[result.shard] = result.data
+		}
+	}
+	var availableShardIndices []int
+	for shard, shardData := range shards {
+		if shardData != nil {
+			availableShardIndices = append(availableShardIndices, shard)
+		}
+	}
+	var validData []byte
+	forEachCombination(len(availableShardIndices), ba.dataShards, func(combination []int) bool {
+		candidateShards := make([][]byte, totalShards)
+		for _, i := range combination {
+			shard := availableShardIndices[i]
+			candidateShards[shard] = shards[shard]
+		}
+		candidateData, err := ba.reconstructBlob(candidateShards, sizeBytes)
+		if err == nil && ba.isValidBlob(digest, candidateData) {
+			validData = candidateData
+			return false
+		}
+		return true
+	})
+	if validData != nil {
+		return buffer.NewValidatedBufferFromByteSlice(validData)
+	}
+
+	// None of the combinations yield the expected data. Let the
+	// buffer report the checksum mismatch.
+	return buffer.NewCASBufferFromByteSlice(digest, data, buffer.BackendProvided(buffer.Irreparable(digest)))
+}
+
+// reconstructBlob reconstructs data fragments that are absent, and
+// joins the data fragments to form the original blob.
+func (ba *erasureCodingBlobAccess) reconstructBlob(shards [][]byte, sizeBytes int64) ([]byte, error) {
+	if err := ba.encoder.ReconstructData(shards); err != nil {
+		return nil, util.StatusWrapWithCode(err, codes.Internal, "Failed to reconstruct blob")
+	}
+	var data bytes.Buffer
+	data.Grow(int(sizeBytes))
+	if err := ba.encoder.Join(&data, shards, int(sizeBytes)); err != nil {
+		return nil, util.StatusWrapWithCode(err, codes.Internal, "Failed to join fragments")
+	}
+	return data.Bytes(), nil
+}
+
+// isValidBlob returns whether the contents of a reconstructed blob
+// match its digest.
+func (ba *erasureCodingBlobAccess) isValidBlob(blobDigest digest.Digest, data []byte) bool {
+	generator := blobDigest.GetDigestFunction().NewGenerator()
+	generator.Write(data)
+	return generator.Sum() == blobDigest
+}
+
+// forEachCombination calls a callback for every combination of k
+// distinct integers in the range [0, n), in lexicographic order. The
+// callback may return false to stop iteration.
+func forEachCombination(n, k int, f func(combination []int) bool) {
+	if k > n {
+		return
+	}
+	combination := make([]int, k)
+	for i := range combination {
+		combination[i] = i
+	}
+	for f(combination) {
+		// Find the rightmost element that can be incremented.
+		i := k - 1
+		for i >= 0 && combination[i] == n-k+i {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		combination[i]++
+		for j := i + 1; j < k; j++ {
+			combination[j] = combination[j-1] + 1
+		}
+	}
+}
+
+func (ba *erasureCodingBlobAccess) Put(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+	if err := ba.checkBlobSize(digest); err != nil {
+		b.Discard()
+		return err
+	}
+	data, err := b.ToByteSlice(int(ba.maximumBlobSizeBytes))
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		// The empty blob cannot be split into fragments. There
+		// is no need to store it, as Get() is able to return it
+		// without consulting any backends.
+		return nil
+	}
+
+	// Split the blob into fragments and compute parity.
+	shards, err := ba.encoder.Split(data)
+	if err != nil {
+		return util.StatusWrapWithCode(err, codes.Internal, "Failed to split blob into fragments")
+	}
+	if err := ba.encoder.Encode(shards); err != nil {
+		return util.StatusWrapWithCode(err, codes.Internal, "Failed to compute parity fragments")
+	}
+
+	// Write all fragments to their respective backends. Failures
+	// of individual backends are tolerated, as long as enough
+	// fragments are stored to reconstruct the blob.
+	indices := ba.getBackendIndices(digest)
+	errs := make([]error, len(shards))
+	var wg sync.WaitGroup
+	wg.Add(len(shards))
+	for shardIter, dataIter := range shards {
+		shard, data := shardIter, dataIter
+		go func() {
+			errs[shard] = ba.backends[indices[shard]].Put(ctx, digest, buffer.NewValidatedBufferFromByteSlice(data))
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+
+	storedShards := 0
+	var firstErr error
+	for shard, err := range errs {
+		if err == nil {
+			storedShards++
+		} else if firstErr == nil {
+			firstErr = util.StatusWrapf(err, "Fragment %d on backend %d", shard, indices[shard])
+		}
+	}
+	if storedShards < ba.dataShards {
+		return util.StatusWrapf(firstErr, "Only %d of %d fragments could be stored, while %d are needed to reconstruct the blob", storedShards, len(shards), ba.dataShards)
+	}
+	return nil
+}
+
+func (ba *erasureCodingBlobAccess) FindMissing(ctx context.Context, digests digest.Set) (digest.Set, error) {
+	// Partition all digests by backend. Every digest is sent to
+	// all backends that store one of its fragments. The empty blob
+	// is not stored in any of the backends.
+	digestsPerBackend := make([]digest.SetBuilder, 0, len(ba.backends))
+	for range ba.backends {
+		digestsPerBackend = append(digestsPerBackend, digest.NewSetBuilder())
+	}
+	for _, blobDigest := range digests.RemoveEmptyBlob().Items() {
+		for _, index := range ba.getBackendIndices(blobDigest) {
+			digestsPerBackend[index].Add(blobDigest)
+		}
+	}
+
+	// Asynchronously call FindMissing() on backends. Backends that
+	// fail are assumed not to have any of the fragments.
+	missingPerBackend := make([]digest.Set, len(ba.backends))
+	errs := make([]error, len(ba.backends))
+	var wg sync.WaitGroup
+	queriedBackends := 0
+	for indexIter, digestsIter := range digestsPerBackend {
+		index, digests := indexIter, digestsIter.Build()
+		if !digests.Empty() {
+			queriedBackends++
+			wg.Add(1)
+			go func() {
+				missing, err := ba.backends[index].FindMissing(ctx, digests)
+				if err != nil {
+					missing = digests
+				}
+				missingPerBackend[index], errs[index] = missing, err
+				wg.Done()
+			}()
+		}
+	}
+	wg.Wait()
+
+	failedBackends := 0
+	var firstErr error
+	for index, err := range errs {
+		if err != nil {
+			failedBackends++
+			if firstErr == nil {
+				firstErr = util.StatusWrapf(err, "Backend %d", index)
+			}
+		}
+	}
+	if failedBackends > 0 && failedBackends == queriedBackends {
+		// Don't report all blobs as missing if none of the
+		// backends could be reached.
+		return digest.EmptySet, firstErr
+	}
+
+	// Blobs are missing if not enough fragments are present to
+	// reconstruct them.
+	missingFragments := map[digest.Digest]int{}
+	for _, missing := range missingPerBackend {
+		for _, blobDigest := range missing.Items() {
+			missingFragments[blobDigest]++
+		}
+	}
+	missingBlobs := digest.NewSetBuilder()
+	for blobDigest, count := range missingFragments {
+		if count > ba.parityShards {
+			missingBlobs.Add(blobDigest)
+		}
+	}
+	return missingBlobs.Build(), nil
+}
@@ -0,0 +1,323 @@
+package erasurecoding_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/buildbarn/bb-storage/internal/mock"
+	"github.com/buildbarn/bb-storage/pkg/blobstore"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/erasurecoding"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/sharding"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/testutil"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErasureCodingBlobAccess(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	backend0 := mock.NewMockBlobAccess(ctrl)
+	backend1 := mock.NewMockBlobAccess(ctrl)
+	backend2 := mock.NewMockBlobAccess(ctrl)
+	backend3 := mock.NewMockBlobAccess(ctrl)
+	shardPermuter := mock.NewMockShardPermuter(ctrl)
+	capabilitiesProvider := mock.NewMockProvider(ctrl)
+	blobAccess, err := erasurecoding.NewErasureCodingBlobAccess(
+		[]blobstore.BlobAccess{backend0, backend1, backend2, backend3},
+		shardPermuter,
+		0x62994904405896a1,
+		/* dataShards = */ 2,
+		/* parityShards = */ 1,
+		/* maximumBlobSizeBytes = */ 1000,
+		capabilitiesProvider)
+	require.NoError(t, err)
+
+	helloDigest := digest.MustNewDigest("example", "8b1a9953c4611296a827abf8c47804d7", 5)
+	emptyDigest := digest.MustNewDigest("example", "d41d8cd98f00b204e9800998ecf8427e", 0)
+
+	// All tests below use backends 3, 0 and 1, in that order. The
+	// permuter returning a backend multiple times should not cause
+	// it to be used for multiple fragments.
+	expectGetShard := func() {
+		shardPermuter.EXPECT().GetShard(uint64(0xa0230a77da24e99d), gomock.Any()).Do(
+			func(hash uint64, selector sharding.ShardSelector) {
+				require.True(t, selector(3))
+				require.True(t, selector(3))
+				require.True(t, selector(0))
+				require.False(t, selector(1))
+			})
+	}
+
+	// Fragments of "Hello", as computed by the Reed-Solomon
+	// encoder. Data is padded to a multiple of the fragment size.
+	var fragmentsLock sync.Mutex
+	fragments := map[*mock.MockBlobAccess][]byte{}
+
+	t.Run("PutTooLarge", func(t *testing.T) {
+		largeDigest := digest.MustNewDigest("example", "8b1a9953c4611296a827abf8c47804d7", 1001)
+		testutil.RequireEqualStatus(
+			t,
+			status.Error(codes.InvalidArgument, "Blob is 1001 bytes in size, while this backend is limited to 1000 bytes"),
+			blobAccess.Put(ctx, largeDigest, buffer.NewValidatedBufferFromByteSlice([]byte("Hello"))))
+	})
+
+	expectPut := func(backend *mock.MockBlobAccess, err error) {
+		backend.EXPECT().Put(gomock.Any(), helloDigest, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+				b.Discard()
+				return err
+			})
+	}
+
+	t.Run("PutPartialFailure", func(t *testing.T) {
+		// Failing to store a single fragment should be
+		// tolerated, as the blob can still be reconstructed
+		// from the other fragments.
+		expectGetShard()
+		expectPut(backend3, nil)
+		expectPut(backend0, status.Error(codes.Unavailable, "Server offline"))
+		expectPut(backend1, nil)
+
+		require.NoError(t, blobAccess.Put(ctx, helloDigest, buffer.NewValidatedBufferFromByteSlice([]byte("Hello"))))
+	})
+
+	t.Run("PutFailure", func(t *testing.T) {
+		// Errors should be prefixed with the fragment and
+		// backend number.
+		expectGetShard()
+		expectPut(backend3, nil)
+		expectPut(backend0, status.Error(codes.Unavailable, "Server offline"))
+		expectPut(backend1, status.Error(codes.Internal, "Server on fire"))
+
+		testutil.RequireEqualStatus(
+			t,
+			status.Error(codes.Unavailable, "Only 1 of 3 fragments could be stored, while 2 are needed to reconstruct the blob: Fragment 1 on backend 0: Server offline"),
+			blobAccess.Put(ctx, helloDigest, buffer.NewValidatedBufferFromByteSlice([]byte("Hello"))))
+	})
+
+	t.Run("PutEmpty", func(t *testing.T) {
+		// The empty blob cannot be split into fragments. It
+		// should not be sent to any of the backends.
+		require.NoError(t, blobAccess.Put(ctx, emptyDigest, buffer.NewValidatedBufferFromByteSlice(nil)))
+	})
+
+	t.Run("PutSuccess", func(t *testing.T) {
+		expectGetShard()
+		for _, backend := range []*mock.MockBlobAccess{backend3, backend0, backend1} {
+			b := backend
+			b.EXPECT().Put(gomock.Any(), helloDigest, gomock.Any()).DoAndReturn(
+				func(ctx context.Context, digest digest.Digest, buf buffer.Buffer) error {
+					data, err := buf.ToByteSlice(1000)
+					require.NoError(t, err)
+					fragmentsLock.Lock()
+					fragments[b] = data
+					fragmentsLock.Unlock()
+					return nil
+				})
+		}
+
+		require.NoError(t, blobAccess.Put(ctx, helloDigest, buffer.NewValidatedBufferFromByteSlice([]byte("Hello"))))
+		require.Equal(t, []byte("Hel"), fragments[backend3])
+		require.Equal(t, []byte("lo\x00"), fragments[backend0])
+		require.Len(t, fragments[backend1], 3)
+	})
+
+	t.Run("GetEmpty", func(t *testing.T) {
+		data, err := blobAccess.Get(ctx, emptyDigest).ToByteSlice(1000)
+		require.NoError(t, err)
+		require.Empty(t, data)
+	})
+
+	t.Run("GetDataFragments", func(t *testing.T) {
+		// When all data fragments are present, there is no
+		// need to consult the parity fragment.
+		expectGetShard()
+		backend3.EXPECT().Get(gomock.Any(), helloDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice(fragments[backend3]))
+		backend0.EXPECT().Get(gomock.Any(), helloDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice(fragments[backend0]))
+
+		data, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+	})
+
+	t.Run("GetReconstructed", func(t *testing.T) {
+		// If a data fragment is unavailable, it should be
+		// reconstructed using the parity fragment.
+		expectGetShard()
+		backend3.EXPECT().Get(gomock.Any(), helloDigest).
+			Return(buffer.NewBufferFromError(status.Error(codes.Unavailable, "Server offline")))
+		backend0.EXPECT().Get(gomock.Any(), helloDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice(fragments[backend0]))
+		backend1.EXPECT().Get(gomock.Any(), helloDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice(fragments[backend1]))
+
+		data, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+	})
+
+	t.Run("GetCorruptedFragment", func(t *testing.T) {
+		// Corruption of fragments should be detected after the
+		// blob has been reassembled. The parity fragment should
+		// then be used to reconstruct the blob without the
+		// corrupted fragment.
+		expectGetShard()
+		backend3.EXPECT().Get(gomock.Any(), helloDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("Jel")))
+		backend0.EXPECT().Get(gomock.Any(), helloDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice(fragments[backend0]))
+		backend1.EXPECT().Get(gomock.Any(), helloDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice(fragments[backend1]))
+
+		data, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+	})
+
+	t.Run("GetCorruptedUnrecoverable", func(t *testing.T) {
+		// If more fragments are corrupted than there are parity
+		// fragments, the blob cannot be reconstructed.
+		expectGetShard()
+		backend3.EXPECT().Get(gomock.Any(), helloDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("Jel")))
+		backend0.EXPECT().Get(gomock.Any(), helloDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("la\x00")))
+		backend1.EXPECT().Get(gomock.Any(), helloDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice(fragments[backend1]))
+
+		_, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		testutil.RequireEqualStatus(t, status.Error(codes.Internal, "Buffer has checksum 93a5a2b0659fafc19b2f86b8b020cc2b, while 8b1a9953c4611296a827abf8c47804d7 was expected"), err)
+	})
+
+	t.Run("GetWrongFragmentSize", func(t *testing.T) {
+		expectGetShard()
+		backend3.EXPECT().Get(gomock.Any(), helloDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("He")))
+		backend0.EXPECT().Get(gomock.Any(), helloDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice(fragments[backend0]))
+		backend1.EXPECT().Get(gomock.Any(), helloDigest).
+			Return(buffer.NewBufferFromError(status.Error(codes.NotFound, "Object not found")))
+
+		_, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		testutil.RequireEqualStatus(t, status.Error(codes.Internal, "Fragment 0 on backend 3: Fragment is 2 bytes in size, while 3 bytes were expected"), err)
+	})
+
+	t.Run("GetNotFound", func(t *testing.T) {
+		// If more fragments are absent than there are parity
+		// fragments, the blob is gone.
+		expectGetShard()
+		backend3.EXPECT().Get(gomock.Any(), helloDigest).
+			Return(buffer.NewBufferFromError(status.Error(codes.NotFound, "Object not found")))
+		backend0.EXPECT().Get(gomock.Any(), helloDigest).
+			Return(buffer.NewBufferFromError(status.Error(codes.Unavailable, "Server offline")))
+		backend1.EXPECT().Get(gomock.Any(), helloDigest).
+			Return(buffer.NewBufferFromError(status.Error(codes.NotFound, "Object not found")))
+
+		_, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		testutil.RequireEqualStatus(t, status.Error(codes.NotFound, "Only 1 of 3 fragments are present, while 2 are needed to reconstruct the blob"), err)
+	})
+
+	t.Run("FindMissing", func(t *testing.T) {
+		// The blob should only be reported as missing if more
+		// fragments are missing than there are parity fragments.
+		expectGetShard()
+		backend3.EXPECT().FindMissing(gomock.Any(), helloDigest.ToSingletonSet()).
+			Return(helloDigest.ToSingletonSet(), nil)
+		backend0.EXPECT().FindMissing(gomock.Any(), helloDigest.ToSingletonSet()).
+			Return(digest.EmptySet, nil)
+		backend1.EXPECT().FindMissing(gomock.Any(), helloDigest.ToSingletonSet()).
+			Return(digest.EmptySet, nil)
+
+		missing, err := blobAccess.FindMissing(ctx, helloDigest.ToSingletonSet())
+		require.NoError(t, err)
+		require.Equal(t, digest.EmptySet, missing)
+
+		expectGetShard()
+		backend3.EXPECT().FindMissing(gomock.Any(), helloDigest.ToSingletonSet()).
+			Return(helloDigest.ToSingletonSet(), nil)
+		backend0.EXPECT().FindMissing(gomock.Any(), helloDigest.ToSingletonSet()).
+			Return(digest.EmptySet, nil)
+		backend1.EXPECT().FindMissing(gomock.Any(), helloDigest.ToSingletonSet()).
+			Return(helloDigest.ToSingletonSet(), nil)
+
+		missing, err = blobAccess.FindMissing(ctx, helloDigest.ToSingletonSet())
+		require.NoError(t, err)
+		require.Equal(t, helloDigest.ToSingletonSet(), missing)
+	})
+
+	t.Run("FindMissingEmpty", func(t *testing.T) {
+		// The empty blob is never stored in the backends, so
+		// it should never be reported as missing.
+		missing, err := blobAccess.FindMissing(ctx, emptyDigest.ToSingletonSet())
+		require.NoError(t, err)
+		require.Equal(t, digest.EmptySet, missing)
+	})
+
+	t.Run("FindMissingPartialFailure", func(t *testing.T) {
+		// Backends that fail should be treated as if they
+		// don't have any fragments. One missing fragment can
+		// be tolerated.
+		expectGetShard()
+		backend3.EXPECT().FindMissing(gomock.Any(), helloDigest.ToSingletonSet()).
+			Return(digest.EmptySet, nil)
+		backend0.EXPECT().FindMissing(gomock.Any(), helloDigest.ToSingletonSet()).
+			Return(digest.EmptySet, status.Error(codes.Unavailable, "Server offline"))
+		backend1.EXPECT().FindMissing(gomock.Any(), helloDigest.ToSingletonSet()).
+			Return(digest.EmptySet, nil)
+
+		missing, err := blobAccess.FindMissing(ctx, helloDigest.ToSingletonSet())
+		require.NoError(t, err)
+		require.Equal(t, digest.EmptySet, missing)
+
+		// Two missing fragments cannot be tolerated.
+		expectGetShard()
+		backend3.EXPECT().FindMissing(gomock.Any(), helloDigest.ToSingletonSet()).
+			Return(helloDigest.ToSingletonSet(), nil)
+		backend0.EXPECT().FindMissing(gomock.Any(), helloDigest.ToSingletonSet()).
+			Return(digest.EmptySet, status.Error(codes.Unavailable, "Server offline"))
+		backend1.EXPECT().FindMissing(gomock.Any(), helloDigest.ToSingletonSet()).
+			Return(digest.EmptySet, nil)
+
+		missing, err = blobAccess.FindMissing(ctx, helloDigest.ToSingletonSet())
+		require.NoError(t, err)
+		require.Equal(t, helloDigest.ToSingletonSet(), missing)
+	})
+
+	t.Run("FindMissingFailure", func(t *testing.T) {
+		// If none of the backends can be reached, an error
+		// should be returned, as opposed to reporting all
+		// blobs as missing.
+		expectGetShard()
+		backend3.EXPECT().FindMissing(gomock.Any(), helloDigest.ToSingletonSet()).
+			Return(digest.EmptySet, status.Error(codes.Unavailable, "Server offline"))
+		backend0.EXPECT().FindMissing(gomock.Any(), helloDigest.ToSingletonSet()).
+			Return(digest.EmptySet, status.Error(codes.Unavailable, "Server offline"))
+		backend1.EXPECT().FindMissing(gomock.Any(), helloDigest.ToSingletonSet()).
+			Return(digest.EmptySet, status.Error(codes.Unavailable, "Server offline"))
+
+		_, err := blobAccess.FindMissing(ctx, helloDigest.ToSingletonSet())
+		testutil.RequireEqualStatus(t, status.Error(codes.Unavailable, "Backend 0: Server offline"), err)
+	})
+}
+
+func TestErasureCodingBlobAccessInvalidMaximumBlobSize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	_, err := erasurecoding.NewErasureCodingBlobAccess(
+		[]blobstore.BlobAccess{mock.NewMockBlobAccess(ctrl), mock.NewMockBlobAccess(ctrl)},
+		mock.NewMockShardPermuter(ctrl),
+		0x62994904405896a1,
+		/* dataShards = */ 1,
+		/* parityShards = */ 1,
+		/* maximumBlobSizeBytes = */ 0,
+		mock.NewMockProvider(ctrl))
+	testutil.RequireEqualStatus(t, status.Error(codes.InvalidArgument, "The maximum blob size must be positive"), err)
+}
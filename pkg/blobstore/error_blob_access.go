@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 
+	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
 	"github.com/buildbarn/bb-storage/pkg/digest"
 )
@@ -36,3 +37,7 @@ func (ba *errorBlobAccess) Put(ctx context.Context, digest digest.Digest, b buff
 func (ba *errorBlobAccess) FindMissing(ctx context.Context, digests digest.Set) (digest.Set, error) {
 	return digest.EmptySet, ba.err
 }
+
+func (ba *errorBlobAccess) GetCapabilities(ctx context.Context, instanceName digest.InstanceName) (*remoteexecution.ServerCapabilities, error) {
+	return nil, ba.err
+}
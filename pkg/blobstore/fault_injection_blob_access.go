@@ -0,0 +1,218 @@
+package blobstore
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/buildbarn/bb-storage/pkg/clock"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/random"
+	"github.com/buildbarn/bb-storage/pkg/util"
+
+	"google.golang.org/grpc/status"
+)
+
+// Faults describes which faults FaultInjectionBlobAccess should inject
+// into a single type of operation. Every fault is injected
+// independently, with the probability provided.
+type Faults struct {
+	// Delay the operation by a fixed amount of time.
+	LatencyProbability float64
+	Latency            time.Duration
+
+	// Let the operation fail with a fixed error.
+	ErrorProbability float64
+	Error            *status.Status
+
+	// Cut off data returned by Get() at a random offset.
+	TruncationProbability float64
+
+	// Modify a single byte of data returned by Get() at a random
+	// offset.
+	CorruptionProbability float64
+}
+
+// FaultInjectionFilter limits the set of objects for which
+// FaultInjectionBlobAccess injects faults.
+type FaultInjectionFilter struct {
+	// If non-empty, only inject faults for objects whose instance
+	// name matches one of the provided prefixes.
+	InstanceNamePrefixes []digest.InstanceName
+
+	// If non-zero, only inject faults for objects whose digest has a
+	// size within the provided bounds.
+	MinimumSizeBytes int64
+	MaximumSizeBytes int64
+}
+
+type faultInjectionBlobAccess struct {
+	BlobAccess
+	readBufferFactory     ReadBufferFactory
+	clock                 clock.Clock
+	randomNumberGenerator random.ThreadSafeGenerator
+	instanceNamePrefixes  *digest.InstanceNameTrie
+	minimumSizeBytes      int64
+	maximumSizeBytes      int64
+	getFaults             Faults
+	putFaults             Faults
+	findMissingFaults     Faults
+}
+
+// NewFaultInjectionBlobAccess creates a decorator for BlobAccess that
+// randomly injects latency, errors, truncated reads and data
+// corruption. It can be used to test how clients and other BlobAccess
+// implementations (e.g., MirroredBlobAccess) behave in the presence of
+// failures.
+//
+// Truncated and corrupted data is handed out through a newly created
+// buffer, meaning that it is subject to the regular data integrity
+// checking. Any failures are not reported to the backend, as the data
+// stored in the backend is still valid.
+func NewFaultInjectionBlobAccess(base BlobAccess, readBufferFactory ReadBufferFactory, clock clock.Clock, randomNumberGenerator random.ThreadSafeGenerator, filter FaultInjectionFilter, getFaults, putFaults, findMissingFaults Faults) BlobAccess {
+	var instanceNamePrefixes *digest.InstanceNameTrie
+	if len(filter.InstanceNamePrefixes) > 0 {
+		instanceNamePrefixes = digest.NewInstanceNameTrie()
+		for i, instanceNamePrefix := range filter.InstanceNamePrefixes {
+			instanceNamePrefixes.Set(instanceNamePrefix, i)
+		}
+	}
+	return &faultInjectionBlobAccess{
+		BlobAccess:            base,
+		readBufferFactory:     readBufferFactory,
+		clock:                 clock,
+		randomNumberGenerator: randomNumberGenerator,
+		instanceNamePrefixes:  instanceNamePrefixes,
+		minimumSizeBytes:      filter.MinimumSizeBytes,
+		maximumSizeBytes:      filter.MaximumSizeBytes,
+		getFaults:             getFaults,
+		putFaults:             putFaults,
+		findMissingFaults:     findMissingFaults,
+	}
+}
+
+// matches returns whether faults may be injected for a given digest.
+func (ba *faultInjectionBlobAccess) matches(blobDigest digest.Digest) bool {
+	if ba.instanceNamePrefixes != nil && !ba.instanceNamePrefixes.ContainsPrefix(blobDigest.GetInstanceName()) {
+		return false
+	}
+	sizeBytes := blobDigest.GetSizeBytes()
+	return (ba.minimumSizeBytes == 0 || sizeBytes >= ba.minimumSizeBytes) &&
+		(ba.maximumSizeBytes == 0 || sizeBytes <= ba.maximumSizeBytes)
+}
+
+func (ba *faultInjectionBlobAccess) shouldInject(probability float64) bool {
+	return probability > 0 && ba.randomNumberGenerator.Float64() < probability
+}
+
+// injectLatencyAndError injects the faults that are shared by all
+// types of operations.
+func (ba *faultInjectionBlobAccess) injectLatencyAndError(ctx context.Context, faults *Faults) error {
+	if ba.shouldInject(faults.LatencyProbability) {
+		timer, timerChannel := ba.clock.NewTimer(faults.Latency)
+		select {
+		case <-timerChannel:
+		case <-ctx.Done():
+			timer.Stop()
+			return util.StatusFromContext(ctx)
+		}
+	}
+	if faults.Error != nil && ba.shouldInject(faults.ErrorProbability) {
+		return util.StatusWrap(faults.Error.Err(), "Injected fault")
+	}
+	return nil
+}
+
+func (ba *faultInjectionBlobAccess) Get(ctx context.Context, blobDigest digest.Digest) buffer.Buffer {
+	if !ba.matches(blobDigest) {
+		return ba.BlobAccess.Get(ctx, blobDigest)
+	}
+	if err := ba.injectLatencyAndError(ctx, &ba.getFaults); err != nil {
+		return buffer.NewBufferFromError(err)
+	}
+	b := ba.BlobAccess.Get(ctx, blobDigest)
+
+	truncate := ba.shouldInject(ba.getFaults.TruncationProbability)
+	corrupt := ba.shouldInject(ba.getFaults.CorruptionProbability)
+	if !truncate && !corrupt {
+		return b
+	}
+	sizeBytes, err := b.GetSizeBytes()
+	if err != nil || sizeBytes == 0 {
+		return b
+	}
+	r := b.ToReader()
+	if corrupt {
+		r = &corruptingReader{
+			ReadCloser: r,
+			offset:     ba.randomNumberGenerator.Int63n(sizeBytes),
+			mask:       byte(1 + ba.randomNumberGenerator.Intn(255)),
+		}
+	}
+	if truncate {
+		r = &truncatingReader{
+			ReadCloser: r,
+			remaining:  ba.randomNumberGenerator.Int63n(sizeBytes),
+		}
+	}
+	return ba.readBufferFactory.NewBufferFromReader(blobDigest, r, func(dataIsValid bool) {})
+}
+
+func (ba *faultInjectionBlobAccess) Put(ctx context.Context, blobDigest digest.Digest, b buffer.Buffer) error {
+	if ba.matches(blobDigest) {
+		if err := ba.injectLatencyAndError(ctx, &ba.putFaults); err != nil {
+			b.Discard()
+			return err
+		}
+	}
+	return ba.BlobAccess.Put(ctx, blobDigest, b)
+}
+
+func (ba *faultInjectionBlobAccess) FindMissing(ctx context.Context, digests digest.Set) (digest.Set, error) {
+	for _, blobDigest := range digests.Items() {
+		if ba.matches(blobDigest) {
+			if err := ba.injectLatencyAndError(ctx, &ba.findMissingFaults); err != nil {
+				return digest.EmptySet, err
+			}
+			break
+		}
+	}
+	return ba.BlobAccess.FindMissing(ctx, digests)
+}
+
+// truncatingReader is a decorator for io.ReadCloser that returns
+// end-of-file after a fixed number of bytes have been read.
+type truncatingReader struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (r *truncatingReader) Read(p []byte) (int, error) {
+	if r.remaining <= 0 {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > r.remaining {
+		p = p[:r.remaining]
+	}
+	n, err := r.ReadCloser.Read(p)
+	r.remaining -= int64(n)
+	return n, err
+}
+
+// corruptingReader is a decorator for io.ReadCloser that flips bits
+// in the byte stored at a fixed offset.
+type corruptingReader struct {
+	io.ReadCloser
+	offset int64
+	mask   byte
+}
+
+func (r *corruptingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if r.offset >= 0 && r.offset < int64(n) {
+		p[r.offset] ^= r.mask
+	}
+	r.offset -= int64(n)
+	return n, err
+}
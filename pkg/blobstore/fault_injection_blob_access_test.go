@@ -0,0 +1,151 @@
+package blobstore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/buildbarn/bb-storage/internal/mock"
+	"github.com/buildbarn/bb-storage/pkg/blobstore"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/testutil"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFaultInjectionBlobAccess(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	baseBlobAccess := mock.NewMockBlobAccess(ctrl)
+	clock := mock.NewMockClock(ctrl)
+	randomNumberGenerator := mock.NewMockThreadSafeGenerator(ctrl)
+	faults := blobstore.Faults{
+		LatencyProbability:    0.1,
+		Latency:               time.Second,
+		ErrorProbability:      0.2,
+		Error:                 status.New(codes.Unavailable, "Server offline"),
+		TruncationProbability: 0.3,
+		CorruptionProbability: 0.4,
+	}
+	blobAccess := blobstore.NewFaultInjectionBlobAccess(
+		baseBlobAccess,
+		blobstore.CASReadBufferFactory,
+		clock,
+		randomNumberGenerator,
+		blobstore.FaultInjectionFilter{
+			InstanceNamePrefixes: []digest.InstanceName{
+				digest.MustNewInstanceName("staging"),
+			},
+			MaximumSizeBytes: 100,
+		},
+		faults,
+		faults,
+		faults)
+
+	blobDigest := digest.MustNewDigest("staging/project", "8b1a9953c4611296a827abf8c47804d7", 5)
+
+	t.Run("FilteredByInstanceName", func(t *testing.T) {
+		// Requests for instance names that don't match any of
+		// the prefixes should not be affected.
+		otherDigest := digest.MustNewDigest("production", "8b1a9953c4611296a827abf8c47804d7", 5)
+		baseBlobAccess.EXPECT().Get(ctx, otherDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello")))
+
+		data, err := blobAccess.Get(ctx, otherDigest).ToByteSlice(1000)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+	})
+
+	t.Run("FilteredBySize", func(t *testing.T) {
+		largeDigest := digest.MustNewDigest("staging", "8b1a9953c4611296a827abf8c47804d7", 1000)
+		baseBlobAccess.EXPECT().FindMissing(ctx, largeDigest.ToSingletonSet()).
+			Return(digest.EmptySet, nil)
+
+		missing, err := blobAccess.FindMissing(ctx, largeDigest.ToSingletonSet())
+		require.NoError(t, err)
+		require.Equal(t, digest.EmptySet, missing)
+	})
+
+	t.Run("NoFaultsInjected", func(t *testing.T) {
+		randomNumberGenerator.EXPECT().Float64().Return(0.9).Times(4)
+		baseBlobAccess.EXPECT().Get(ctx, blobDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello")))
+
+		data, err := blobAccess.Get(ctx, blobDigest).ToByteSlice(1000)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+	})
+
+	t.Run("Latency", func(t *testing.T) {
+		randomNumberGenerator.EXPECT().Float64().Return(0.05)
+		timer := mock.NewMockTimer(ctrl)
+		timerChannel := make(chan time.Time, 1)
+		timerChannel <- time.Unix(1001, 0)
+		clock.EXPECT().NewTimer(time.Second).Return(timer, timerChannel)
+		randomNumberGenerator.EXPECT().Float64().Return(0.9)
+		baseBlobAccess.EXPECT().FindMissing(ctx, blobDigest.ToSingletonSet()).
+			Return(blobDigest.ToSingletonSet(), nil)
+
+		missing, err := blobAccess.FindMissing(ctx, blobDigest.ToSingletonSet())
+		require.NoError(t, err)
+		require.Equal(t, blobDigest.ToSingletonSet(), missing)
+	})
+
+	t.Run("LatencyCancelled", func(t *testing.T) {
+		// Cancelling the context while latency is being
+		// injected should cause the operation to fail
+		// immediately.
+		ctxWithCancel, cancel := context.WithCancel(ctx)
+		cancel()
+		randomNumberGenerator.EXPECT().Float64().Return(0.05)
+		timer := mock.NewMockTimer(ctrl)
+		clock.EXPECT().NewTimer(time.Second).Return(timer, nil)
+		timer.EXPECT().Stop().Return(true)
+
+		testutil.RequireEqualStatus(
+			t,
+			status.Error(codes.Canceled, "context canceled"),
+			blobAccess.Put(ctxWithCancel, blobDigest, buffer.NewValidatedBufferFromByteSlice([]byte("Hello"))))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		randomNumberGenerator.EXPECT().Float64().Return(0.9)
+		randomNumberGenerator.EXPECT().Float64().Return(0.15)
+
+		testutil.RequireEqualStatus(
+			t,
+			status.Error(codes.Unavailable, "Injected fault: Server offline"),
+			blobAccess.Put(ctx, blobDigest, buffer.NewValidatedBufferFromByteSlice([]byte("Hello"))))
+	})
+
+	t.Run("Truncation", func(t *testing.T) {
+		// Truncated data should be caught by the data integrity
+		// checking performed by the buffer layer.
+		randomNumberGenerator.EXPECT().Float64().Return(0.9).Times(2)
+		baseBlobAccess.EXPECT().Get(ctx, blobDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello")))
+		randomNumberGenerator.EXPECT().Float64().Return(0.25)
+		randomNumberGenerator.EXPECT().Float64().Return(0.9)
+		randomNumberGenerator.EXPECT().Int63n(int64(5)).Return(int64(3))
+
+		_, err := blobAccess.Get(ctx, blobDigest).ToByteSlice(1000)
+		testutil.RequireEqualStatus(t, status.Error(codes.Internal, "Buffer is 3 bytes in size, while 5 bytes were expected"), err)
+	})
+
+	t.Run("Corruption", func(t *testing.T) {
+		randomNumberGenerator.EXPECT().Float64().Return(0.9).Times(2)
+		baseBlobAccess.EXPECT().Get(ctx, blobDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello")))
+		randomNumberGenerator.EXPECT().Float64().Return(0.9)
+		randomNumberGenerator.EXPECT().Float64().Return(0.35)
+		randomNumberGenerator.EXPECT().Int63n(int64(5)).Return(int64(1))
+		randomNumberGenerator.EXPECT().Intn(255).Return(3) // "Hello" -> "Hallo".
+
+		_, err := blobAccess.Get(ctx, blobDigest).ToByteSlice(1000)
+		testutil.RequireEqualStatus(t, status.Error(codes.Internal, "Buffer has checksum d1bf93299de1b68e6d382c893bf1215f, while 8b1a9953c4611296a827abf8c47804d7 was expected"), err)
+	})
+}
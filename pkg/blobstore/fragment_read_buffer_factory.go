@@ -0,0 +1,36 @@
+package blobstore
+
+import (
+	"io"
+	"io/ioutil"
+
+	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/util"
+)
+
+type fragmentReadBufferFactory struct{}
+
+func (f fragmentReadBufferFactory) NewBufferFromByteSlice(digest digest.Digest, data []byte, dataIntegrityCallback buffer.DataIntegrityCallback) buffer.Buffer {
+	return buffer.NewValidatedBufferFromByteSlice(data)
+}
+
+func (f fragmentReadBufferFactory) NewBufferFromReader(digest digest.Digest, r io.ReadCloser, dataIntegrityCallback buffer.DataIntegrityCallback) buffer.Buffer {
+	data, err := ioutil.ReadAll(r)
+	r.Close()
+	if err != nil {
+		return buffer.NewBufferFromError(util.StatusWrap(err, "Failed to read fragment"))
+	}
+	return buffer.NewValidatedBufferFromByteSlice(data)
+}
+
+func (f fragmentReadBufferFactory) NewBufferFromReaderAt(digest digest.Digest, r buffer.ReadAtCloser, sizeBytes int64, dataIntegrityCallback buffer.DataIntegrityCallback) buffer.Buffer {
+	return buffer.NewValidatedBufferFromReaderAt(r, sizeBytes)
+}
+
+// FragmentReadBufferFactory is capable of creating buffers for
+// fragments of objects stored by ErasureCodingBlobAccess. Fragments
+// are stored under the digest of the object they belong to, meaning
+// their contents cannot be validated against it. Data integrity is
+// only validated after the object has been reassembled.
+var FragmentReadBufferFactory ReadBufferFactory = fragmentReadBufferFactory{}
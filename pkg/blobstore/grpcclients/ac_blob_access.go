@@ -15,6 +15,7 @@ import (
 
 type acBlobAccess struct {
 	actionCacheClient       remoteexecution.ActionCacheClient
+	capabilitiesClient      remoteexecution.CapabilitiesClient
 	maximumMessageSizeBytes int
 }
 
@@ -25,14 +26,16 @@ type acBlobAccess struct {
 func NewACBlobAccess(client grpc.ClientConnInterface, maximumMessageSizeBytes int) blobstore.BlobAccess {
 	return &acBlobAccess{
 		actionCacheClient:       remoteexecution.NewActionCacheClient(client),
+		capabilitiesClient:      remoteexecution.NewCapabilitiesClient(client),
 		maximumMessageSizeBytes: maximumMessageSizeBytes,
 	}
 }
 
 func (ba *acBlobAccess) Get(ctx context.Context, digest digest.Digest) buffer.Buffer {
 	actionResult, err := ba.actionCacheClient.GetActionResult(ctx, &remoteexecution.GetActionResultRequest{
-		InstanceName: digest.GetInstanceName().String(),
-		ActionDigest: digest.GetProto(),
+		InstanceName:   digest.GetInstanceName().String(),
+		ActionDigest:   digest.GetProto(),
+		DigestFunction: digest.GetDigestFunction().GetEnumValue(),
 	})
 	if err != nil {
 		return buffer.NewBufferFromError(err)
@@ -46,9 +49,10 @@ func (ba *acBlobAccess) Put(ctx context.Context, digest digest.Digest, b buffer.
 		return err
 	}
 	_, err = ba.actionCacheClient.UpdateActionResult(ctx, &remoteexecution.UpdateActionResultRequest{
-		InstanceName: digest.GetInstanceName().String(),
-		ActionDigest: digest.GetProto(),
-		ActionResult: actionResult.(*remoteexecution.ActionResult),
+		InstanceName:   digest.GetInstanceName().String(),
+		ActionDigest:   digest.GetProto(),
+		ActionResult:   actionResult.(*remoteexecution.ActionResult),
+		DigestFunction: digest.GetDigestFunction().GetEnumValue(),
 	})
 	return err
 }
@@ -56,3 +60,25 @@ func (ba *acBlobAccess) Put(ctx context.Context, digest digest.Digest, b buffer.
 func (ba *acBlobAccess) FindMissing(ctx context.Context, digests digest.Set) (digest.Set, error) {
 	return digest.EmptySet, status.Error(codes.Unimplemented, "Bazel action cache does not support bulk existence checking")
 }
+
+func (ba *acBlobAccess) GetCapabilities(ctx context.Context, instanceName digest.InstanceName) (*remoteexecution.ServerCapabilities, error) {
+	serverCapabilities, err := ba.capabilitiesClient.GetCapabilities(ctx, &remoteexecution.GetCapabilitiesRequest{
+		InstanceName: instanceName.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	cacheCapabilities := serverCapabilities.CacheCapabilities
+	if cacheCapabilities == nil {
+		return nil, status.Error(codes.InvalidArgument, "Instance name does not support remote caching")
+	}
+
+	// Only report the capabilities that pertain to the Action
+	// Cache. All other fields are provided by the Content
+	// Addressable Storage.
+	return &remoteexecution.ServerCapabilities{
+		CacheCapabilities: &remoteexecution.CacheCapabilities{
+			ActionCacheUpdateCapabilities: cacheCapabilities.ActionCacheUpdateCapabilities,
+		},
+	}, nil
+}
@@ -13,13 +13,17 @@ import (
 
 	"google.golang.org/genproto/googleapis/bytestream"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type casBlobAccess struct {
 	byteStreamClient                bytestream.ByteStreamClient
 	contentAddressableStorageClient remoteexecution.ContentAddressableStorageClient
+	capabilitiesClient              remoteexecution.CapabilitiesClient
 	uuidGenerator                   util.UUIDGenerator
 	readChunkSize                   int
+	compressor                      remoteexecution.Compressor_Value
 }
 
 // NewCASBlobAccess creates a BlobAccess handle that relays any requests
@@ -27,12 +31,19 @@ type casBlobAccess struct {
 // remoteexecution.ContentAddressableStorage services. Those are the
 // services that Bazel uses to access blobs stored in the Content
 // Addressable Storage.
-func NewCASBlobAccess(client grpc.ClientConnInterface, uuidGenerator util.UUIDGenerator, readChunkSize int) blobstore.BlobAccess {
+//
+// If a compressor other than IDENTITY is provided, blobs are
+// transferred through the ByteStream service in compressed form. Data
+// is decompressed on the fly, meaning that buffers returned by Get()
+// contain uncompressed data that is validated against the digest.
+func NewCASBlobAccess(client grpc.ClientConnInterface, uuidGenerator util.UUIDGenerator, readChunkSize int, compressor remoteexecution.Compressor_Value) blobstore.BlobAccess {
 	return &casBlobAccess{
 		byteStreamClient:                bytestream.NewByteStreamClient(client),
 		contentAddressableStorageClient: remoteexecution.NewContentAddressableStorageClient(client),
+		capabilitiesClient:              remoteexecution.NewCapabilitiesClient(client),
 		uuidGenerator:                   uuidGenerator,
 		readChunkSize:                   readChunkSize,
+		compressor:                      compressor,
 	}
 }
 
@@ -58,23 +69,45 @@ func (r *byteStreamChunkReader) Close() {
 	}
 }
 
-func (ba *casBlobAccess) Get(ctx context.Context, digest digest.Digest) buffer.Buffer {
+func (ba *casBlobAccess) openByteStreamChunkReader(ctx context.Context, digest digest.Digest, off, limit int64) (buffer.ChunkReader, error) {
 	ctxWithCancel, cancel := context.WithCancel(ctx)
 	client, err := ba.byteStreamClient.Read(ctxWithCancel, &bytestream.ReadRequest{
-		ResourceName: digest.GetByteStreamReadPath(remoteexecution.Compressor_IDENTITY),
+		ResourceName: digest.GetByteStreamReadPath(ba.compressor),
+		ReadOffset:   off,
+		ReadLimit:    limit,
 	})
 	if err != nil {
 		cancel()
-		return buffer.NewBufferFromError(err)
+		return nil, err
 	}
-	return buffer.NewCASBufferFromChunkReader(digest, &byteStreamChunkReader{
+	return &byteStreamChunkReader{
 		client: client,
 		cancel: cancel,
-	}, buffer.BackendProvided(buffer.Irreparable(digest)))
+	}, nil
+}
+
+func (ba *casBlobAccess) Get(ctx context.Context, digest digest.Digest) buffer.Buffer {
+	if ba.compressor == remoteexecution.Compressor_IDENTITY {
+		// Defer calling ByteStream.Read() until the buffer is
+		// accessed, so that partial reads can be forwarded to
+		// the server by setting the read offset and limit.
+		return buffer.NewCASBufferFromChunkReaderFactory(
+			digest,
+			func(off, limit int64) (buffer.ChunkReader, error) {
+				return ba.openByteStreamChunkReader(ctx, digest, off, limit)
+			},
+			buffer.BackendProvided(buffer.Irreparable(digest)))
+	}
+
+	r, err := ba.openByteStreamChunkReader(ctx, digest, 0, 0)
+	if err != nil {
+		return buffer.NewBufferFromError(err)
+	}
+	return buffer.NewCASBufferFromCompressedChunkReader(digest, r, ba.compressor, buffer.BackendProvided(buffer.Irreparable(digest)))
 }
 
 func (ba *casBlobAccess) Put(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
-	r := b.ToChunkReader(0, ba.readChunkSize)
+	r := buffer.NewCompressingChunkReader(b.ToChunkReader(0, 0, ba.readChunkSize), ba.compressor, ba.readChunkSize)
 	defer r.Close()
 
 	ctxWithCancel, cancel := context.WithCancel(ctx)
@@ -84,7 +117,9 @@ func (ba *casBlobAccess) Put(ctx context.Context, digest digest.Digest, b buffer
 		return err
 	}
 
-	resourceName := digest.GetByteStreamWritePath(uuid.Must(ba.uuidGenerator()), remoteexecution.Compressor_IDENTITY)
+	// For compressed uploads, the write offset of successive
+	// requests is based on the size of the compressed data.
+	resourceName := digest.GetByteStreamWritePath(uuid.Must(ba.uuidGenerator()), ba.compressor)
 	writeOffset := int64(0)
 	for {
 		if data, err := r.Read(); err == nil {
@@ -123,21 +158,22 @@ func (ba *casBlobAccess) Put(ctx context.Context, digest digest.Digest, b buffer
 }
 
 func (ba *casBlobAccess) FindMissing(ctx context.Context, digests digest.Set) (digest.Set, error) {
-	// Partition all digests by instance name, as the
-	// FindMissingBlobs() RPC can only process digests for a single
-	// instance.
-	perInstanceDigests := map[digest.InstanceName][]*remoteexecution.Digest{}
+	// Partition all digests by instance name and digest function,
+	// as the FindMissingBlobs() RPC can only process digests for a
+	// single instance that use the same digest function.
+	perFunctionDigests := map[digest.Function][]*remoteexecution.Digest{}
 	for _, digest := range digests.Items() {
-		instanceName := digest.GetInstanceName()
-		perInstanceDigests[instanceName] = append(perInstanceDigests[instanceName], digest.GetProto())
+		digestFunction := digest.GetDigestFunction()
+		perFunctionDigests[digestFunction] = append(perFunctionDigests[digestFunction], digest.GetProto())
 	}
 
 	missingDigests := digest.NewSetBuilder()
-	for instanceName, blobDigests := range perInstanceDigests {
+	for digestFunction, blobDigests := range perFunctionDigests {
 		// Call FindMissingBlobs() for each instance.
 		request := remoteexecution.FindMissingBlobsRequest{
-			InstanceName: instanceName.String(),
-			BlobDigests:  blobDigests,
+			InstanceName:   digestFunction.GetInstanceName().String(),
+			BlobDigests:    blobDigests,
+			DigestFunction: digestFunction.GetEnumValue(),
 		}
 		response, err := ba.contentAddressableStorageClient.FindMissingBlobs(ctx, &request)
 		if err != nil {
@@ -146,7 +182,7 @@ func (ba *casBlobAccess) FindMissing(ctx context.Context, digests digest.Set) (d
 
 		// Convert results back.
 		for _, proto := range response.MissingBlobDigests {
-			blobDigest, err := instanceName.NewDigestFromProto(proto)
+			blobDigest, err := digestFunction.NewDigestFromProto(proto)
 			if err != nil {
 				return digest.EmptySet, err
 			}
@@ -155,3 +191,38 @@ func (ba *casBlobAccess) FindMissing(ctx context.Context, digests digest.Set) (d
 	}
 	return missingDigests.Build(), nil
 }
+
+func (ba *casBlobAccess) GetCapabilities(ctx context.Context, instanceName digest.InstanceName) (*remoteexecution.ServerCapabilities, error) {
+	serverCapabilities, err := ba.capabilitiesClient.GetCapabilities(ctx, &remoteexecution.GetCapabilitiesRequest{
+		InstanceName: instanceName.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	cacheCapabilities := serverCapabilities.CacheCapabilities
+	if cacheCapabilities == nil {
+		return nil, status.Error(codes.InvalidArgument, "Instance name does not support remote caching")
+	}
+
+	// Only announce digest functions that are supported both by
+	// the remote server and by this process. Compression is
+	// performed locally, meaning that the compressors supported by
+	// the remote server are irrelevant.
+	var digestFunctions []remoteexecution.DigestFunction_Value
+	for _, digestFunction := range cacheCapabilities.DigestFunctions {
+		for _, supportedDigestFunction := range digest.SupportedDigestFunctions {
+			if digestFunction == supportedDigestFunction {
+				digestFunctions = append(digestFunctions, digestFunction)
+				break
+			}
+		}
+	}
+	return &remoteexecution.ServerCapabilities{
+		CacheCapabilities: &remoteexecution.CacheCapabilities{
+			DigestFunctions:             digestFunctions,
+			MaxBatchTotalSizeBytes:      cacheCapabilities.MaxBatchTotalSizeBytes,
+			SymlinkAbsolutePathStrategy: cacheCapabilities.SymlinkAbsolutePathStrategy,
+			SupportedCompressors:        buffer.SupportedCompressors,
+		},
+	}, nil
+}
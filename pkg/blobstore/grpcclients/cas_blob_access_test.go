@@ -5,6 +5,7 @@ import (
 	"io"
 	"testing"
 
+	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
 	"github.com/buildbarn/bb-storage/internal/mock"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/grpcclients"
@@ -12,6 +13,7 @@ import (
 	"github.com/buildbarn/bb-storage/pkg/testutil"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/require"
 
 	"google.golang.org/genproto/googleapis/bytestream"
@@ -20,12 +22,97 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+func TestCASBlobAccessGet(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	client := mock.NewMockClientConnInterface(ctrl)
+	uuidGenerator := mock.NewMockUUIDGenerator(ctrl)
+	blobAccess := grpcclients.NewCASBlobAccess(client, uuidGenerator.Call, 10, remoteexecution.Compressor_IDENTITY)
+
+	blobDigest := digest.MustNewDigest("hello", "3e25960a79dbc69b674cd4ec67a72c62", 11)
+
+	t.Run("Success", func(t *testing.T) {
+		clientStream := mock.NewMockClientStream(ctrl)
+		client.EXPECT().NewStream(gomock.Any(), gomock.Any(), "/google.bytestream.ByteStream/Read").
+			Return(clientStream, nil)
+		clientStream.EXPECT().SendMsg(testutil.EqProto(t, &bytestream.ReadRequest{
+			ResourceName: "hello/blobs/3e25960a79dbc69b674cd4ec67a72c62/11",
+		}))
+		clientStream.EXPECT().CloseSend()
+		clientStream.EXPECT().RecvMsg(gomock.Any()).DoAndReturn(func(m interface{}) error {
+			m.(*bytestream.ReadResponse).Data = []byte("Hello world")
+			return nil
+		})
+		clientStream.EXPECT().RecvMsg(gomock.Any()).Return(io.EOF).MinTimes(1)
+
+		data, err := blobAccess.Get(ctx, blobDigest).ToByteSlice(100)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello world"), data)
+	})
+
+	t.Run("PartialRead", func(t *testing.T) {
+		// Partial reads should be forwarded to the server by
+		// setting the read offset and limit, so that only the
+		// requested part of the blob is transferred.
+		clientStream := mock.NewMockClientStream(ctrl)
+		client.EXPECT().NewStream(gomock.Any(), gomock.Any(), "/google.bytestream.ByteStream/Read").
+			Return(clientStream, nil)
+		clientStream.EXPECT().SendMsg(testutil.EqProto(t, &bytestream.ReadRequest{
+			ResourceName: "hello/blobs/3e25960a79dbc69b674cd4ec67a72c62/11",
+			ReadOffset:   3,
+			ReadLimit:    4,
+		}))
+		clientStream.EXPECT().CloseSend()
+		clientStream.EXPECT().RecvMsg(gomock.Any()).DoAndReturn(func(m interface{}) error {
+			m.(*bytestream.ReadResponse).Data = []byte("lo w")
+			return nil
+		})
+		clientStream.EXPECT().RecvMsg(gomock.Any()).Return(io.EOF).AnyTimes()
+
+		r := blobAccess.Get(ctx, blobDigest).ToChunkReader(3, 4, 10)
+		chunk, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, []byte("lo w"), chunk)
+		_, err = r.Read()
+		require.Equal(t, io.EOF, err)
+		r.Close()
+	})
+
+	t.Run("ResumedRead", func(t *testing.T) {
+		// Reads that extend to the end of the blob, but start
+		// at a non-zero offset, should also only request the
+		// remaining part of the blob from the server.
+		clientStream := mock.NewMockClientStream(ctrl)
+		client.EXPECT().NewStream(gomock.Any(), gomock.Any(), "/google.bytestream.ByteStream/Read").
+			Return(clientStream, nil)
+		clientStream.EXPECT().SendMsg(testutil.EqProto(t, &bytestream.ReadRequest{
+			ResourceName: "hello/blobs/3e25960a79dbc69b674cd4ec67a72c62/11",
+			ReadOffset:   6,
+			ReadLimit:    5,
+		}))
+		clientStream.EXPECT().CloseSend()
+		clientStream.EXPECT().RecvMsg(gomock.Any()).DoAndReturn(func(m interface{}) error {
+			m.(*bytestream.ReadResponse).Data = []byte("world")
+			return nil
+		})
+		clientStream.EXPECT().RecvMsg(gomock.Any()).Return(io.EOF).AnyTimes()
+
+		r := blobAccess.Get(ctx, blobDigest).ToChunkReader(6, 0, 10)
+		chunk, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, []byte("world"), chunk)
+		_, err = r.Read()
+		require.Equal(t, io.EOF, err)
+		r.Close()
+	})
+}
+
 func TestCASBlobAccessPut(t *testing.T) {
 	ctrl, ctx := gomock.WithContext(context.Background(), t)
 
 	client := mock.NewMockClientConnInterface(ctrl)
 	uuidGenerator := mock.NewMockUUIDGenerator(ctrl)
-	blobAccess := grpcclients.NewCASBlobAccess(client, uuidGenerator.Call, 10)
+	blobAccess := grpcclients.NewCASBlobAccess(client, uuidGenerator.Call, 10, remoteexecution.Compressor_IDENTITY)
 
 	blobDigest := digest.MustNewDigest("hello", "8b1a9953c4611296a827abf8c47804d7", 5)
 	uuid := uuid.Must(uuid.Parse("7d659e5f-0e4b-48f0-ad9f-3489db6e103b"))
@@ -156,3 +243,140 @@ func TestCASBlobAccessPut(t *testing.T) {
 			blobAccess.Put(ctx, blobDigest, buffer.NewValidatedBufferFromReaderAt(r, 5)))
 	})
 }
+
+func TestCASBlobAccessFindMissing(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	client := mock.NewMockClientConnInterface(ctrl)
+	uuidGenerator := mock.NewMockUUIDGenerator(ctrl)
+	blobAccess := grpcclients.NewCASBlobAccess(client, uuidGenerator.Call, 10, remoteexecution.Compressor_IDENTITY)
+
+	t.Run("DigestFunction", func(t *testing.T) {
+		// BLAKE3 hashes cannot be distinguished from SHA-256
+		// hashes. The digest function must be sent along, and
+		// be used to parse the digests that are returned.
+		blobDigest, err := digest.MustNewFunction("hello", digest.DigestFunctionBLAKE3).NewDigest("6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85", 123)
+		require.NoError(t, err)
+		client.EXPECT().Invoke(
+			ctx,
+			"/build.bazel.remote.execution.v2.ContentAddressableStorage/FindMissingBlobs",
+			testutil.EqProto(t, &remoteexecution.FindMissingBlobsRequest{
+				InstanceName: "hello",
+				BlobDigests: []*remoteexecution.Digest{
+					{
+						Hash:      "6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85",
+						SizeBytes: 123,
+					},
+				},
+				DigestFunction: digest.DigestFunctionBLAKE3,
+			}),
+			gomock.Any(),
+			gomock.Any(),
+		).DoAndReturn(func(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+			reply.(*remoteexecution.FindMissingBlobsResponse).MissingBlobDigests = []*remoteexecution.Digest{
+				{
+					Hash:      "6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85",
+					SizeBytes: 123,
+				},
+			}
+			return nil
+		})
+
+		missing, err := blobAccess.FindMissing(ctx, blobDigest.ToSingletonSet())
+		require.NoError(t, err)
+		require.Equal(t, blobDigest.ToSingletonSet(), missing)
+	})
+}
+
+func TestCASBlobAccessCompression(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	client := mock.NewMockClientConnInterface(ctrl)
+	uuidGenerator := mock.NewMockUUIDGenerator(ctrl)
+	blobAccess := grpcclients.NewCASBlobAccess(client, uuidGenerator.Call, 10, remoteexecution.Compressor_ZSTD)
+
+	blobDigest := digest.MustNewDigest("hello", "8b1a9953c4611296a827abf8c47804d7", 5)
+	uuid := uuid.Must(uuid.Parse("7d659e5f-0e4b-48f0-ad9f-3489db6e103b"))
+
+	encoder, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	compressedHello := encoder.EncodeAll([]byte("Hello"), nil)
+	decoder, err := zstd.NewReader(nil)
+	require.NoError(t, err)
+	defer decoder.Close()
+
+	t.Run("GetSuccess", func(t *testing.T) {
+		// Data should be requested in compressed form, while
+		// the returned buffer contains the original data.
+		clientStream := mock.NewMockClientStream(ctrl)
+		client.EXPECT().NewStream(gomock.Any(), gomock.Any(), "/google.bytestream.ByteStream/Read").
+			Return(clientStream, nil)
+		clientStream.EXPECT().SendMsg(testutil.EqProto(t, &bytestream.ReadRequest{
+			ResourceName: "hello/compressed-blobs/zstd/8b1a9953c4611296a827abf8c47804d7/5",
+		}))
+		clientStream.EXPECT().CloseSend()
+		clientStream.EXPECT().RecvMsg(gomock.Any()).DoAndReturn(func(m interface{}) error {
+			m.(*bytestream.ReadResponse).Data = compressedHello
+			return nil
+		})
+		clientStream.EXPECT().RecvMsg(gomock.Any()).Return(io.EOF).MinTimes(1)
+
+		data, err := blobAccess.Get(ctx, blobDigest).ToByteSlice(100)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+	})
+
+	t.Run("GetCorrupted", func(t *testing.T) {
+		// Compressed data that is malformed should be
+		// treated as a data integrity error.
+		clientStream := mock.NewMockClientStream(ctrl)
+		client.EXPECT().NewStream(gomock.Any(), gomock.Any(), "/google.bytestream.ByteStream/Read").
+			Return(clientStream, nil)
+		clientStream.EXPECT().SendMsg(gomock.Any())
+		clientStream.EXPECT().CloseSend()
+		clientStream.EXPECT().RecvMsg(gomock.Any()).DoAndReturn(func(m interface{}) error {
+			m.(*bytestream.ReadResponse).Data = []byte("Not Zstandard compressed")
+			return nil
+		})
+		clientStream.EXPECT().RecvMsg(gomock.Any()).Return(io.EOF).AnyTimes()
+
+		_, err := blobAccess.Get(ctx, blobDigest).ToByteSlice(100)
+		require.Equal(t, codes.Internal, status.Code(err))
+	})
+
+	t.Run("PutSuccess", func(t *testing.T) {
+		// Data should be uploaded in compressed form. Write
+		// offsets are based on the size of the compressed data.
+		clientStream := mock.NewMockClientStream(ctrl)
+		client.EXPECT().NewStream(gomock.Any(), gomock.Any(), "/google.bytestream.ByteStream/Write").
+			Return(clientStream, nil)
+		uuidGenerator.EXPECT().Call().Return(uuid, nil)
+		var compressed []byte
+		finished := false
+		clientStream.EXPECT().SendMsg(gomock.Any()).DoAndReturn(func(m interface{}) error {
+			request := m.(*bytestream.WriteRequest)
+			require.False(t, finished)
+			if len(compressed) == 0 {
+				require.Equal(t, "hello/uploads/7d659e5f-0e4b-48f0-ad9f-3489db6e103b/compressed-blobs/zstd/8b1a9953c4611296a827abf8c47804d7/5", request.ResourceName)
+			} else {
+				require.Empty(t, request.ResourceName)
+			}
+			require.Equal(t, int64(len(compressed)), request.WriteOffset)
+			require.LessOrEqual(t, len(request.Data), 10)
+			compressed = append(compressed, request.Data...)
+			finished = request.FinishWrite
+			return nil
+		}).MinTimes(2)
+		clientStream.EXPECT().CloseSend()
+		clientStream.EXPECT().RecvMsg(gomock.Any()).DoAndReturn(func(m interface{}) error {
+			m.(*bytestream.WriteResponse).CommittedSize = 5
+			return nil
+		})
+
+		require.NoError(t, blobAccess.Put(ctx, blobDigest, buffer.NewValidatedBufferFromByteSlice([]byte("Hello"))))
+		require.True(t, finished)
+		decompressed, err := decoder.DecodeAll(compressed, nil)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), decompressed)
+	})
+}
@@ -30,8 +30,9 @@ func NewICASBlobAccess(client grpc.ClientConnInterface, maximumMessageSizeBytes
 
 func (ba *icasBlobAccess) Get(ctx context.Context, digest digest.Digest) buffer.Buffer {
 	reference, err := ba.icasClient.GetReference(ctx, &icas.GetReferenceRequest{
-		InstanceName: digest.GetInstanceName().String(),
-		Digest:       digest.GetProto(),
+		InstanceName:   digest.GetInstanceName().String(),
+		Digest:         digest.GetProto(),
+		DigestFunction: digest.GetDigestFunction().GetEnumValue(),
 	})
 	if err != nil {
 		return buffer.NewBufferFromError(err)
@@ -55,26 +56,28 @@ func (ba *icasBlobAccess) Put(ctx context.Context, digest digest.Digest, b buffe
 				Reference: reference.(*icas.Reference),
 			},
 		},
+		DigestFunction: digest.GetDigestFunction().GetEnumValue(),
 	})
 	return err
 }
 
 func (ba *icasBlobAccess) FindMissing(ctx context.Context, digests digest.Set) (digest.Set, error) {
-	// Partition all digests by instance name, as the
-	// FindMissingReferences() RPC can only process digests for a
-	// single instance.
-	perInstanceDigests := map[digest.InstanceName][]*remoteexecution.Digest{}
+	// Partition all digests by instance name and digest function,
+	// as the FindMissingReferences() RPC can only process digests for a
+	// single instance that use the same digest function.
+	perFunctionDigests := map[digest.Function][]*remoteexecution.Digest{}
 	for _, digest := range digests.Items() {
-		instanceName := digest.GetInstanceName()
-		perInstanceDigests[instanceName] = append(perInstanceDigests[instanceName], digest.GetProto())
+		digestFunction := digest.GetDigestFunction()
+		perFunctionDigests[digestFunction] = append(perFunctionDigests[digestFunction], digest.GetProto())
 	}
 
 	missingDigests := digest.NewSetBuilder()
-	for instanceName, blobDigests := range perInstanceDigests {
+	for digestFunction, blobDigests := range perFunctionDigests {
 		// Call FindMissingReferences() for each instance.
 		request := remoteexecution.FindMissingBlobsRequest{
-			InstanceName: instanceName.String(),
-			BlobDigests:  blobDigests,
+			InstanceName:   digestFunction.GetInstanceName().String(),
+			BlobDigests:    blobDigests,
+			DigestFunction: digestFunction.GetEnumValue(),
 		}
 		response, err := ba.icasClient.FindMissingReferences(ctx, &request)
 		if err != nil {
@@ -83,7 +86,7 @@ func (ba *icasBlobAccess) FindMissing(ctx context.Context, digests digest.Set) (
 
 		// Convert results back.
 		for _, proto := range response.MissingBlobDigests {
-			blobDigest, err := instanceName.NewDigestFromProto(proto)
+			blobDigest, err := digestFunction.NewDigestFromProto(proto)
 			if err != nil {
 				return digest.EmptySet, err
 			}
@@ -92,3 +95,9 @@ func (ba *icasBlobAccess) FindMissing(ctx context.Context, digests digest.Set) (
 	}
 	return missingDigests.Build(), nil
 }
+
+func (ba *icasBlobAccess) GetCapabilities(ctx context.Context, instanceName digest.InstanceName) (*remoteexecution.ServerCapabilities, error) {
+	// This storage type is not part of REv2, meaning there are no
+	// capabilities to report.
+	return &remoteexecution.ServerCapabilities{}, nil
+}
@@ -36,6 +36,7 @@ func (ba *isccBlobAccess) Get(ctx context.Context, digest digest.Digest) buffer.
 	previousExecutionStats, err := ba.initialSizeClassCacheClient.GetPreviousExecutionStats(ctx, &iscc.GetPreviousExecutionStatsRequest{
 		InstanceName:        digest.GetInstanceName().String(),
 		ReducedActionDigest: digest.GetProto(),
+		DigestFunction:      digest.GetDigestFunction().GetEnumValue(),
 	})
 	if err != nil {
 		return buffer.NewBufferFromError(err)
@@ -52,6 +53,7 @@ func (ba *isccBlobAccess) Put(ctx context.Context, digest digest.Digest, b buffe
 		InstanceName:           digest.GetInstanceName().String(),
 		ReducedActionDigest:    digest.GetProto(),
 		PreviousExecutionStats: previousExecutionStats.(*iscc.PreviousExecutionStats),
+		DigestFunction:         digest.GetDigestFunction().GetEnumValue(),
 	})
 	return err
 }
@@ -59,3 +61,9 @@ func (ba *isccBlobAccess) Put(ctx context.Context, digest digest.Digest, b buffe
 func (ba *isccBlobAccess) FindMissing(ctx context.Context, digests digest.Set) (digest.Set, error) {
 	return digest.EmptySet, status.Error(codes.Unimplemented, "Initial Size Class Cache does not support bulk existence checking")
 }
+
+func (ba *isccBlobAccess) GetCapabilities(ctx context.Context, instanceName digest.InstanceName) (*remoteexecution.ServerCapabilities, error) {
+	// This storage type is not part of REv2, meaning there are no
+	// capabilities to report.
+	return &remoteexecution.ServerCapabilities{}, nil
+}
@@ -8,19 +8,28 @@ import (
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
 	"github.com/buildbarn/bb-storage/pkg/digest"
 	"github.com/buildbarn/bb-storage/pkg/util"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
 )
 
 type actionCacheServer struct {
-	blobAccess              blobstore.BlobAccess
-	maximumMessageSizeBytes int
+	blobAccess                blobstore.BlobAccess
+	contentAddressableStorage blobstore.BlobAccess
+	maximumMessageSizeBytes   int
 }
 
 // NewActionCacheServer creates a GRPC service for serving the contents
 // of a Bazel Action Cache (AC) to Bazel.
-func NewActionCacheServer(blobAccess blobstore.BlobAccess, maximumMessageSizeBytes int) remoteexecution.ActionCacheServer {
+//
+// The Content Addressable Storage (CAS) is used to inline the contents
+// of standard output, standard error and output files into responses
+// of GetActionResult(), if requested by the client.
+func NewActionCacheServer(blobAccess, contentAddressableStorage blobstore.BlobAccess, maximumMessageSizeBytes int) remoteexecution.ActionCacheServer {
 	return &actionCacheServer{
-		blobAccess:              blobAccess,
-		maximumMessageSizeBytes: maximumMessageSizeBytes,
+		blobAccess:                blobAccess,
+		contentAddressableStorage: contentAddressableStorage,
+		maximumMessageSizeBytes:   maximumMessageSizeBytes,
 	}
 }
 
@@ -29,7 +38,7 @@ func (s *actionCacheServer) GetActionResult(ctx context.Context, in *remoteexecu
 	if err != nil {
 		return nil, util.StatusWrapf(err, "Invalid instance name %#v", in.InstanceName)
 	}
-	digest, err := instanceName.NewDigestFromProto(in.ActionDigest)
+	digest, err := instanceName.NewDigestFromProtoAndDigestFunction(in.DigestFunction, in.ActionDigest)
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +48,84 @@ func (s *actionCacheServer) GetActionResult(ctx context.Context, in *remoteexecu
 	if err != nil {
 		return nil, err
 	}
-	return actionResult.(*remoteexecution.ActionResult), nil
+	if !in.InlineStdout && !in.InlineStderr && len(in.InlineOutputFiles) == 0 {
+		return actionResult.(*remoteexecution.ActionResult), nil
+	}
+
+	// The message returned by the buffer may be shared with the
+	// storage backend. Make a copy before inlining contents.
+	inlinedActionResult := proto.Clone(actionResult).(*remoteexecution.ActionResult)
+	s.inlineContents(ctx, digest.GetDigestFunction(), in, inlinedActionResult)
+	return inlinedActionResult, nil
+}
+
+// inlineContents loads the contents of standard output, standard error
+// and output files from the Content Addressable Storage into an
+// ActionResult, as requested by the client. Contents are only inlined
+// as long as the resulting message does not exceed the maximum message
+// size.
+//
+// Inlining is merely an optimization. Objects that cannot be loaded
+// are not inlined, as clients will fall back to loading them from the
+// Content Addressable Storage themselves.
+func (s *actionCacheServer) inlineContents(ctx context.Context, digestFunction digest.Function, in *remoteexecution.GetActionResultRequest, actionResult *remoteexecution.ActionResult) {
+	remainingSizeBytes := s.maximumMessageSizeBytes - proto.Size(actionResult)
+	bytesFieldSizeBytes := func(sizeBytes int) int {
+		// stdout_raw, stderr_raw and contents all use field
+		// numbers that can be encoded as a single byte tag.
+		return protowire.SizeTag(5) + protowire.SizeBytes(sizeBytes)
+	}
+
+	if in.InlineStdout && len(actionResult.StdoutRaw) == 0 {
+		actionResult.StdoutRaw = s.getInlinedContents(ctx, digestFunction, actionResult.StdoutDigest, bytesFieldSizeBytes, &remainingSizeBytes)
+	}
+	if in.InlineStderr && len(actionResult.StderrRaw) == 0 {
+		actionResult.StderrRaw = s.getInlinedContents(ctx, digestFunction, actionResult.StderrDigest, bytesFieldSizeBytes, &remainingSizeBytes)
+	}
+
+	if len(in.InlineOutputFiles) > 0 {
+		inlineOutputFiles := make(map[string]struct{}, len(in.InlineOutputFiles))
+		for _, path := range in.InlineOutputFiles {
+			inlineOutputFiles[path] = struct{}{}
+		}
+		for _, outputFile := range actionResult.OutputFiles {
+			if _, ok := inlineOutputFiles[outputFile.Path]; !ok || len(outputFile.Contents) > 0 {
+				continue
+			}
+			// Adding contents to an OutputFile may also cause
+			// the length prefix of the OutputFile to grow.
+			oldSizeBytes := proto.Size(outputFile)
+			outputFile.Contents = s.getInlinedContents(ctx, digestFunction, outputFile.Digest, func(sizeBytes int) int {
+				return protowire.SizeBytes(oldSizeBytes+bytesFieldSizeBytes(sizeBytes)) - protowire.SizeBytes(oldSizeBytes)
+			}, &remainingSizeBytes)
+		}
+	}
+}
+
+// getInlinedContents loads the contents of a single object from the
+// Content Addressable Storage, if its size permits it to be inlined.
+func (s *actionCacheServer) getInlinedContents(ctx context.Context, digestFunction digest.Function, blobDigest *remoteexecution.Digest, getFieldSizeBytes func(sizeBytes int) int, remainingSizeBytes *int) []byte {
+	if blobDigest == nil {
+		return nil
+	}
+	digest, err := digestFunction.NewDigestFromProto(blobDigest)
+	if err != nil {
+		return nil
+	}
+	sizeBytes := digest.GetSizeBytes()
+	if sizeBytes == 0 || sizeBytes > int64(*remainingSizeBytes) {
+		return nil
+	}
+	fieldSizeBytes := getFieldSizeBytes(int(sizeBytes))
+	if fieldSizeBytes > *remainingSizeBytes {
+		return nil
+	}
+	data, err := s.contentAddressableStorage.Get(ctx, digest).ToByteSlice(int(sizeBytes))
+	if err != nil {
+		return nil
+	}
+	*remainingSizeBytes -= fieldSizeBytes
+	return data
 }
 
 func (s *actionCacheServer) UpdateActionResult(ctx context.Context, in *remoteexecution.UpdateActionResultRequest) (*remoteexecution.ActionResult, error) {
@@ -47,7 +133,7 @@ func (s *actionCacheServer) UpdateActionResult(ctx context.Context, in *remoteex
 	if err != nil {
 		return nil, util.StatusWrapf(err, "Invalid instance name %#v", in.InstanceName)
 	}
-	digest, err := instanceName.NewDigestFromProto(in.ActionDigest)
+	digest, err := instanceName.NewDigestFromProtoAndDigestFunction(in.DigestFunction, in.ActionDigest)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,160 @@
+package grpcservers_test
+
+import (
+	"context"
+	"testing"
+
+	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
+	"github.com/buildbarn/bb-storage/internal/mock"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/grpcservers"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/testutil"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestActionCacheServerGetActionResult(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	actionDigest := digest.MustNewDigest("example", "d41d8cd98f00b204e9800998ecf8427e", 123)
+	exampleActionResult := &remoteexecution.ActionResult{
+		OutputFiles: []*remoteexecution.OutputFile{
+			{
+				Path: "a",
+				Digest: &remoteexecution.Digest{
+					Hash:      "0cc175b9c0f1b6a831c399e269772661",
+					SizeBytes: 1,
+				},
+			},
+			{
+				Path: "b",
+				Digest: &remoteexecution.Digest{
+					Hash:      "92eb5ffee6ae2fec3ad71c777531578f",
+					SizeBytes: 1,
+				},
+			},
+		},
+		StdoutDigest: &remoteexecution.Digest{
+			Hash:      "8b1a9953c4611296a827abf8c47804d7",
+			SizeBytes: 5,
+		},
+		StderrDigest: &remoteexecution.Digest{
+			Hash:      "6fc422233a40a75a1f028e11c3cd1140",
+			SizeBytes: 7,
+		},
+	}
+
+	t.Run("NoInlining", func(t *testing.T) {
+		actionCache := mock.NewMockBlobAccess(ctrl)
+		contentAddressableStorage := mock.NewMockBlobAccess(ctrl)
+		s := grpcservers.NewActionCacheServer(actionCache, contentAddressableStorage, 10000)
+
+		actionCache.EXPECT().Get(ctx, actionDigest).
+			Return(buffer.NewProtoBufferFromProto(exampleActionResult, buffer.UserProvided))
+
+		actionResult, err := s.GetActionResult(ctx, &remoteexecution.GetActionResultRequest{
+			InstanceName: "example",
+			ActionDigest: &remoteexecution.Digest{
+				Hash:      "d41d8cd98f00b204e9800998ecf8427e",
+				SizeBytes: 123,
+			},
+		})
+		require.NoError(t, err)
+		testutil.RequireEqualProto(t, exampleActionResult, actionResult)
+	})
+
+	t.Run("InlineAll", func(t *testing.T) {
+		actionCache := mock.NewMockBlobAccess(ctrl)
+		contentAddressableStorage := mock.NewMockBlobAccess(ctrl)
+		s := grpcservers.NewActionCacheServer(actionCache, contentAddressableStorage, 10000)
+
+		actionCache.EXPECT().Get(ctx, actionDigest).
+			Return(buffer.NewProtoBufferFromProto(exampleActionResult, buffer.UserProvided))
+		contentAddressableStorage.EXPECT().Get(ctx, digest.MustNewDigest("example", "8b1a9953c4611296a827abf8c47804d7", 5)).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello")))
+		contentAddressableStorage.EXPECT().Get(ctx, digest.MustNewDigest("example", "6fc422233a40a75a1f028e11c3cd1140", 7)).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("Goodbye")))
+		contentAddressableStorage.EXPECT().Get(ctx, digest.MustNewDigest("example", "0cc175b9c0f1b6a831c399e269772661", 1)).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("a")))
+
+		// Only output files that are explicitly requested
+		// should be inlined.
+		actionResult, err := s.GetActionResult(ctx, &remoteexecution.GetActionResultRequest{
+			InstanceName: "example",
+			ActionDigest: &remoteexecution.Digest{
+				Hash:      "d41d8cd98f00b204e9800998ecf8427e",
+				SizeBytes: 123,
+			},
+			InlineStdout:      true,
+			InlineStderr:      true,
+			InlineOutputFiles: []string{"a", "c"},
+		})
+		require.NoError(t, err)
+		expectedActionResult := proto.Clone(exampleActionResult).(*remoteexecution.ActionResult)
+		expectedActionResult.OutputFiles[0].Contents = []byte("a")
+		expectedActionResult.StdoutRaw = []byte("Hello")
+		expectedActionResult.StderrRaw = []byte("Goodbye")
+		testutil.RequireEqualProto(t, expectedActionResult, actionResult)
+
+		// The original message should not have been modified.
+		require.Empty(t, exampleActionResult.StdoutRaw)
+	})
+
+	t.Run("SizeLimit", func(t *testing.T) {
+		// Only standard output fits within the maximum message
+		// size. Standard error should not be loaded.
+		actionCache := mock.NewMockBlobAccess(ctrl)
+		contentAddressableStorage := mock.NewMockBlobAccess(ctrl)
+		s := grpcservers.NewActionCacheServer(actionCache, contentAddressableStorage, proto.Size(exampleActionResult)+10)
+
+		actionCache.EXPECT().Get(ctx, actionDigest).
+			Return(buffer.NewProtoBufferFromProto(exampleActionResult, buffer.UserProvided))
+		contentAddressableStorage.EXPECT().Get(ctx, digest.MustNewDigest("example", "8b1a9953c4611296a827abf8c47804d7", 5)).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello")))
+
+		actionResult, err := s.GetActionResult(ctx, &remoteexecution.GetActionResultRequest{
+			InstanceName: "example",
+			ActionDigest: &remoteexecution.Digest{
+				Hash:      "d41d8cd98f00b204e9800998ecf8427e",
+				SizeBytes: 123,
+			},
+			InlineStdout: true,
+			InlineStderr: true,
+		})
+		require.NoError(t, err)
+		expectedActionResult := proto.Clone(exampleActionResult).(*remoteexecution.ActionResult)
+		expectedActionResult.StdoutRaw = []byte("Hello")
+		testutil.RequireEqualProto(t, expectedActionResult, actionResult)
+		require.LessOrEqual(t, proto.Size(actionResult), proto.Size(exampleActionResult)+10)
+	})
+
+	t.Run("StorageFailure", func(t *testing.T) {
+		// Failures to load contents from the CAS should not
+		// cause the request to fail. The client can still load
+		// the contents itself.
+		actionCache := mock.NewMockBlobAccess(ctrl)
+		contentAddressableStorage := mock.NewMockBlobAccess(ctrl)
+		s := grpcservers.NewActionCacheServer(actionCache, contentAddressableStorage, 10000)
+
+		actionCache.EXPECT().Get(ctx, actionDigest).
+			Return(buffer.NewProtoBufferFromProto(exampleActionResult, buffer.UserProvided))
+		contentAddressableStorage.EXPECT().Get(ctx, digest.MustNewDigest("example", "8b1a9953c4611296a827abf8c47804d7", 5)).
+			Return(buffer.NewBufferFromError(status.Error(codes.NotFound, "Object not found")))
+
+		actionResult, err := s.GetActionResult(ctx, &remoteexecution.GetActionResultRequest{
+			InstanceName: "example",
+			ActionDigest: &remoteexecution.Digest{
+				Hash:      "d41d8cd98f00b204e9800998ecf8427e",
+				SizeBytes: 123,
+			},
+			InlineStdout: true,
+		})
+		require.NoError(t, err)
+		testutil.RequireEqualProto(t, exampleActionResult, actionResult)
+	})
+}
@@ -3,11 +3,13 @@ package grpcservers
 import (
 	"context"
 	"io"
+	"strings"
 
 	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
 	"github.com/buildbarn/bb-storage/pkg/blobstore"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
 	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/util"
 
 	"google.golang.org/genproto/googleapis/bytestream"
 	"google.golang.org/grpc/codes"
@@ -15,33 +17,68 @@ import (
 )
 
 type byteStreamServer struct {
-	blobAccess    blobstore.BlobAccess
-	readChunkSize int
+	blobAccess                blobstore.BlobAccess
+	readChunkSize             int
+	partialUploadStore        PartialUploadStore
+	minimumPartialUploadSize  int64
+	checkExistenceBeforeWrite bool
 }
 
 // NewByteStreamServer creates a GRPC service for reading blobs from and
 // writing blobs to a BlobAccess. It is used by Bazel to access the
 // Content Addressable Storage (CAS).
-func NewByteStreamServer(blobAccess blobstore.BlobAccess, readChunkSize int) bytestream.ByteStreamServer {
+//
+// If a PartialUploadStore is provided, data of uploads that are
+// interrupted is staged, so that clients may resume them after
+// calling QueryWriteStatus(). Resumption is only supported for
+// uploads of uncompressed data that are at least
+// minimumPartialUploadSize bytes in size. Smaller uploads are cheap to
+// restart, meaning there is little value in staging them.
+//
+// If checkExistenceBeforeWrite is set, Write() calls FindMissing()
+// upon receipt of the first request. If the object is already present,
+// the write is completed immediately, without receiving the remainder
+// of the data from the client.
+func NewByteStreamServer(blobAccess blobstore.BlobAccess, readChunkSize int, partialUploadStore PartialUploadStore, minimumPartialUploadSize int64, checkExistenceBeforeWrite bool) bytestream.ByteStreamServer {
 	return &byteStreamServer{
-		blobAccess:    blobAccess,
-		readChunkSize: readChunkSize,
+		blobAccess:                blobAccess,
+		readChunkSize:             readChunkSize,
+		partialUploadStore:        partialUploadStore,
+		minimumPartialUploadSize:  minimumPartialUploadSize,
+		checkExistenceBeforeWrite: checkExistenceBeforeWrite,
 	}
 }
 
-func (s *byteStreamServer) Read(in *bytestream.ReadRequest, out bytestream.ByteStream_ReadServer) error {
-	if in.ReadLimit != 0 {
-		return status.Error(codes.Unimplemented, "This service does not support downloading partial files")
+// getUploadID extracts the UUID from a resource name that is provided
+// to Write() or QueryWriteStatus(). These resource names have the
+// form "${instance_name}/uploads/${uuid}/blobs/...". This function
+// assumes the resource name has already been validated.
+func getUploadID(resourceName string) string {
+	fields := strings.FieldsFunc(resourceName, func(r rune) bool { return r == '/' })
+	for i := 0; i < len(fields)-1; i++ {
+		if fields[i] == "uploads" {
+			return fields[i+1]
+		}
 	}
+	return ""
+}
+
+func (s *byteStreamServer) Read(in *bytestream.ReadRequest, out bytestream.ByteStream_ReadServer) error {
 	digest, compressor, err := digest.NewDigestFromByteStreamReadPath(in.ResourceName)
 	if err != nil {
 		return err
 	}
-	if compressor != remoteexecution.Compressor_IDENTITY {
-		return status.Error(codes.Unimplemented, "This service does not support downloading compressed files")
+	if in.ReadLimit != 0 && compressor != remoteexecution.Compressor_IDENTITY {
+		return status.Error(codes.InvalidArgument, "Read limits cannot be used in combination with compression")
 	}
 
-	r := s.blobAccess.Get(out.Context(), digest).ToChunkReader(in.ReadOffset, s.readChunkSize)
+	// For compressed resources, the read offset refers to the
+	// uncompressed form of the blob. Compression is thus applied
+	// after seeking.
+	r := buffer.NewCompressingChunkReader(
+		s.blobAccess.Get(out.Context(), digest).ToChunkReader(in.ReadOffset, in.ReadLimit, s.readChunkSize),
+		compressor,
+		s.readChunkSize)
 	defer r.Close()
 
 	for {
@@ -101,6 +138,49 @@ func (r *byteStreamWriteServerChunkReader) Read() ([]byte, error) {
 
 func (r *byteStreamWriteServerChunkReader) Close() {}
 
+// partialUploadChunkReader is a ChunkReader that is used by Write()
+// when resuming uploads. It first returns any data that was staged by
+// previous attempts, followed by data received from the client. The
+// latter is staged as well, so that it may be resumed once more if
+// the current attempt is interrupted.
+type partialUploadChunkReader struct {
+	r                *byteStreamWriteServerChunkReader
+	upload           PartialUpload
+	replayOffset     int64
+	replaySizeBytes  int64
+	maximumChunkSize int
+	finishedWrite    bool
+}
+
+func (r *partialUploadChunkReader) Read() ([]byte, error) {
+	if remaining := r.replaySizeBytes - r.replayOffset; remaining > 0 {
+		chunkSize := int64(r.maximumChunkSize)
+		if chunkSize > remaining {
+			chunkSize = remaining
+		}
+		chunk := make([]byte, chunkSize)
+		if _, err := r.upload.ReadAt(chunk, r.replayOffset); err != nil {
+			return nil, err
+		}
+		r.replayOffset += chunkSize
+		return chunk, nil
+	}
+
+	chunk, err := r.r.Read()
+	if err != nil {
+		if err == io.EOF {
+			r.finishedWrite = true
+		}
+		return nil, err
+	}
+	if err := r.upload.Append(chunk); err != nil {
+		return nil, err
+	}
+	return chunk, nil
+}
+
+func (r *partialUploadChunkReader) Close() {}
+
 func (s *byteStreamServer) Write(stream bytestream.ByteStream_WriteServer) error {
 	request, err := stream.Recv()
 	if err != nil {
@@ -110,18 +190,88 @@ func (s *byteStreamServer) Write(stream bytestream.ByteStream_WriteServer) error
 	if err != nil {
 		return err
 	}
-	if compressor != remoteexecution.Compressor_IDENTITY {
-		return status.Error(codes.Unimplemented, "This service does not support uploading compressed files")
+
+	// If the object is already present, REv2 permits completing
+	// the write immediately. For uncompressed uploads, the full size
+	// of the object is reported as being committed. For compressed
+	// uploads, REv2 requires that -1 is reported instead.
+	if s.checkExistenceBeforeWrite {
+		missing, err := s.blobAccess.FindMissing(stream.Context(), digest.ToSingletonSet())
+		if err != nil {
+			return util.StatusWrap(err, "Failed to determine existence of blob")
+		}
+		if missing.Empty() {
+			committedSize := digest.GetSizeBytes()
+			if compressor != remoteexecution.Compressor_IDENTITY {
+				committedSize = -1
+			}
+			return stream.SendAndClose(&bytestream.WriteResponse{
+				CommittedSize: committedSize,
+			})
+		}
 	}
 
+	// For compressed resources, the write offset of successive
+	// requests is based on the size of the compressed data. This is
+	// consistent with how byteStreamWriteServerChunkReader tracks
+	// offsets, as it only observes compressed data.
 	r := &byteStreamWriteServerChunkReader{stream: stream}
+	if s.partialUploadStore != nil && compressor == remoteexecution.Compressor_IDENTITY && digest.GetSizeBytes() >= s.minimumPartialUploadSize {
+		return s.writeResumable(stream, request, digest, r)
+	}
 	if err := r.setRequest(request); err != nil {
 		return err
 	}
 	if err := s.blobAccess.Put(
 		stream.Context(),
 		digest,
-		buffer.NewCASBufferFromChunkReader(digest, r, buffer.UserProvided)); err != nil {
+		buffer.NewCASBufferFromCompressedChunkReader(digest, r, compressor, buffer.UserProvided)); err != nil {
+		return err
+	}
+	return stream.SendAndClose(&bytestream.WriteResponse{
+		CommittedSize: digest.GetSizeBytes(),
+	})
+}
+
+// writeResumable is invoked by Write() to process uploads of
+// uncompressed data, while staging data in the PartialUploadStore.
+func (s *byteStreamServer) writeResumable(stream bytestream.ByteStream_WriteServer, request *bytestream.WriteRequest, digest digest.Digest, r *byteStreamWriteServerChunkReader) error {
+	upload, err := s.partialUploadStore.Open(getUploadID(request.ResourceName), digest)
+	if err != nil {
+		return err
+	}
+
+	// Clients may restart uploads at any time by writing at offset
+	// zero, in which case previously staged data is discarded.
+	if request.WriteOffset == 0 {
+		if err := upload.Truncate(); err != nil {
+			upload.Release(true)
+			return err
+		}
+	}
+	stagedSizeBytes := upload.GetSizeBytes()
+	r.writeOffset = stagedSizeBytes
+	if err := r.setRequest(request); err != nil {
+		upload.Release(false)
+		return err
+	}
+
+	pr := &partialUploadChunkReader{
+		r:                r,
+		upload:           upload,
+		replaySizeBytes:  stagedSizeBytes,
+		maximumChunkSize: s.readChunkSize,
+	}
+	err = s.blobAccess.Put(
+		stream.Context(),
+		digest,
+		buffer.NewCASBufferFromChunkReader(digest, pr, buffer.UserProvided))
+
+	// Staged data is retained if the client may resume the upload.
+	// It is discarded if the upload succeeded, or if all data was
+	// received and turned out to be invalid.
+	upload.Release(err == nil || (pr.finishedWrite && status.Code(err) == codes.InvalidArgument))
+	if err != nil {
 		return err
 	}
 	return stream.SendAndClose(&bytestream.WriteResponse{
@@ -130,5 +280,32 @@ func (s *byteStreamServer) Write(stream bytestream.ByteStream_WriteServer) error
 }
 
 func (s *byteStreamServer) QueryWriteStatus(ctx context.Context, in *bytestream.QueryWriteStatusRequest) (*bytestream.QueryWriteStatusResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "This service does not support querying write status")
+	digest, _, err := digest.NewDigestFromByteStreamWritePath(in.ResourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Report the amount of data staged for an upload that is either
+	// in progress or was interrupted.
+	if s.partialUploadStore != nil {
+		if sizeBytes := s.partialUploadStore.GetSizeBytes(getUploadID(in.ResourceName), digest); sizeBytes > 0 {
+			return &bytestream.QueryWriteStatusResponse{
+				CommittedSize: sizeBytes,
+			}, nil
+		}
+	}
+
+	// The upload may already have completed, or the object may
+	// have been uploaded by another client.
+	missing, err := s.blobAccess.FindMissing(ctx, digest.ToSingletonSet())
+	if err != nil {
+		return nil, util.StatusWrap(err, "Failed to determine existence of blob")
+	}
+	if missing.Empty() {
+		return &bytestream.QueryWriteStatusResponse{
+			CommittedSize: digest.GetSizeBytes(),
+			Complete:      true,
+		}, nil
+	}
+	return &bytestream.QueryWriteStatusResponse{}, nil
 }
@@ -1,6 +1,8 @@
 package grpcservers_test
 
 import (
+	"bytes"
+	"compress/flate"
 	"context"
 	"io"
 	"net"
@@ -11,8 +13,10 @@ import (
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/grpcservers"
 	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/filesystem"
 	"github.com/buildbarn/bb-storage/pkg/testutil"
 	"github.com/golang/mock/gomock"
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/require"
 
 	"google.golang.org/genproto/googleapis/bytestream"
@@ -29,7 +33,7 @@ func TestByteStreamServer(t *testing.T) {
 	l := bufconn.Listen(1 << 20)
 	server := grpc.NewServer()
 	blobAccess := mock.NewMockBlobAccess(ctrl)
-	bytestream.RegisterByteStreamServer(server, grpcservers.NewByteStreamServer(blobAccess, 10))
+	bytestream.RegisterByteStreamServer(server, grpcservers.NewByteStreamServer(blobAccess, 10, nil, 0, false))
 	go func() {
 		require.NoError(t, server.Serve(l))
 	}()
@@ -178,6 +182,42 @@ func TestByteStreamServer(t *testing.T) {
 		require.Equal(t, io.EOF, err)
 	})
 
+	t.Run("ReadSuccessWithLimit", func(t *testing.T) {
+		// Attempt to fetch a part of a blob, using both an
+		// offset and a limit.
+		blobAccess.EXPECT().Get(
+			gomock.Any(),
+			digest.MustNewDigest("ubuntu1804", "da39a3ee5e6b4b0d3255bfef95601890", 19),
+		).Return(buffer.NewValidatedBufferFromByteSlice([]byte("This offset message")))
+
+		req, err := client.Read(ctx, &bytestream.ReadRequest{
+			ResourceName: "ubuntu1804/blobs/da39a3ee5e6b4b0d3255bfef95601890/19",
+			ReadOffset:   5,
+			ReadLimit:    6,
+		})
+		require.NoError(t, err)
+		readResponse, err := req.Recv()
+		require.NoError(t, err)
+		require.Equal(t, []byte("offset"), readResponse.Data)
+		_, err = req.Recv()
+		require.Equal(t, io.EOF, err)
+	})
+
+	t.Run("ReadNegativeReadLimit", func(t *testing.T) {
+		blobAccess.EXPECT().Get(
+			gomock.Any(),
+			digest.MustNewDigest("ubuntu1804", "da39a3ee5e6b4b0d3255bfef95601890", 19),
+		).Return(buffer.NewValidatedBufferFromByteSlice([]byte("This offset message")))
+
+		req, err := client.Read(ctx, &bytestream.ReadRequest{
+			ResourceName: "ubuntu1804/blobs/da39a3ee5e6b4b0d3255bfef95601890/19",
+			ReadLimit:    -1,
+		})
+		require.NoError(t, err)
+		_, err = req.Recv()
+		testutil.RequireEqualStatus(t, status.Error(codes.InvalidArgument, "Negative read limit: -1"), err)
+	})
+
 	t.Run("ReadNonexistentBlob", func(t *testing.T) {
 		// Attempt to fetch a nonexistent blob.
 		blobAccess.EXPECT().Get(
@@ -193,6 +233,50 @@ func TestByteStreamServer(t *testing.T) {
 		testutil.RequireEqualStatus(t, status.Error(codes.NotFound, "Blob not found"), err)
 	})
 
+	t.Run("ReadCompressedWithReadLimit", func(t *testing.T) {
+		// REv2 requires that read limits are not used in
+		// combination with compression.
+		req, err := client.Read(ctx, &bytestream.ReadRequest{
+			ResourceName: "ubuntu1804/compressed-blobs/zstd/da39a3ee5e6b4b0d3255bfef95601890/19",
+			ReadLimit:    5,
+		})
+		require.NoError(t, err)
+		_, err = req.Recv()
+		testutil.RequireEqualStatus(t, status.Error(codes.InvalidArgument, "Read limits cannot be used in combination with compression"), err)
+	})
+
+	t.Run("ReadCompressedSuccess", func(t *testing.T) {
+		// Data should be compressed on the fly. The read
+		// offset applies to the uncompressed data.
+		blobAccess.EXPECT().Get(
+			gomock.Any(),
+			digest.MustNewDigest("ubuntu1804", "da39a3ee5e6b4b0d3255bfef95601890", 19),
+		).Return(buffer.NewValidatedBufferFromByteSlice([]byte("This offset message")))
+
+		req, err := client.Read(ctx, &bytestream.ReadRequest{
+			ResourceName: "ubuntu1804/compressed-blobs/zstd/da39a3ee5e6b4b0d3255bfef95601890/19",
+			ReadOffset:   4,
+		})
+		require.NoError(t, err)
+		var compressed []byte
+		for {
+			readResponse, err := req.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(readResponse.Data), 10)
+			compressed = append(compressed, readResponse.Data...)
+		}
+
+		decoder, err := zstd.NewReader(nil)
+		require.NoError(t, err)
+		defer decoder.Close()
+		decompressed, err := decoder.DecodeAll(compressed, nil)
+		require.NoError(t, err)
+		require.Equal(t, []byte(" offset message"), decompressed)
+	})
+
 	t.Run("WriteBadResourceName", func(t *testing.T) {
 		// Attempt to write to a bad resource name.
 		stream, err := client.Write(ctx)
@@ -311,10 +395,348 @@ func TestByteStreamServer(t *testing.T) {
 		testutil.RequireEqualStatus(t, status.Error(codes.InvalidArgument, "Attempted to write at offset 4, while 5 was expected"), err)
 	})
 
-	t.Run("QueryWriteStatus", func(t *testing.T) {
+	t.Run("WriteCompressedSuccess", func(t *testing.T) {
+		// Attempt to write a blob in compressed form. Write
+		// offsets of successive requests are based on the size
+		// of the compressed data.
+		blobAccess.EXPECT().Put(
+			gomock.Any(),
+			digest.MustNewDigest("", "581c1053f832a1c719fb6528a588ccfd", 14),
+			gomock.Any(),
+		).DoAndReturn(func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+			data, err := b.ToByteSlice(100)
+			require.NoError(t, err)
+			require.Equal(t, []byte("LaputanMachine"), data)
+			return nil
+		})
+
+		encoder, err := zstd.NewWriter(nil)
+		require.NoError(t, err)
+		compressed := encoder.EncodeAll([]byte("LaputanMachine"), nil)
+
+		stream, err := client.Write(ctx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&bytestream.WriteRequest{
+			ResourceName: "uploads/7de747e0-ab6b-4d83-90cb-11989f84c473/compressed-blobs/zstd/581c1053f832a1c719fb6528a588ccfd/14",
+			Data:         compressed[:5],
+		}))
+		require.NoError(t, stream.Send(&bytestream.WriteRequest{
+			Data:        compressed[5:],
+			WriteOffset: 5,
+			FinishWrite: true,
+		}))
+		response, err := stream.CloseAndRecv()
+		require.NoError(t, err)
+		require.Equal(t, int64(14), response.CommittedSize)
+	})
+
+	t.Run("WriteCompressedChecksumMismatch", func(t *testing.T) {
+		// The uncompressed data must match the digest.
+		blobAccess.EXPECT().Put(
+			gomock.Any(),
+			digest.MustNewDigest("", "581c1053f832a1c719fb6528a588ccfd", 14),
+			gomock.Any(),
+		).DoAndReturn(func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+			_, err := b.ToByteSlice(100)
+			return err
+		})
+
+		var compressed bytes.Buffer
+		w, err := flate.NewWriter(&compressed, flate.BestCompression)
+		require.NoError(t, err)
+		_, err = w.Write([]byte("LaputanMachinf"))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		stream, err := client.Write(ctx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&bytestream.WriteRequest{
+			ResourceName: "uploads/7de747e0-ab6b-4d83-90cb-11989f84c473/compressed-blobs/deflate/581c1053f832a1c719fb6528a588ccfd/14",
+			Data:         compressed.Bytes(),
+			FinishWrite:  true,
+		}))
+		_, err = stream.CloseAndRecv()
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("QueryWriteStatusMissing", func(t *testing.T) {
+		// Without a partial upload store, uploads can only be
+		// reported as either absent or complete.
+		blobAccess.EXPECT().FindMissing(
+			gomock.Any(),
+			digest.MustNewDigest("windows10", "68e109f0f40ca72a15e05cc22786f8e6", 10).ToSingletonSet(),
+		).Return(digest.MustNewDigest("windows10", "68e109f0f40ca72a15e05cc22786f8e6", 10).ToSingletonSet(), nil)
+
+		response, err := client.QueryWriteStatus(ctx, &bytestream.QueryWriteStatusRequest{
+			ResourceName: "windows10/uploads/d834d9c2-f3c9-4f30-a698-75fd4be9470d/blobs/68e109f0f40ca72a15e05cc22786f8e6/10",
+		})
+		require.NoError(t, err)
+		testutil.RequireEqualProto(t, &bytestream.QueryWriteStatusResponse{}, response)
+	})
+
+	t.Run("QueryWriteStatusComplete", func(t *testing.T) {
+		blobAccess.EXPECT().FindMissing(
+			gomock.Any(),
+			digest.MustNewDigest("windows10", "68e109f0f40ca72a15e05cc22786f8e6", 10).ToSingletonSet(),
+		).Return(digest.EmptySet, nil)
+
+		response, err := client.QueryWriteStatus(ctx, &bytestream.QueryWriteStatusRequest{
+			ResourceName: "windows10/uploads/d834d9c2-f3c9-4f30-a698-75fd4be9470d/blobs/68e109f0f40ca72a15e05cc22786f8e6/10",
+		})
+		require.NoError(t, err)
+		testutil.RequireEqualProto(t, &bytestream.QueryWriteStatusResponse{
+			CommittedSize: 10,
+			Complete:      true,
+		}, response)
+	})
+
+	t.Run("QueryWriteStatusFailure", func(t *testing.T) {
+		blobAccess.EXPECT().FindMissing(
+			gomock.Any(),
+			digest.MustNewDigest("windows10", "68e109f0f40ca72a15e05cc22786f8e6", 10).ToSingletonSet(),
+		).Return(digest.EmptySet, status.Error(codes.Unavailable, "Server on fire"))
+
 		_, err := client.QueryWriteStatus(ctx, &bytestream.QueryWriteStatusRequest{
 			ResourceName: "windows10/uploads/d834d9c2-f3c9-4f30-a698-75fd4be9470d/blobs/68e109f0f40ca72a15e05cc22786f8e6/10",
 		})
-		testutil.RequireEqualStatus(t, status.Error(codes.Unimplemented, "This service does not support querying write status"), err)
+		testutil.RequireEqualStatus(t, status.Error(codes.Unavailable, "Failed to determine existence of blob: Server on fire"), err)
+	})
+}
+
+func TestByteStreamServerResumableWrite(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	// Create an RPC server/client pair, where the server stages
+	// data of partial uploads in a temporary directory.
+	directory, err := filesystem.NewLocalDirectory(t.TempDir())
+	require.NoError(t, err)
+	defer directory.Close()
+	clock := mock.NewMockClock(ctrl)
+	clock.EXPECT().Now().Return(time.Unix(1000, 0)).AnyTimes()
+	partialUploadStore, err := grpcservers.NewDirectoryBackedPartialUploadStore(directory, clock, time.Hour, 1<<20)
+	require.NoError(t, err)
+
+	l := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	blobAccess := mock.NewMockBlobAccess(ctrl)
+	bytestream.RegisterByteStreamServer(server, grpcservers.NewByteStreamServer(blobAccess, 10, partialUploadStore, 10, false))
+	go func() {
+		require.NoError(t, server.Serve(l))
+	}()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithDialer(func(string, time.Duration) (net.Conn, error) {
+		return l.Dial()
+	}), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer server.Stop()
+	defer conn.Close()
+	client := bytestream.NewByteStreamClient(conn)
+
+	const resourceName = "uploads/7de747e0-ab6b-4d83-90cb-11989f84c473/blobs/581c1053f832a1c719fb6528a588ccfd/14"
+	blobDigest := digest.MustNewDigest("", "581c1053f832a1c719fb6528a588ccfd", 14)
+
+	// Start an upload that gets interrupted halfway.
+	blobAccess.EXPECT().Put(gomock.Any(), blobDigest, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+			_, err := b.ToByteSlice(100)
+			return err
+		})
+	stream, err := client.Write(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&bytestream.WriteRequest{
+		ResourceName: resourceName,
+		Data:         []byte("Laputan"),
+	}))
+	_, err = stream.CloseAndRecv()
+	testutil.RequireEqualStatus(t, status.Error(codes.InvalidArgument, "Client closed stream without finishing write"), err)
+
+	// The data that was received should be reported as committed.
+	response, err := client.QueryWriteStatus(ctx, &bytestream.QueryWriteStatusRequest{
+		ResourceName: resourceName,
+	})
+	require.NoError(t, err)
+	testutil.RequireEqualProto(t, &bytestream.QueryWriteStatusResponse{
+		CommittedSize: 7,
+	}, response)
+
+	t.Run("BadOffset", func(t *testing.T) {
+		// Resuming at an offset other than the committed size
+		// should fail, without discarding the staged data.
+		stream, err := client.Write(ctx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&bytestream.WriteRequest{
+			ResourceName: resourceName,
+			WriteOffset:  3,
+			Data:         []byte("utanMachine"),
+			FinishWrite:  true,
+		}))
+		_, err = stream.CloseAndRecv()
+		testutil.RequireEqualStatus(t, status.Error(codes.InvalidArgument, "Attempted to write at offset 3, while 7 was expected"), err)
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		// Resuming at the committed size should cause the
+		// staged data to be prepended.
+		blobAccess.EXPECT().Put(gomock.Any(), blobDigest, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+				data, err := b.ToByteSlice(100)
+				require.NoError(t, err)
+				require.Equal(t, []byte("LaputanMachine"), data)
+				return nil
+			})
+		stream, err := client.Write(ctx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&bytestream.WriteRequest{
+			ResourceName: resourceName,
+			WriteOffset:  7,
+			Data:         []byte("Machine"),
+			FinishWrite:  true,
+		}))
+		response, err := stream.CloseAndRecv()
+		require.NoError(t, err)
+		require.Equal(t, int64(14), response.CommittedSize)
+	})
+
+	t.Run("Completed", func(t *testing.T) {
+		// Staged data should be discarded after the upload
+		// completes, meaning the status is obtained from
+		// storage.
+		blobAccess.EXPECT().FindMissing(gomock.Any(), blobDigest.ToSingletonSet()).
+			Return(digest.EmptySet, nil)
+
+		response, err := client.QueryWriteStatus(ctx, &bytestream.QueryWriteStatusRequest{
+			ResourceName: resourceName,
+		})
+		require.NoError(t, err)
+		testutil.RequireEqualProto(t, &bytestream.QueryWriteStatusResponse{
+			CommittedSize: 14,
+			Complete:      true,
+		}, response)
+	})
+
+	t.Run("BelowMinimumSize", func(t *testing.T) {
+		// Uploads of objects that are smaller than the minimum
+		// size should not be staged. When interrupted, they
+		// need to be restarted from the beginning.
+		const smallResourceName = "uploads/4a3f2b5c-58d4-4a8e-9e5c-0c8f61b2a7d9/blobs/8b1a9953c4611296a827abf8c47804d7/5"
+		smallDigest := digest.MustNewDigest("", "8b1a9953c4611296a827abf8c47804d7", 5)
+
+		blobAccess.EXPECT().Put(gomock.Any(), smallDigest, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+				_, err := b.ToByteSlice(100)
+				return err
+			})
+		stream, err := client.Write(ctx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&bytestream.WriteRequest{
+			ResourceName: smallResourceName,
+			Data:         []byte("Hel"),
+		}))
+		_, err = stream.CloseAndRecv()
+		testutil.RequireEqualStatus(t, status.Error(codes.InvalidArgument, "Client closed stream without finishing write"), err)
+
+		blobAccess.EXPECT().FindMissing(gomock.Any(), smallDigest.ToSingletonSet()).
+			Return(smallDigest.ToSingletonSet(), nil)
+
+		response, err := client.QueryWriteStatus(ctx, &bytestream.QueryWriteStatusRequest{
+			ResourceName: smallResourceName,
+		})
+		require.NoError(t, err)
+		testutil.RequireEqualProto(t, &bytestream.QueryWriteStatusResponse{}, response)
+	})
+}
+
+func TestByteStreamServerCheckExistenceBeforeWrite(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	// Create an RPC server/client pair, where the server checks for
+	// the existence of objects prior to receiving them.
+	l := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	blobAccess := mock.NewMockBlobAccess(ctrl)
+	bytestream.RegisterByteStreamServer(server, grpcservers.NewByteStreamServer(blobAccess, 10, nil, 0, true))
+	go func() {
+		require.NoError(t, server.Serve(l))
+	}()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithDialer(func(string, time.Duration) (net.Conn, error) {
+		return l.Dial()
+	}), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer server.Stop()
+	defer conn.Close()
+	client := bytestream.NewByteStreamClient(conn)
+
+	const resourceName = "uploads/7de747e0-ab6b-4d83-90cb-11989f84c473/blobs/581c1053f832a1c719fb6528a588ccfd/14"
+	blobDigest := digest.MustNewDigest("", "581c1053f832a1c719fb6528a588ccfd", 14)
+
+	t.Run("Present", func(t *testing.T) {
+		// The write should complete immediately, reporting the
+		// full size of the object as being committed.
+		blobAccess.EXPECT().FindMissing(gomock.Any(), blobDigest.ToSingletonSet()).
+			Return(digest.EmptySet, nil)
+
+		stream, err := client.Write(ctx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&bytestream.WriteRequest{
+			ResourceName: resourceName,
+			Data:         []byte("Laputan"),
+		}))
+		response, err := stream.CloseAndRecv()
+		require.NoError(t, err)
+		require.Equal(t, int64(14), response.CommittedSize)
+	})
+
+	t.Run("PresentCompressed", func(t *testing.T) {
+		// For compressed uploads, REv2 requires that a
+		// committed size of -1 is reported.
+		blobAccess.EXPECT().FindMissing(gomock.Any(), blobDigest.ToSingletonSet()).
+			Return(digest.EmptySet, nil)
+
+		stream, err := client.Write(ctx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&bytestream.WriteRequest{
+			ResourceName: "uploads/7de747e0-ab6b-4d83-90cb-11989f84c473/compressed-blobs/zstd/581c1053f832a1c719fb6528a588ccfd/14",
+			Data:         []byte("Laputan"),
+		}))
+		response, err := stream.CloseAndRecv()
+		require.NoError(t, err)
+		require.Equal(t, int64(-1), response.CommittedSize)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		// Objects that are absent should be written as usual.
+		blobAccess.EXPECT().FindMissing(gomock.Any(), blobDigest.ToSingletonSet()).
+			Return(blobDigest.ToSingletonSet(), nil)
+		blobAccess.EXPECT().Put(gomock.Any(), blobDigest, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+				data, err := b.ToByteSlice(100)
+				require.NoError(t, err)
+				require.Equal(t, []byte("LaputanMachine"), data)
+				return nil
+			})
+
+		stream, err := client.Write(ctx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&bytestream.WriteRequest{
+			ResourceName: resourceName,
+			Data:         []byte("LaputanMachine"),
+			FinishWrite:  true,
+		}))
+		response, err := stream.CloseAndRecv()
+		require.NoError(t, err)
+		require.Equal(t, int64(14), response.CommittedSize)
+	})
+
+	t.Run("FindMissingFailure", func(t *testing.T) {
+		blobAccess.EXPECT().FindMissing(gomock.Any(), blobDigest.ToSingletonSet()).
+			Return(digest.EmptySet, status.Error(codes.Unavailable, "Server on fire"))
+
+		stream, err := client.Write(ctx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&bytestream.WriteRequest{
+			ResourceName: resourceName,
+			Data:         []byte("Laputan"),
+		}))
+		_, err = stream.CloseAndRecv()
+		testutil.RequireEqualStatus(t, status.Error(codes.Unavailable, "Failed to determine existence of blob: Server on fire"), err)
 	})
 }
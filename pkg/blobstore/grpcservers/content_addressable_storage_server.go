@@ -2,29 +2,80 @@ package grpcservers
 
 import (
 	"context"
+	"encoding/base64"
+	"sync"
 
 	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
 	"github.com/buildbarn/bb-storage/pkg/blobstore"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
 	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/proto/cas"
 	"github.com/buildbarn/bb-storage/pkg/util"
 
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
 )
 
 type contentAddressableStorageServer struct {
 	contentAddressableStorage blobstore.BlobAccess
 	maximumMessageSizeBytes   int64
+	maximumBatchConcurrency   int64
 }
 
 // NewContentAddressableStorageServer creates a GRPC service for serving
 // the contents of a Bazel Content Addressable Storage (CAS) to Bazel.
-func NewContentAddressableStorageServer(contentAddressableStorage blobstore.BlobAccess, maximumMessageSizeBytes int64) remoteexecution.ContentAddressableStorageServer {
+//
+// BatchReadBlobs() and BatchUpdateBlobs() process the objects contained
+// in a single request in parallel, with at most
+// maximumBatchConcurrency objects being processed at a time. A value
+// of zero causes objects to be processed sequentially.
+func NewContentAddressableStorageServer(contentAddressableStorage blobstore.BlobAccess, maximumMessageSizeBytes, maximumBatchConcurrency int64) remoteexecution.ContentAddressableStorageServer {
+	if maximumBatchConcurrency < 1 {
+		maximumBatchConcurrency = 1
+	}
 	return &contentAddressableStorageServer{
 		contentAddressableStorage: contentAddressableStorage,
 		maximumMessageSizeBytes:   maximumMessageSizeBytes,
+		maximumBatchConcurrency:   maximumBatchConcurrency,
+	}
+}
+
+// forEachInBatch calls a function for every object contained in a
+// batch request, running at most maximumBatchConcurrency calls in
+// parallel. The function is expected to store its results in a
+// response, as errors are reported on a per-object basis.
+func (s *contentAddressableStorageServer) forEachInBatch(ctx context.Context, count int, f func(i int)) error {
+	if s.maximumBatchConcurrency == 1 {
+		// Don't bother launching goroutines if objects are
+		// processed sequentially.
+		for i := 0; i < count; i++ {
+			if ctx.Err() != nil {
+				return util.StatusFromContext(ctx)
+			}
+			f(i)
+		}
+		return nil
 	}
+
+	concurrency := semaphore.NewWeighted(s.maximumBatchConcurrency)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for i := 0; i < count; i++ {
+		if concurrency.Acquire(ctx, 1) != nil {
+			return util.StatusFromContext(ctx)
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer concurrency.Release(1)
+			f(i)
+		}(i)
+	}
+	return nil
 }
 
 func (s *contentAddressableStorageServer) FindMissingBlobs(ctx context.Context, in *remoteexecution.FindMissingBlobsRequest) (*remoteexecution.FindMissingBlobsResponse, error) {
@@ -35,7 +86,7 @@ func (s *contentAddressableStorageServer) FindMissingBlobs(ctx context.Context,
 
 	inDigests := digest.NewSetBuilder()
 	for _, partialDigest := range in.BlobDigests {
-		digest, err := instanceName.NewDigestFromProto(partialDigest)
+		digest, err := instanceName.NewDigestFromProtoAndDigestFunction(in.DigestFunction, partialDigest)
 		if err != nil {
 			return nil, err
 		}
@@ -63,7 +114,7 @@ func (s *contentAddressableStorageServer) BatchReadBlobs(ctx context.Context, in
 	bytesRemaining := s.maximumMessageSizeBytes
 	digests := make([]digest.Digest, 0, len(in.Digests))
 	for _, reqDigest := range in.Digests {
-		digest, err := instanceName.NewDigestFromProto(reqDigest)
+		digest, err := instanceName.NewDigestFromProtoAndDigestFunction(in.DigestFunction, reqDigest)
 		if err != nil {
 			return nil, err
 		}
@@ -79,18 +130,21 @@ func (s *contentAddressableStorageServer) BatchReadBlobs(ctx context.Context, in
 		digests = append(digests, digest)
 	}
 
-	var response remoteexecution.BatchReadBlobsResponse
-	for i, reqDigest := range in.Digests {
+	response := remoteexecution.BatchReadBlobsResponse{
+		Responses: make([]*remoteexecution.BatchReadBlobsResponse_Response, len(in.Digests)),
+	}
+	if err := s.forEachInBatch(ctx, len(in.Digests), func(i int) {
 		data, err := s.contentAddressableStorage.Get(
 			ctx,
 			digests[i]).ToByteSlice(int(digests[i].GetSizeBytes()))
-		response.Responses = append(response.Responses, &remoteexecution.BatchReadBlobsResponse_Response{
-			Digest: reqDigest,
+		response.Responses[i] = &remoteexecution.BatchReadBlobsResponse_Response{
+			Digest: in.Digests[i],
 			Data:   data,
 			Status: status.Convert(err).Proto(),
-		})
+		}
+	}); err != nil {
+		return nil, err
 	}
-
 	return &response, nil
 }
 
@@ -100,25 +154,270 @@ func (s *contentAddressableStorageServer) BatchUpdateBlobs(ctx context.Context,
 		return nil, util.StatusWrapf(err, "Invalid instance name %#v", in.InstanceName)
 	}
 
-	var response remoteexecution.BatchUpdateBlobsResponse
-	for _, request := range in.Requests {
-		digest, err := instanceName.NewDigestFromProto(request.Digest)
+	response := remoteexecution.BatchUpdateBlobsResponse{
+		Responses: make([]*remoteexecution.BatchUpdateBlobsResponse_Response, len(in.Requests)),
+	}
+	if err := s.forEachInBatch(ctx, len(in.Requests), func(i int) {
+		request := in.Requests[i]
+		digest, err := instanceName.NewDigestFromProtoAndDigestFunction(in.DigestFunction, request.Digest)
 		if err == nil {
 			err = s.contentAddressableStorage.Put(
 				ctx,
 				digest,
 				buffer.NewCASBufferFromByteSlice(digest, request.Data, buffer.UserProvided))
 		}
-		response.Responses = append(response.Responses,
-			&remoteexecution.BatchUpdateBlobsResponse_Response{
-				Digest: request.Digest,
-				Status: status.Convert(err).Proto(),
-			})
+		response.Responses[i] = &remoteexecution.BatchUpdateBlobsResponse_Response{
+			Digest: request.Digest,
+			Status: status.Convert(err).Proto(),
+		}
+	}); err != nil {
+		return nil, err
 	}
-
 	return &response, nil
 }
 
+// getTreeBatchSize is the maximum number of Directory messages that
+// GetTree() loads from the Content Addressable Storage in parallel.
+const getTreeBatchSize = 100
+
 func (s *contentAddressableStorageServer) GetTree(in *remoteexecution.GetTreeRequest, stream remoteexecution.ContentAddressableStorage_GetTreeServer) error {
-	return status.Error(codes.Unimplemented, "This service does not support downloading directory trees")
+	instanceName, err := digest.NewInstanceName(in.InstanceName)
+	if err != nil {
+		return util.StatusWrapf(err, "Invalid instance name %#v", in.InstanceName)
+	}
+	if in.PageSize < 0 {
+		return status.Errorf(codes.InvalidArgument, "Invalid page size: %d", in.PageSize)
+	}
+
+	// Determine which directories still need to be returned. For
+	// the first page, this is only the root directory. For
+	// successive pages, this list is stored in the page token,
+	// together with the directories that have already been
+	// returned.
+	var pendingDigests, returnedDigests []digest.Digest
+	if in.PageToken == "" {
+		rootDigest, err := instanceName.NewDigestFromProtoAndDigestFunction(in.DigestFunction, in.RootDigest)
+		if err != nil {
+			return util.StatusWrap(err, "Invalid root digest")
+		}
+		pendingDigests = append(pendingDigests, rootDigest)
+	} else {
+		pendingDigests, returnedDigests, err = s.parseGetTreePageToken(instanceName, in.DigestFunction, in.PageToken)
+		if err != nil {
+			return err
+		}
+	}
+	discoveredDigests := make(map[digest.Digest]struct{}, len(pendingDigests)+len(returnedDigests))
+	pendingSizeBytes := 0
+	for _, pendingDigest := range pendingDigests {
+		discoveredDigests[pendingDigest] = struct{}{}
+		pendingSizeBytes += getGetTreePageTokenEntrySizeBytes(pendingDigest)
+	}
+	for _, returnedDigest := range returnedDigests {
+		discoveredDigests[returnedDigest] = struct{}{}
+	}
+
+	// Traverse the tree in breadth-first order, loading Directory
+	// messages in batches. Responses are flushed whenever they
+	// would otherwise exceed the maximum message size.
+	//
+	// As the digests of all directories that are discovered, but
+	// not yet returned, need to be stored in the page token,
+	// directories are only expanded as long as the page token stays
+	// within bounds. The first directory of every page is always
+	// returned to guarantee progress.
+	ctx := stream.Context()
+	maximumPageTokenSizeBytes := s.getMaximumGetTreePageTokenSizeBytes()
+	var response remoteexecution.GetTreeResponse
+	responseSizeBytes := 0
+	directoriesRemaining := int(in.PageSize)
+	directoriesReturned := 0
+	pageTokenFull := false
+	for len(pendingDigests) > 0 && (in.PageSize == 0 || directoriesRemaining > 0) && !pageTokenFull {
+		batchSize := len(pendingDigests)
+		if batchSize > getTreeBatchSize {
+			batchSize = getTreeBatchSize
+		}
+		if in.PageSize != 0 && batchSize > directoriesRemaining {
+			batchSize = directoriesRemaining
+		}
+		batchDigests := pendingDigests[:batchSize]
+		directories, err := s.getDirectories(ctx, batchDigests)
+		if err != nil {
+			return err
+		}
+
+		batchReturned := 0
+		for i, directory := range directories {
+			// Child directories use the same digest function
+			// as their parent.
+			directoryDigest := batchDigests[i]
+			digestFunction := directoryDigest.GetDigestFunction()
+			var childDigests []digest.Digest
+			childrenSizeBytes := 0
+			for _, child := range directory.Directories {
+				childDigest, err := digestFunction.NewDigestFromProto(child.Digest)
+				if err != nil {
+					return util.StatusWrapf(err, "Invalid digest for child directory %#v", child.Name)
+				}
+				if _, ok := discoveredDigests[childDigest]; !ok {
+					discoveredDigests[childDigest] = struct{}{}
+					childDigests = append(childDigests, childDigest)
+					childrenSizeBytes += getGetTreePageTokenEntrySizeBytes(childDigest)
+				}
+			}
+			if directoriesReturned > 0 && pendingSizeBytes+childrenSizeBytes > maximumPageTokenSizeBytes {
+				// Expanding this directory would cause the
+				// page token to become too large. Return
+				// it as part of the next page instead.
+				for _, childDigest := range childDigests {
+					delete(discoveredDigests, childDigest)
+				}
+				pageTokenFull = true
+				break
+			}
+			pendingDigests = append(pendingDigests, childDigests...)
+			pendingSizeBytes += childrenSizeBytes - getGetTreePageTokenEntrySizeBytes(directoryDigest)
+			returnedDigests = append(returnedDigests, directoryDigest)
+			batchReturned++
+			directoriesReturned++
+
+			entrySizeBytes := getRepeatedFieldEntrySizeBytes(proto.Size(directory))
+			if len(response.Directories) > 0 && int64(responseSizeBytes+entrySizeBytes) > s.maximumMessageSizeBytes {
+				if err := stream.Send(&response); err != nil {
+					return err
+				}
+				response = remoteexecution.GetTreeResponse{}
+				responseSizeBytes = 0
+			}
+			response.Directories = append(response.Directories, directory)
+			responseSizeBytes += entrySizeBytes
+		}
+		pendingDigests = pendingDigests[batchReturned:]
+		directoriesRemaining -= batchReturned
+	}
+
+	// Attach a page token to the final response in case there are
+	// still directories left to be returned.
+	if len(pendingDigests) > 0 {
+		pageToken, err := s.newGetTreePageToken(pendingDigests, returnedDigests, maximumPageTokenSizeBytes-pendingSizeBytes)
+		if err != nil {
+			return err
+		}
+		if len(response.Directories) > 0 && int64(responseSizeBytes+getRepeatedFieldEntrySizeBytes(len(pageToken))) > s.maximumMessageSizeBytes {
+			if err := stream.Send(&response); err != nil {
+				return err
+			}
+			response = remoteexecution.GetTreeResponse{}
+		}
+		response.NextPageToken = pageToken
+	}
+	return stream.Send(&response)
+}
+
+// getDirectories loads a list of Directory messages from the Content
+// Addressable Storage in parallel.
+func (s *contentAddressableStorageServer) getDirectories(ctx context.Context, directoryDigests []digest.Digest) ([]*remoteexecution.Directory, error) {
+	directories := make([]*remoteexecution.Directory, len(directoryDigests))
+	group, groupCtx := errgroup.WithContext(ctx)
+	for i, directoryDigest := range directoryDigests {
+		i, directoryDigest := i, directoryDigest
+		group.Go(func() error {
+			directory, err := s.contentAddressableStorage.Get(groupCtx, directoryDigest).ToProto(&remoteexecution.Directory{}, int(s.maximumMessageSizeBytes))
+			if err != nil {
+				return util.StatusWrapf(err, "Failed to load directory %#v", directoryDigest.String())
+			}
+			directories[i] = directory.(*remoteexecution.Directory)
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	return directories, nil
+}
+
+// parseGetTreePageToken extracts the digests of directories that still
+// need to be returned and the digests of directories that have already
+// been returned from a page token created by newGetTreePageToken().
+func (s *contentAddressableStorageServer) parseGetTreePageToken(instanceName digest.InstanceName, digestFunction remoteexecution.DigestFunction_Value, pageToken string) ([]digest.Digest, []digest.Digest, error) {
+	data, err := base64.RawURLEncoding.DecodeString(pageToken)
+	if err != nil {
+		return nil, nil, util.StatusWrapWithCode(err, codes.InvalidArgument, "Invalid page token")
+	}
+	var message cas.GetTreePageToken
+	if err := proto.Unmarshal(data, &message); err != nil {
+		return nil, nil, util.StatusWrapWithCode(err, codes.InvalidArgument, "Invalid page token")
+	}
+	if len(message.PendingDirectoryDigests) == 0 {
+		return nil, nil, status.Error(codes.InvalidArgument, "Invalid page token: No pending directories")
+	}
+	pendingDigests := make([]digest.Digest, 0, len(message.PendingDirectoryDigests))
+	for _, pendingDigest := range message.PendingDirectoryDigests {
+		d, err := instanceName.NewDigestFromProtoAndDigestFunction(digestFunction, pendingDigest)
+		if err != nil {
+			return nil, nil, util.StatusWrap(err, "Invalid page token")
+		}
+		pendingDigests = append(pendingDigests, d)
+	}
+
+	// Returned directories are stored from most to least recently
+	// returned. Convert them back to the order in which they were
+	// returned.
+	returnedDigests := make([]digest.Digest, len(message.ReturnedDirectoryDigests))
+	for i, returnedDigest := range message.ReturnedDirectoryDigests {
+		d, err := instanceName.NewDigestFromProtoAndDigestFunction(digestFunction, returnedDigest)
+		if err != nil {
+			return nil, nil, util.StatusWrap(err, "Invalid page token")
+		}
+		returnedDigests[len(returnedDigests)-1-i] = d
+	}
+	return pendingDigests, returnedDigests, nil
+}
+
+// newGetTreePageToken creates an opaque page token that may be used to
+// resume traversal of a tree in a successive call to GetTree(). All
+// pending directories are stored in the page token. Directories that
+// have already been returned are only stored as long as they fit in
+// the remaining space, preferring the ones returned most recently.
+func (s *contentAddressableStorageServer) newGetTreePageToken(pendingDigests, returnedDigests []digest.Digest, remainingSizeBytes int) (string, error) {
+	message := cas.GetTreePageToken{
+		PendingDirectoryDigests: make([]*remoteexecution.Digest, 0, len(pendingDigests)),
+	}
+	for _, pendingDigest := range pendingDigests {
+		message.PendingDirectoryDigests = append(message.PendingDirectoryDigests, pendingDigest.GetProto())
+	}
+	for i := len(returnedDigests) - 1; i >= 0; i-- {
+		remainingSizeBytes -= getGetTreePageTokenEntrySizeBytes(returnedDigests[i])
+		if remainingSizeBytes < 0 {
+			break
+		}
+		message.ReturnedDirectoryDigests = append(message.ReturnedDirectoryDigests, returnedDigests[i].GetProto())
+	}
+	data, err := proto.Marshal(&message)
+	if err != nil {
+		return "", util.StatusWrapWithCode(err, codes.Internal, "Failed to marshal page token")
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// getMaximumGetTreePageTokenSizeBytes returns the maximum size of the
+// Protobuf message stored in page tokens returned by GetTree(). It is
+// chosen so that the base64 encoded page token takes up at most half
+// of the maximum message size.
+func (s *contentAddressableStorageServer) getMaximumGetTreePageTokenSizeBytes() int {
+	return int(s.maximumMessageSizeBytes) / 2 / 4 * 3
+}
+
+// getGetTreePageTokenEntrySizeBytes computes the number of bytes
+// needed to store the digest of a directory in a page token.
+func getGetTreePageTokenEntrySizeBytes(directoryDigest digest.Digest) int {
+	return getRepeatedFieldEntrySizeBytes(proto.Size(directoryDigest.GetProto()))
+}
+
+// getRepeatedFieldEntrySizeBytes computes the number of bytes needed
+// to store an entry of a given size in a repeated field of a Protobuf
+// message, taking the tag and length prefix into account.
+func getRepeatedFieldEntrySizeBytes(sizeBytes int) int {
+	return protowire.SizeTag(1) + protowire.SizeBytes(sizeBytes)
 }
@@ -2,21 +2,101 @@ package grpcservers_test
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"net"
+	"sync"
 	"testing"
+	"time"
 
 	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
 	"github.com/buildbarn/bb-storage/internal/mock"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/grpcservers"
 	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/testutil"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
 	status_pb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
 )
 
+func TestContentAddressableStorageServerFindMissingBlobs(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	contentAddressableStorage := mock.NewMockBlobAccess(ctrl)
+	contentAddressableStorageServer := grpcservers.NewContentAddressableStorageServer(contentAddressableStorage, 1<<16, 2)
+
+	t.Run("InferredDigestFunction", func(t *testing.T) {
+		// Clients that don't set the digest function use digest
+		// functions that are inferred from the hash length.
+		contentAddressableStorage.EXPECT().FindMissing(
+			ctx,
+			digest.NewSetBuilder().Add(digest.MustNewDigest("ubuntu1804", "409a7f83ac6b31dc8c77e3ec18038f209bd2f545e0f4177c2e2381aa4e067b49", 123)).Build(),
+		).Return(digest.EmptySet, nil)
+
+		response, err := contentAddressableStorageServer.FindMissingBlobs(ctx, &remoteexecution.FindMissingBlobsRequest{
+			InstanceName: "ubuntu1804",
+			BlobDigests: []*remoteexecution.Digest{
+				{
+					Hash:      "409a7f83ac6b31dc8c77e3ec18038f209bd2f545e0f4177c2e2381aa4e067b49",
+					SizeBytes: 123,
+				},
+			},
+		})
+		require.NoError(t, err)
+		testutil.RequireEqualProto(t, &remoteexecution.FindMissingBlobsResponse{}, response)
+	})
+
+	t.Run("ExplicitDigestFunction", func(t *testing.T) {
+		// BLAKE3 hashes have the same length as SHA-256 hashes.
+		// The digest function provided by the client should be
+		// respected.
+		blobDigest, err := digest.MustNewFunction("ubuntu1804", digest.DigestFunctionBLAKE3).NewDigest("409a7f83ac6b31dc8c77e3ec18038f209bd2f545e0f4177c2e2381aa4e067b49", 123)
+		require.NoError(t, err)
+		contentAddressableStorage.EXPECT().FindMissing(ctx, blobDigest.ToSingletonSet()).
+			Return(blobDigest.ToSingletonSet(), nil)
+
+		response, err := contentAddressableStorageServer.FindMissingBlobs(ctx, &remoteexecution.FindMissingBlobsRequest{
+			InstanceName: "ubuntu1804",
+			BlobDigests: []*remoteexecution.Digest{
+				{
+					Hash:      "409a7f83ac6b31dc8c77e3ec18038f209bd2f545e0f4177c2e2381aa4e067b49",
+					SizeBytes: 123,
+				},
+			},
+			DigestFunction: digest.DigestFunctionBLAKE3,
+		})
+		require.NoError(t, err)
+		testutil.RequireEqualProto(t, &remoteexecution.FindMissingBlobsResponse{
+			MissingBlobDigests: []*remoteexecution.Digest{
+				{
+					Hash:      "409a7f83ac6b31dc8c77e3ec18038f209bd2f545e0f4177c2e2381aa4e067b49",
+					SizeBytes: 123,
+				},
+			},
+		}, response)
+	})
+
+	t.Run("UnknownDigestFunction", func(t *testing.T) {
+		_, err := contentAddressableStorageServer.FindMissingBlobs(ctx, &remoteexecution.FindMissingBlobsRequest{
+			InstanceName: "ubuntu1804",
+			BlobDigests: []*remoteexecution.Digest{
+				{
+					Hash:      "409a7f83ac6b31dc8c77e3ec18038f209bd2f545e0f4177c2e2381aa4e067b49",
+					SizeBytes: 123,
+				},
+			},
+			DigestFunction: remoteexecution.DigestFunction_VSO,
+		})
+		testutil.RequireEqualStatus(t, status.Error(codes.InvalidArgument, "Unknown digest function"), err)
+	})
+}
+
 func TestContentAddressableStorageServerBatchReadBlobsSuccess(t *testing.T) {
 	ctrl, ctx := gomock.WithContext(context.Background(), t)
 
@@ -53,7 +133,7 @@ func TestContentAddressableStorageServerBatchReadBlobsSuccess(t *testing.T) {
 	buf3 := buffer.NewBufferFromError(status.Error(codes.NotFound, "The object you requested could not be found"))
 	contentAddressableStorage.EXPECT().Get(ctx, digest3).Return(buf3)
 
-	contentAddressableStorageServer := grpcservers.NewContentAddressableStorageServer(contentAddressableStorage, 1<<16)
+	contentAddressableStorageServer := grpcservers.NewContentAddressableStorageServer(contentAddressableStorage, 1<<16, 10)
 
 	response, err := contentAddressableStorageServer.BatchReadBlobs(ctx, request)
 	require.NoError(t, err)
@@ -106,10 +186,373 @@ func TestContentAddressableStorageServerBatchReadBlobsFailure(t *testing.T) {
 
 	contentAddressableStorage := mock.NewMockBlobAccess(ctrl)
 
-	contentAddressableStorageServer := grpcservers.NewContentAddressableStorageServer(contentAddressableStorage, 200)
+	contentAddressableStorageServer := grpcservers.NewContentAddressableStorageServer(contentAddressableStorage, 200, 1)
 
 	_, err := contentAddressableStorageServer.BatchReadBlobs(ctx, request)
 	require.Equal(t, status.Error(codes.InvalidArgument,
 		"Attempted to read a total of at least 357 bytes, while a maximum of 200 bytes is permitted"),
 		err)
 }
+
+func TestContentAddressableStorageServerBatchUpdateBlobs(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	contentAddressableStorage := mock.NewMockBlobAccess(ctrl)
+	contentAddressableStorageServer := grpcservers.NewContentAddressableStorageServer(contentAddressableStorage, 1<<16, 2)
+
+	// Both objects should be written in parallel. Each call to
+	// Put() blocks until the other one has been started.
+	var started sync.WaitGroup
+	started.Add(2)
+	contentAddressableStorage.EXPECT().Put(ctx, digest.MustNewDigest("ubuntu1804", "8b1a9953c4611296a827abf8c47804d7", 5), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+			b.Discard()
+			started.Done()
+			started.Wait()
+			return nil
+		})
+	contentAddressableStorage.EXPECT().Put(ctx, digest.MustNewDigest("ubuntu1804", "6fc422233a40a75a1f028e11c3cd1140", 7), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+			b.Discard()
+			started.Done()
+			started.Wait()
+			return status.Error(codes.Internal, "Disk on fire")
+		})
+
+	response, err := contentAddressableStorageServer.BatchUpdateBlobs(ctx, &remoteexecution.BatchUpdateBlobsRequest{
+		InstanceName: "ubuntu1804",
+		Requests: []*remoteexecution.BatchUpdateBlobsRequest_Request{
+			{
+				Digest: &remoteexecution.Digest{
+					Hash:      "8b1a9953c4611296a827abf8c47804d7",
+					SizeBytes: 5,
+				},
+				Data: []byte("Hello"),
+			},
+			{
+				Digest: &remoteexecution.Digest{
+					Hash:      "6fc422233a40a75a1f028e11c3cd1140",
+					SizeBytes: 7,
+				},
+				Data: []byte("Goodbye"),
+			},
+			{
+				Digest: &remoteexecution.Digest{
+					Hash:      "This is not a valid hash",
+					SizeBytes: 7,
+				},
+				Data: []byte("Goodbye"),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// Statuses should be reported in the original order.
+	testutil.RequireEqualProto(t, &remoteexecution.BatchUpdateBlobsResponse{
+		Responses: []*remoteexecution.BatchUpdateBlobsResponse_Response{
+			{
+				Digest: &remoteexecution.Digest{
+					Hash:      "8b1a9953c4611296a827abf8c47804d7",
+					SizeBytes: 5,
+				},
+			},
+			{
+				Digest: &remoteexecution.Digest{
+					Hash:      "6fc422233a40a75a1f028e11c3cd1140",
+					SizeBytes: 7,
+				},
+				Status: &status_pb.Status{
+					Code:    int32(codes.Internal),
+					Message: "Disk on fire",
+				},
+			},
+			{
+				Digest: &remoteexecution.Digest{
+					Hash:      "This is not a valid hash",
+					SizeBytes: 7,
+				},
+				Status: &status_pb.Status{
+					Code:    int32(codes.InvalidArgument),
+					Message: "Unknown digest hash length: 24 characters",
+				},
+			},
+		},
+	}, response)
+}
+
+func TestContentAddressableStorageServerGetTree(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	// Create an RPC server/client pair.
+	l := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	contentAddressableStorage := mock.NewMockBlobAccess(ctrl)
+	remoteexecution.RegisterContentAddressableStorageServer(server, grpcservers.NewContentAddressableStorageServer(contentAddressableStorage, 1<<16, 1))
+	go func() {
+		require.NoError(t, server.Serve(l))
+	}()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithDialer(func(string, time.Duration) (net.Conn, error) {
+		return l.Dial()
+	}), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer server.Stop()
+	defer conn.Close()
+	client := remoteexecution.NewContentAddressableStorageClient(conn)
+
+	// A tree that contains a root directory with two children, one
+	// of which has a child of its own. Both children of the root
+	// directory also share an identical grandchild.
+	rootDigest := digest.MustNewDigest("example", "4f0c1f3a1f0ab0c6d0e5b94dc1bd47f7", 200)
+	childADigest := digest.MustNewDigest("example", "0f6b7b8c4e56ac1e3aa6e2a2bd5dbd53", 100)
+	childBDigest := digest.MustNewDigest("example", "7e4f0c9b3cc4e1b6a40cbcdf7b6ad9a2", 100)
+	grandchildDigest := digest.MustNewDigest("example", "1b1f4d3a2e5c6d7e8f90a1b2c3d4e5f6", 0)
+	grandchild := &remoteexecution.Directory{
+		Files: []*remoteexecution.FileNode{
+			{Name: "file", Digest: &remoteexecution.Digest{Hash: "d41d8cd98f00b204e9800998ecf8427e", SizeBytes: 0}},
+		},
+	}
+	childA := &remoteexecution.Directory{
+		Directories: []*remoteexecution.DirectoryNode{
+			{Name: "grandchild", Digest: grandchildDigest.GetProto()},
+		},
+	}
+	childB := &remoteexecution.Directory{
+		Directories: []*remoteexecution.DirectoryNode{
+			{Name: "grandchild", Digest: grandchildDigest.GetProto()},
+		},
+		Symlinks: []*remoteexecution.SymlinkNode{
+			{Name: "symlink", Target: "target"},
+		},
+	}
+	root := &remoteexecution.Directory{
+		Directories: []*remoteexecution.DirectoryNode{
+			{Name: "a", Digest: childADigest.GetProto()},
+			{Name: "b", Digest: childBDigest.GetProto()},
+		},
+	}
+	expectGet := func(blobDigest digest.Digest, directory *remoteexecution.Directory) {
+		contentAddressableStorage.EXPECT().Get(gomock.Any(), blobDigest).
+			Return(buffer.NewProtoBufferFromProto(directory, buffer.UserProvided))
+	}
+	receiveAll := func(stream remoteexecution.ContentAddressableStorage_GetTreeClient) ([]*remoteexecution.Directory, string, error) {
+		var directories []*remoteexecution.Directory
+		var nextPageToken string
+		for {
+			response, err := stream.Recv()
+			if err == io.EOF {
+				return directories, nextPageToken, nil
+			} else if err != nil {
+				return nil, "", err
+			}
+			directories = append(directories, response.Directories...)
+			if response.NextPageToken != "" {
+				nextPageToken = response.NextPageToken
+			}
+		}
+	}
+
+	t.Run("InvalidRootDigest", func(t *testing.T) {
+		stream, err := client.GetTree(ctx, &remoteexecution.GetTreeRequest{
+			InstanceName: "example",
+			RootDigest:   &remoteexecution.Digest{Hash: "abc", SizeBytes: 12},
+		})
+		require.NoError(t, err)
+		_, _, err = receiveAll(stream)
+		testutil.RequireEqualStatus(t, status.Error(codes.InvalidArgument, "Invalid root digest: Unknown digest hash length: 3 characters"), err)
+	})
+
+	t.Run("InvalidPageToken", func(t *testing.T) {
+		stream, err := client.GetTree(ctx, &remoteexecution.GetTreeRequest{
+			InstanceName: "example",
+			RootDigest:   rootDigest.GetProto(),
+			PageToken:    "!!!",
+		})
+		require.NoError(t, err)
+		_, _, err = receiveAll(stream)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		// Missing directories should cause the request to fail
+		// with NOT_FOUND.
+		expectGet(rootDigest, root)
+		expectGet(childADigest, childA)
+		contentAddressableStorage.EXPECT().Get(gomock.Any(), childBDigest).
+			Return(buffer.NewBufferFromError(status.Error(codes.NotFound, "Object not found")))
+
+		stream, err := client.GetTree(ctx, &remoteexecution.GetTreeRequest{
+			InstanceName: "example",
+			RootDigest:   rootDigest.GetProto(),
+		})
+		require.NoError(t, err)
+		_, _, err = receiveAll(stream)
+		testutil.RequireEqualStatus(t, status.Error(codes.NotFound, "Failed to load directory \"7e4f0c9b3cc4e1b6a40cbcdf7b6ad9a2-100-example\": Object not found"), err)
+	})
+
+	t.Run("SinglePage", func(t *testing.T) {
+		// Without a page size, the full tree should be returned
+		// in breadth-first order. The shared grandchild should
+		// only be returned once.
+		expectGet(rootDigest, root)
+		expectGet(childADigest, childA)
+		expectGet(childBDigest, childB)
+		expectGet(grandchildDigest, grandchild)
+
+		stream, err := client.GetTree(ctx, &remoteexecution.GetTreeRequest{
+			InstanceName: "example",
+			RootDigest:   rootDigest.GetProto(),
+		})
+		require.NoError(t, err)
+		directories, nextPageToken, err := receiveAll(stream)
+		require.NoError(t, err)
+		testutil.RequireEqualProto(t, root, directories[0])
+		testutil.RequireEqualProto(t, childA, directories[1])
+		testutil.RequireEqualProto(t, childB, directories[2])
+		testutil.RequireEqualProto(t, grandchild, directories[3])
+		require.Len(t, directories, 4)
+		require.Empty(t, nextPageToken)
+	})
+
+	t.Run("MultiplePages", func(t *testing.T) {
+		// With a page size of two, the tree should be returned
+		// in two pages.
+		expectGet(rootDigest, root)
+		expectGet(childADigest, childA)
+
+		stream, err := client.GetTree(ctx, &remoteexecution.GetTreeRequest{
+			InstanceName: "example",
+			RootDigest:   rootDigest.GetProto(),
+			PageSize:     2,
+		})
+		require.NoError(t, err)
+		directories, nextPageToken, err := receiveAll(stream)
+		require.NoError(t, err)
+		require.Len(t, directories, 2)
+		testutil.RequireEqualProto(t, root, directories[0])
+		testutil.RequireEqualProto(t, childA, directories[1])
+		require.NotEmpty(t, nextPageToken)
+
+		expectGet(childBDigest, childB)
+		expectGet(grandchildDigest, grandchild)
+
+		stream, err = client.GetTree(ctx, &remoteexecution.GetTreeRequest{
+			InstanceName: "example",
+			RootDigest:   rootDigest.GetProto(),
+			PageSize:     2,
+			PageToken:    nextPageToken,
+		})
+		require.NoError(t, err)
+		directories, nextPageToken, err = receiveAll(stream)
+		require.NoError(t, err)
+		require.Len(t, directories, 2)
+		testutil.RequireEqualProto(t, childB, directories[0])
+		testutil.RequireEqualProto(t, grandchild, directories[1])
+		require.Empty(t, nextPageToken)
+	})
+}
+
+func TestContentAddressableStorageServerGetTreeWide(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	// Create an RPC server/client pair. Use a small maximum message
+	// size, so that page tokens are limited in size.
+	l := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	contentAddressableStorage := mock.NewMockBlobAccess(ctrl)
+	remoteexecution.RegisterContentAddressableStorageServer(server, grpcservers.NewContentAddressableStorageServer(contentAddressableStorage, 4000, 1))
+	go func() {
+		require.NoError(t, server.Serve(l))
+	}()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithDialer(func(string, time.Duration) (net.Conn, error) {
+		return l.Dial()
+	}), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer server.Stop()
+	defer conn.Close()
+	client := remoteexecution.NewContentAddressableStorageClient(conn)
+
+	// A tree that contains a root directory with five children,
+	// each having eight children of their own. The last child of
+	// the root directory also contains a copy of the first child,
+	// which should not be returned again.
+	directories := map[digest.Digest]*remoteexecution.Directory{}
+	newDigest := func(i int) digest.Digest {
+		return digest.MustNewDigest("example", fmt.Sprintf("%032x", i), int64(i))
+	}
+	root := &remoteexecution.Directory{}
+	rootDigest := newDigest(1)
+	directories[rootDigest] = root
+	for i := 0; i < 5; i++ {
+		child := &remoteexecution.Directory{}
+		childDigest := newDigest(100 + i)
+		directories[childDigest] = child
+		root.Directories = append(root.Directories, &remoteexecution.DirectoryNode{
+			Name:   fmt.Sprintf("child%d", i),
+			Digest: childDigest.GetProto(),
+		})
+		for j := 0; j < 8; j++ {
+			grandchild := &remoteexecution.Directory{
+				Symlinks: []*remoteexecution.SymlinkNode{
+					{Name: "symlink", Target: fmt.Sprintf("%d/%d", i, j)},
+				},
+			}
+			grandchildDigest := newDigest(1000 + 10*i + j)
+			directories[grandchildDigest] = grandchild
+			child.Directories = append(child.Directories, &remoteexecution.DirectoryNode{
+				Name:   fmt.Sprintf("grandchild%d", j),
+				Digest: grandchildDigest.GetProto(),
+			})
+		}
+	}
+	directories[newDigest(104)].Directories = append(
+		directories[newDigest(104)].Directories,
+		&remoteexecution.DirectoryNode{
+			Name:   "copy",
+			Digest: newDigest(100).GetProto(),
+		})
+	for blobDigest, directory := range directories {
+		contentAddressableStorage.EXPECT().Get(gomock.Any(), blobDigest).
+			Return(buffer.NewProtoBufferFromProto(directory, buffer.UserProvided)).
+			AnyTimes()
+	}
+
+	// Traversing the tree should require multiple pages, as the
+	// page token would otherwise become too large. Page tokens
+	// should always fit in a response. Every directory should be
+	// returned exactly once.
+	returnedDirectories := map[string]int{}
+	pageToken := ""
+	pages := 0
+	for {
+		stream, err := client.GetTree(ctx, &remoteexecution.GetTreeRequest{
+			InstanceName: "example",
+			RootDigest:   rootDigest.GetProto(),
+			PageToken:    pageToken,
+		})
+		require.NoError(t, err)
+		pageToken = ""
+		for {
+			response, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			for _, directory := range response.Directories {
+				returnedDirectories[directory.String()]++
+			}
+			if response.NextPageToken != "" {
+				require.LessOrEqual(t, len(response.NextPageToken), 4000)
+				pageToken = response.NextPageToken
+			}
+		}
+		pages++
+		if pageToken == "" {
+			break
+		}
+	}
+	require.Greater(t, pages, 2)
+	require.Len(t, returnedDirectories, len(directories))
+	for _, directory := range directories {
+		require.Equal(t, 1, returnedDirectories[directory.String()])
+	}
+}
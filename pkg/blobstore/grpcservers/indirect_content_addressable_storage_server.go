@@ -37,7 +37,7 @@ func (s *indirectContentAddressableStorageServer) FindMissingReferences(ctx cont
 
 	inDigests := digest.NewSetBuilder()
 	for _, partialDigest := range in.BlobDigests {
-		digest, err := instanceName.NewDigestFromProto(partialDigest)
+		digest, err := instanceName.NewDigestFromProtoAndDigestFunction(in.DigestFunction, partialDigest)
 		if err != nil {
 			return nil, err
 		}
@@ -64,7 +64,7 @@ func (s *indirectContentAddressableStorageServer) BatchUpdateReferences(ctx cont
 
 	responses := make([]*remoteexecution.BatchUpdateBlobsResponse_Response, 0, len(in.Requests))
 	for _, request := range in.Requests {
-		digest, err := instanceName.NewDigestFromProto(request.Digest)
+		digest, err := instanceName.NewDigestFromProtoAndDigestFunction(in.DigestFunction, request.Digest)
 		if err == nil {
 			err = s.blobAccess.Put(
 				ctx,
@@ -88,7 +88,7 @@ func (s *indirectContentAddressableStorageServer) GetReference(ctx context.Conte
 		return nil, util.StatusWrapf(err, "Invalid instance name %#v", in.InstanceName)
 	}
 
-	digest, err := instanceName.NewDigestFromProto(in.Digest)
+	digest, err := instanceName.NewDigestFromProtoAndDigestFunction(in.DigestFunction, in.Digest)
 	if err != nil {
 		return nil, err
 	}
@@ -36,7 +36,7 @@ func (s *initialSizeClassCacheServer) GetPreviousExecutionStats(ctx context.Cont
 		return nil, util.StatusWrapf(err, "Invalid instance name %#v", in.InstanceName)
 	}
 
-	digest, err := instanceName.NewDigestFromProto(in.ReducedActionDigest)
+	digest, err := instanceName.NewDigestFromProtoAndDigestFunction(in.DigestFunction, in.ReducedActionDigest)
 	if err != nil {
 		return nil, err
 	}
@@ -55,7 +55,7 @@ func (s *initialSizeClassCacheServer) UpdatePreviousExecutionStats(ctx context.C
 		return nil, util.StatusWrapf(err, "Invalid instance name %#v", in.InstanceName)
 	}
 
-	digest, err := instanceName.NewDigestFromProto(in.ReducedActionDigest)
+	digest, err := instanceName.NewDigestFromProtoAndDigestFunction(in.DigestFunction, in.ReducedActionDigest)
 	if err != nil {
 		return nil, err
 	}
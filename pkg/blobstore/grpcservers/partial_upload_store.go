@@ -0,0 +1,280 @@
+package grpcservers
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/buildbarn/bb-storage/pkg/clock"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/filesystem"
+	"github.com/buildbarn/bb-storage/pkg/filesystem/path"
+	"github.com/buildbarn/bb-storage/pkg/util"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PartialUploadStore is used by the ByteStream server to stage data of
+// uploads that have not been completed yet. This permits clients to
+// resume uploads that were interrupted, as opposed to restarting them
+// from the beginning.
+//
+// Partial uploads are identified by the UUID that is part of the
+// resource name provided to ByteStream.Write(), combined with the
+// digest of the object that is being uploaded.
+type PartialUploadStore interface {
+	// Open a partial upload, creating it if it does not exist.
+	// Only a single caller may have a partial upload opened at a
+	// time.
+	Open(uploadID string, digest digest.Digest) (PartialUpload, error)
+
+	// GetSizeBytes returns the amount of data that has been staged
+	// for a partial upload. Zero is returned if no data has been
+	// staged.
+	GetSizeBytes(uploadID string, digest digest.Digest) int64
+
+	// RemoveExpiredUploads removes data of partial uploads that
+	// have not been resumed within the retention period. This
+	// function should be called periodically, so that data of
+	// abandoned uploads does not linger indefinitely.
+	RemoveExpiredUploads()
+}
+
+// PartialUpload is a handle to data of an upload that has been staged
+// by PartialUploadStore.
+type PartialUpload interface {
+	GetSizeBytes() int64
+	ReadAt(p []byte, off int64) (int, error)
+	Append(p []byte) error
+	Truncate() error
+
+	// Release the partial upload, so that it may be opened once
+	// again. If discard is set, any staged data is removed.
+	// Otherwise, it is retained for a bounded amount of time.
+	Release(discard bool)
+}
+
+type partialUploadKey struct {
+	uploadID string
+	digest   digest.Digest
+}
+
+type directoryBackedPartialUploadStore struct {
+	directory        filesystem.Directory
+	clock            clock.Clock
+	retention        time.Duration
+	maximumSizeBytes int64
+
+	lock           sync.Mutex
+	uploads        map[partialUploadKey]*directoryBackedPartialUpload
+	nextFileName   uint64
+	totalSizeBytes int64
+}
+
+// NewDirectoryBackedPartialUploadStore creates a PartialUploadStore
+// that writes data of partial uploads into files stored in a local
+// directory. Staged data is removed if the upload is not resumed
+// within the provided retention period.
+//
+// The total amount of staged data is bounded by maximumSizeBytes.
+// When exceeded, data of uploads that are not in use is removed,
+// starting with the ones that were interrupted the longest time ago.
+// If that does not free up enough space, attempts to stage more data
+// fail with codes.ResourceExhausted.
+//
+// As the list of partial uploads is only tracked in memory, any files
+// already present in the directory are removed upon creation.
+func NewDirectoryBackedPartialUploadStore(directory filesystem.Directory, clock clock.Clock, retention time.Duration, maximumSizeBytes int64) (PartialUploadStore, error) {
+	if err := directory.RemoveAllChildren(); err != nil {
+		return nil, util.StatusWrap(err, "Failed to remove stale partial uploads")
+	}
+	return &directoryBackedPartialUploadStore{
+		directory:        directory,
+		clock:            clock,
+		retention:        retention,
+		maximumSizeBytes: maximumSizeBytes,
+		uploads:          map[partialUploadKey]*directoryBackedPartialUpload{},
+	}, nil
+}
+
+// removeExpiredUploads removes all partial uploads that have not been
+// resumed within the retention period.
+func (s *directoryBackedPartialUploadStore) removeExpiredUploads() {
+	now := s.clock.Now()
+	for key, upload := range s.uploads {
+		if !upload.inUse && !now.Before(upload.expiration) {
+			upload.remove()
+			delete(s.uploads, key)
+		}
+	}
+}
+
+// reserveSpace accounts for data that is about to be staged. If doing
+// so would cause the maximum size to be exceeded, data of uploads
+// that are not in use is removed first.
+func (s *directoryBackedPartialUploadStore) reserveSpace(sizeBytes int64) error {
+	if s.totalSizeBytes+sizeBytes > s.maximumSizeBytes {
+		s.removeExpiredUploads()
+
+		var idleUploads []*directoryBackedPartialUpload
+		for _, upload := range s.uploads {
+			if !upload.inUse {
+				idleUploads = append(idleUploads, upload)
+			}
+		}
+		sort.Slice(idleUploads, func(i, j int) bool {
+			return idleUploads[i].expiration.Before(idleUploads[j].expiration)
+		})
+		for _, upload := range idleUploads {
+			if s.totalSizeBytes+sizeBytes <= s.maximumSizeBytes {
+				break
+			}
+			upload.remove()
+			delete(s.uploads, upload.key)
+		}
+
+		if s.totalSizeBytes+sizeBytes > s.maximumSizeBytes {
+			return status.Errorf(codes.ResourceExhausted, "Staging %d more bytes of data would cause the maximum size of %d bytes to be exceeded", sizeBytes, s.maximumSizeBytes)
+		}
+	}
+	s.totalSizeBytes += sizeBytes
+	return nil
+}
+
+func (s *directoryBackedPartialUploadStore) RemoveExpiredUploads() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.removeExpiredUploads()
+}
+
+func (s *directoryBackedPartialUploadStore) Open(uploadID string, digest digest.Digest) (PartialUpload, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.removeExpiredUploads()
+	key := partialUploadKey{uploadID: uploadID, digest: digest}
+	upload, ok := s.uploads[key]
+	if ok {
+		if upload.inUse {
+			return nil, status.Errorf(codes.Aborted, "Upload %#v is already in progress", uploadID)
+		}
+		upload.inUse = true
+		return upload, nil
+	}
+
+	fileName := path.MustNewComponent(strconv.FormatUint(s.nextFileName, 10))
+	s.nextFileName++
+	f, err := s.directory.OpenReadWrite(fileName, filesystem.CreateExcl(0o600))
+	if err != nil {
+		return nil, util.StatusWrapfWithCode(err, codes.Internal, "Failed to create file for upload %#v", uploadID)
+	}
+	upload = &directoryBackedPartialUpload{
+		store:    s,
+		key:      key,
+		fileName: fileName,
+		file:     f,
+		inUse:    true,
+	}
+	s.uploads[key] = upload
+	return upload, nil
+}
+
+func (s *directoryBackedPartialUploadStore) GetSizeBytes(uploadID string, digest digest.Digest) int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.removeExpiredUploads()
+	if upload, ok := s.uploads[partialUploadKey{uploadID: uploadID, digest: digest}]; ok {
+		return upload.sizeBytes
+	}
+	return 0
+}
+
+type directoryBackedPartialUpload struct {
+	store    *directoryBackedPartialUploadStore
+	key      partialUploadKey
+	fileName path.Component
+	file     filesystem.FileReadWriter
+
+	// Fields protected by the store's lock.
+	sizeBytes  int64
+	inUse      bool
+	expiration time.Time
+}
+
+func (u *directoryBackedPartialUpload) GetSizeBytes() int64 {
+	u.store.lock.Lock()
+	defer u.store.lock.Unlock()
+
+	return u.sizeBytes
+}
+
+func (u *directoryBackedPartialUpload) ReadAt(p []byte, off int64) (int, error) {
+	n, err := u.file.ReadAt(p, off)
+	if err != nil && n != len(p) {
+		return n, util.StatusWrapWithCode(err, codes.Internal, "Failed to read staged data")
+	}
+	return n, nil
+}
+
+func (u *directoryBackedPartialUpload) Append(p []byte) error {
+	s := u.store
+	s.lock.Lock()
+	err := s.reserveSpace(int64(len(p)))
+	s.lock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	// The size is only modified by the owner of the upload, meaning
+	// it can be read without holding the lock.
+	if _, err := u.file.WriteAt(p, u.sizeBytes); err != nil {
+		s.lock.Lock()
+		s.totalSizeBytes -= int64(len(p))
+		s.lock.Unlock()
+		return util.StatusWrapWithCode(err, codes.Internal, "Failed to stage data")
+	}
+
+	s.lock.Lock()
+	u.sizeBytes += int64(len(p))
+	s.lock.Unlock()
+	return nil
+}
+
+func (u *directoryBackedPartialUpload) Truncate() error {
+	if err := u.file.Truncate(0); err != nil {
+		return util.StatusWrapWithCode(err, codes.Internal, "Failed to truncate staged data")
+	}
+
+	s := u.store
+	s.lock.Lock()
+	s.totalSizeBytes -= u.sizeBytes
+	u.sizeBytes = 0
+	s.lock.Unlock()
+	return nil
+}
+
+func (u *directoryBackedPartialUpload) Release(discard bool) {
+	s := u.store
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if discard || u.sizeBytes == 0 {
+		u.remove()
+		delete(s.uploads, u.key)
+		return
+	}
+	u.inUse = false
+	u.expiration = s.clock.Now().Add(s.retention)
+}
+
+// remove the file backing the partial upload. This function is
+// called while holding the store's lock.
+func (u *directoryBackedPartialUpload) remove() {
+	u.store.totalSizeBytes -= u.sizeBytes
+	u.file.Close()
+	u.store.directory.Remove(u.fileName)
+}
@@ -0,0 +1,123 @@
+package grpcservers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/buildbarn/bb-storage/internal/mock"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/grpcservers"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/filesystem"
+	"github.com/buildbarn/bb-storage/pkg/testutil"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDirectoryBackedPartialUploadStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	directory, err := filesystem.NewLocalDirectory(t.TempDir())
+	require.NoError(t, err)
+	defer directory.Close()
+	clock := mock.NewMockClock(ctrl)
+	partialUploadStore, err := grpcservers.NewDirectoryBackedPartialUploadStore(directory, clock, time.Minute, 1000)
+	require.NoError(t, err)
+
+	const uploadID = "7de747e0-ab6b-4d83-90cb-11989f84c473"
+	blobDigest := digest.MustNewDigest("", "581c1053f832a1c719fb6528a588ccfd", 14)
+
+	// Stage some data for an upload.
+	clock.EXPECT().Now().Return(time.Unix(1000, 0)).Times(2)
+	upload, err := partialUploadStore.Open(uploadID, blobDigest)
+	require.NoError(t, err)
+	require.NoError(t, upload.Append([]byte("Laputan")))
+	require.Equal(t, int64(7), upload.GetSizeBytes())
+
+	// Only a single caller may have the upload opened at a time.
+	_, err = partialUploadStore.Open(uploadID, blobDigest)
+	testutil.RequireEqualStatus(t, status.Error(codes.Aborted, "Upload \"7de747e0-ab6b-4d83-90cb-11989f84c473\" is already in progress"), err)
+
+	// Uploads are keyed by both the upload ID and the digest.
+	clock.EXPECT().Now().Return(time.Unix(1010, 0))
+	require.Equal(t, int64(0), partialUploadStore.GetSizeBytes(uploadID, digest.MustNewDigest("", "8b1a9953c4611296a827abf8c47804d7", 5)))
+
+	// Releasing the upload should cause its data to be retained.
+	clock.EXPECT().Now().Return(time.Unix(1020, 0)).Times(3)
+	upload.Release(false)
+	require.Equal(t, int64(7), partialUploadStore.GetSizeBytes(uploadID, blobDigest))
+	upload, err = partialUploadStore.Open(uploadID, blobDigest)
+	require.NoError(t, err)
+	data := make([]byte, 7)
+	n, err := upload.ReadAt(data, 0)
+	require.NoError(t, err)
+	require.Equal(t, 7, n)
+	require.Equal(t, []byte("Laputan"), data)
+
+	// Data should be discarded after the retention period.
+	clock.EXPECT().Now().Return(time.Unix(1030, 0))
+	upload.Release(false)
+	clock.EXPECT().Now().Return(time.Unix(1089, 0))
+	require.Equal(t, int64(7), partialUploadStore.GetSizeBytes(uploadID, blobDigest))
+	clock.EXPECT().Now().Return(time.Unix(1090, 0))
+	require.Equal(t, int64(0), partialUploadStore.GetSizeBytes(uploadID, blobDigest))
+}
+
+func TestDirectoryBackedPartialUploadStoreMaximumSize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	directory, err := filesystem.NewLocalDirectory(t.TempDir())
+	require.NoError(t, err)
+	defer directory.Close()
+	clock := mock.NewMockClock(ctrl)
+	partialUploadStore, err := grpcservers.NewDirectoryBackedPartialUploadStore(directory, clock, time.Minute, 10)
+	require.NoError(t, err)
+
+	digest1 := digest.MustNewDigest("", "8b1a9953c4611296a827abf8c47804d7", 5)
+	digest2 := digest.MustNewDigest("", "581c1053f832a1c719fb6528a588ccfd", 14)
+
+	// Stage some data for an upload that gets interrupted.
+	clock.EXPECT().Now().Return(time.Unix(1000, 0))
+	upload1, err := partialUploadStore.Open("upload1", digest1)
+	require.NoError(t, err)
+	require.NoError(t, upload1.Append([]byte("Hello")))
+	clock.EXPECT().Now().Return(time.Unix(1001, 0))
+	upload1.Release(false)
+
+	// Staging data for another upload would exceed the maximum
+	// size. This should cause the interrupted upload to be removed.
+	clock.EXPECT().Now().Return(time.Unix(1002, 0)).Times(2)
+	upload2, err := partialUploadStore.Open("upload2", digest2)
+	require.NoError(t, err)
+	require.NoError(t, upload2.Append([]byte("Laputan")))
+	clock.EXPECT().Now().Return(time.Unix(1003, 0))
+	require.Equal(t, int64(0), partialUploadStore.GetSizeBytes("upload1", digest1))
+
+	// Uploads that are in use cannot be removed. Attempting to
+	// stage more data should fail.
+	clock.EXPECT().Now().Return(time.Unix(1004, 0)).Times(2)
+	upload3, err := partialUploadStore.Open("upload3", digest1)
+	require.NoError(t, err)
+	testutil.RequireEqualStatus(
+		t,
+		status.Error(codes.ResourceExhausted, "Staging 5 more bytes of data would cause the maximum size of 10 bytes to be exceeded"),
+		upload3.Append([]byte("Hello")))
+	upload3.Release(true)
+
+	// Truncating an upload should free up space.
+	require.NoError(t, upload2.Truncate())
+	require.NoError(t, upload2.Append([]byte("Hello")))
+
+	// Expired uploads should be removed by RemoveExpiredUploads(),
+	// even if the store is not accessed otherwise.
+	clock.EXPECT().Now().Return(time.Unix(1005, 0))
+	upload2.Release(false)
+	clock.EXPECT().Now().Return(time.Unix(1064, 0))
+	partialUploadStore.RemoveExpiredUploads()
+	clock.EXPECT().Now().Return(time.Unix(1065, 0))
+	partialUploadStore.RemoveExpiredUploads()
+	clock.EXPECT().Now().Return(time.Unix(1066, 0))
+	require.Equal(t, int64(0), partialUploadStore.GetSizeBytes("upload2", digest2))
+}
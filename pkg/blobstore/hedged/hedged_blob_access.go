@@ -0,0 +1,193 @@
+package hedged
+
+import (
+	"context"
+	"sync"
+
+	"github.com/buildbarn/bb-storage/pkg/blobstore"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/buildbarn/bb-storage/pkg/clock"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	hedgedBlobAccessPrometheusMetrics sync.Once
+
+	hedgedBlobAccessGetRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "buildbarn",
+			Subsystem: "blobstore",
+			Name:      "hedged_blob_access_get_requests_total",
+			Help:      "Number of Get() requests processed, and which backend ended up serving them",
+		},
+		[]string{"outcome"})
+	hedgedBlobAccessGetRequestsBackendUnhedged = hedgedBlobAccessGetRequests.WithLabelValues("BackendUnhedged")
+	hedgedBlobAccessGetRequestsBackendHedged   = hedgedBlobAccessGetRequests.WithLabelValues("BackendHedged")
+	hedgedBlobAccessGetRequestsAlternate       = hedgedBlobAccessGetRequests.WithLabelValues("Alternate")
+	hedgedBlobAccessGetRequestsFailed          = hedgedBlobAccessGetRequests.WithLabelValues("Failed")
+)
+
+type hedgedBlobAccess struct {
+	blobstore.BlobAccess
+
+	alternate      blobstore.BlobAccess
+	clock          clock.Clock
+	latencyTracker LatencyTracker
+}
+
+// NewHedgedBlobAccess creates a decorator for BlobAccess that reduces
+// tail latency of Get() requests. If the backend does not start to
+// return data within the delay provided by the LatencyTracker, the same
+// request is sent to an alternate backend. The response that arrives
+// first is used, while the other request is cancelled. As an
+// exception, NOT_FOUND errors returned by the backend are always
+// returned, so that callers such as MirroredBlobAccess can repair the
+// inconsistency.
+//
+// Requests are only sent once the buffer returned by Get() is
+// accessed. The buffer returned by the backend that wins is passed on
+// to the caller, meaning that its data is only validated once.
+//
+// All other operations are only forwarded to the backend. This means
+// that this decorator is intended to be placed around the backends
+// of MirroredBlobAccess and ReadFallbackBlobAccess, using the other
+// backend as the alternate.
+func NewHedgedBlobAccess(backend, alternate blobstore.BlobAccess, clock clock.Clock, latencyTracker LatencyTracker) blobstore.BlobAccess {
+	hedgedBlobAccessPrometheusMetrics.Do(func() {
+		prometheus.MustRegister(hedgedBlobAccessGetRequests)
+	})
+
+	return &hedgedBlobAccess{
+		BlobAccess:     backend,
+		alternate:      alternate,
+		clock:          clock,
+		latencyTracker: latencyTracker,
+	}
+}
+
+// getResult is the outcome of a Get() request sent to one of the
+// backends. As buffers are lazily evaluated, the request is only
+// considered to be completed once the first chunk of data has been
+// returned.
+type getResult struct {
+	alternate bool
+	buffer    buffer.Buffer
+	err       error
+}
+
+func startGet(ctx context.Context, backend blobstore.BlobAccess, digest digest.Digest, alternate bool, results chan<- getResult) {
+	b, err := buffer.WaitForFirstChunk(backend.Get(ctx, digest))
+	results <- getResult{alternate: alternate, buffer: b, err: err}
+}
+
+// contextCancelingErrorHandler is attached to the buffer that is
+// returned by Get(), so that the context of the request that yielded
+// it is cancelled once the caller is done with it.
+type contextCancelingErrorHandler struct {
+	cancel context.CancelFunc
+}
+
+func (eh contextCancelingErrorHandler) OnError(err error) (buffer.Buffer, error) {
+	return nil, err
+}
+
+func (eh contextCancelingErrorHandler) Done() {
+	eh.cancel()
+}
+
+// discardResults discards the buffers of requests that completed after
+// a response has already been returned.
+func discardResults(results <-chan getResult, count int) {
+	go func() {
+		for i := 0; i < count; i++ {
+			if result := <-results; result.err == nil {
+				result.buffer.Discard()
+			}
+		}
+	}()
+}
+
+func (ba *hedgedBlobAccess) Get(ctx context.Context, digest digest.Digest) buffer.Buffer {
+	return buffer.NewLazyBuffer(func() buffer.Buffer {
+		return ba.getHedged(ctx, digest)
+	})
+}
+
+func (ba *hedgedBlobAccess) getHedged(ctx context.Context, digest digest.Digest) buffer.Buffer {
+	results := make(chan getResult, 2)
+	start := ba.clock.Now()
+	backendCtx, backendCancel := context.WithCancel(ctx)
+	go startGet(backendCtx, ba.BlobAccess, digest, false, results)
+
+	timer, timerChannel := ba.clock.NewTimer(ba.latencyTracker.GetHedgingDelay())
+	select {
+	case result := <-results:
+		// Backend responded in time.
+		timer.Stop()
+		ba.latencyTracker.RecordLatency(ba.clock.Now().Sub(start))
+		if result.err != nil {
+			backendCancel()
+			hedgedBlobAccessGetRequestsFailed.Inc()
+			return buffer.NewBufferFromError(result.err)
+		}
+		hedgedBlobAccessGetRequestsBackendUnhedged.Inc()
+		return buffer.WithErrorHandler(result.buffer, contextCancelingErrorHandler{cancel: backendCancel})
+	case <-timerChannel:
+	}
+
+	// Backend did not respond in time. Send the same request to the
+	// alternate backend, and use whichever succeeds first.
+	alternateCtx, alternateCancel := context.WithCancel(ctx)
+	go startGet(alternateCtx, ba.alternate, digest, true, results)
+
+	var backendErr error
+	for pending := 2; pending > 0; pending-- {
+		result := <-results
+		if !result.alternate {
+			ba.latencyTracker.RecordLatency(ba.clock.Now().Sub(start))
+		}
+		if result.err == nil {
+			discardResults(results, pending-1)
+			if result.alternate {
+				// As the request to the backend is
+				// cancelled, its latency remains unknown.
+				// Record the time spent waiting as a lower
+				// bound.
+				if pending == 2 {
+					ba.latencyTracker.RecordLatency(ba.clock.Now().Sub(start))
+				}
+				backendCancel()
+				hedgedBlobAccessGetRequestsAlternate.Inc()
+				return buffer.WithErrorHandler(result.buffer, contextCancelingErrorHandler{cancel: alternateCancel})
+			}
+			alternateCancel()
+			hedgedBlobAccessGetRequestsBackendHedged.Inc()
+			return buffer.WithErrorHandler(result.buffer, contextCancelingErrorHandler{cancel: backendCancel})
+		}
+		if !result.alternate {
+			backendErr = result.err
+			if status.Code(backendErr) == codes.NotFound {
+				// Don't let the alternate hide the fact
+				// that the object is absent from the
+				// backend.
+				discardResults(results, pending-1)
+				backendCancel()
+				alternateCancel()
+				hedgedBlobAccessGetRequestsFailed.Inc()
+				return buffer.NewBufferFromError(backendErr)
+			}
+		}
+	}
+
+	// Both requests failed. Return the error of the backend, so
+	// that the caller can act upon it in the same way as if no
+	// hedging took place.
+	backendCancel()
+	alternateCancel()
+	hedgedBlobAccessGetRequestsFailed.Inc()
+	return buffer.NewBufferFromError(backendErr)
+}
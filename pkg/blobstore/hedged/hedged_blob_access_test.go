@@ -0,0 +1,204 @@
+package hedged_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/buildbarn/bb-storage/internal/mock"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/hedged"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/testutil"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHedgedBlobAccessGet(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	backend := mock.NewMockBlobAccess(ctrl)
+	alternate := mock.NewMockBlobAccess(ctrl)
+	clock := mock.NewMockClock(ctrl)
+	blobAccess := hedged.NewHedgedBlobAccess(
+		backend,
+		alternate,
+		clock,
+		hedged.NewPercentileLatencyTracker(10, 0.95, time.Second, time.Second))
+
+	helloDigest := digest.MustNewDigest("example", "8b1a9953c4611296a827abf8c47804d7", 5)
+
+	t.Run("Lazy", func(t *testing.T) {
+		// Requests should only be sent once the buffer is
+		// accessed. Discarding the buffer without accessing it
+		// should not cause any requests to be sent.
+		blobAccess.Get(ctx, helloDigest).Discard()
+	})
+
+	t.Run("BackendInTime", func(t *testing.T) {
+		// If the backend responds before the hedging delay
+		// expires, the alternate backend is not contacted.
+		clock.EXPECT().Now().Return(time.Unix(1000, 0))
+		backend.EXPECT().Get(gomock.Any(), helloDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello")))
+		timer := mock.NewMockTimer(ctrl)
+		clock.EXPECT().NewTimer(time.Second).Return(timer, nil)
+		timer.EXPECT().Stop().Return(true)
+		clock.EXPECT().Now().Return(time.Unix(1000, 100))
+
+		data, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+	})
+
+	t.Run("BackendInTimeValidatedOnce", func(t *testing.T) {
+		// The buffer returned by the backend should be passed
+		// on to the caller, as opposed to being wrapped in a
+		// new buffer. This ensures data is only validated once.
+		clock.EXPECT().Now().Return(time.Unix(1000, 200))
+		chunkReader := mock.NewMockChunkReader(ctrl)
+		chunkReader.EXPECT().Read().Return([]byte("Hello"), nil)
+		chunkReader.EXPECT().Read().Return(nil, io.EOF)
+		chunkReader.EXPECT().Close()
+		dataIntegrityCallback := mock.NewMockDataIntegrityCallback(ctrl)
+		dataIntegrityCallback.EXPECT().Call(true)
+		backend.EXPECT().Get(gomock.Any(), helloDigest).
+			Return(buffer.NewCASBufferFromChunkReader(helloDigest, chunkReader, buffer.BackendProvided(dataIntegrityCallback.Call)))
+		timer := mock.NewMockTimer(ctrl)
+		clock.EXPECT().NewTimer(time.Second).Return(timer, nil)
+		timer.EXPECT().Stop().Return(true)
+		clock.EXPECT().Now().Return(time.Unix(1000, 300))
+
+		data, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+	})
+
+	t.Run("BackendFailureInTime", func(t *testing.T) {
+		// Errors returned by the backend before the hedging
+		// delay expires should be returned as is.
+		clock.EXPECT().Now().Return(time.Unix(1001, 0))
+		backend.EXPECT().Get(gomock.Any(), helloDigest).
+			Return(buffer.NewBufferFromError(status.Error(codes.NotFound, "Object not found")))
+		timer := mock.NewMockTimer(ctrl)
+		clock.EXPECT().NewTimer(time.Second).Return(timer, nil)
+		timer.EXPECT().Stop().Return(true)
+		clock.EXPECT().Now().Return(time.Unix(1001, 100))
+
+		_, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		testutil.RequireEqualStatus(t, status.Error(codes.NotFound, "Object not found"), err)
+	})
+
+	t.Run("AlternateFaster", func(t *testing.T) {
+		// If the backend is slow, the request should be sent to
+		// the alternate backend. The request to the backend
+		// should be cancelled once the alternate responds.
+		clock.EXPECT().Now().Return(time.Unix(1002, 0))
+		backendCancelled := make(chan struct{})
+		backend.EXPECT().Get(gomock.Any(), helloDigest).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest) buffer.Buffer {
+				<-ctx.Done()
+				close(backendCancelled)
+				return buffer.NewBufferFromError(status.Error(codes.Canceled, "Request cancelled"))
+			})
+		timerChannel := make(chan time.Time, 1)
+		timerChannel <- time.Unix(1003, 0)
+		clock.EXPECT().NewTimer(time.Second).Return(mock.NewMockTimer(ctrl), timerChannel)
+		alternate.EXPECT().Get(gomock.Any(), helloDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello")))
+		clock.EXPECT().Now().Return(time.Unix(1003, 0))
+
+		data, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+		<-backendCancelled
+	})
+
+	t.Run("BackendFasterAfterHedging", func(t *testing.T) {
+		// The backend may still respond before the alternate
+		// does, even if the hedging delay expired.
+		clock.EXPECT().Now().Return(time.Unix(1004, 0))
+		alternateCalled := make(chan struct{})
+		backend.EXPECT().Get(gomock.Any(), helloDigest).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest) buffer.Buffer {
+				<-alternateCalled
+				return buffer.NewValidatedBufferFromByteSlice([]byte("Hello"))
+			})
+		timerChannel := make(chan time.Time, 1)
+		timerChannel <- time.Unix(1005, 0)
+		clock.EXPECT().NewTimer(time.Second).Return(mock.NewMockTimer(ctrl), timerChannel)
+		alternateCancelled := make(chan struct{})
+		alternate.EXPECT().Get(gomock.Any(), helloDigest).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest) buffer.Buffer {
+				close(alternateCalled)
+				<-ctx.Done()
+				close(alternateCancelled)
+				return buffer.NewBufferFromError(status.Error(codes.Canceled, "Request cancelled"))
+			})
+		clock.EXPECT().Now().Return(time.Unix(1006, 0))
+
+		data, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+		<-alternateCancelled
+	})
+
+	t.Run("BackendNotFoundAfterHedging", func(t *testing.T) {
+		// If the backend reports that the object does not
+		// exist after the hedging delay expired, this should
+		// be returned to the caller, even if the alternate
+		// still has the object. This permits the caller to
+		// repair the inconsistency.
+		clock.EXPECT().Now().Return(time.Unix(1006, 500))
+		alternateCalled := make(chan struct{})
+		backend.EXPECT().Get(gomock.Any(), helloDigest).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest) buffer.Buffer {
+				<-alternateCalled
+				return buffer.NewBufferFromError(status.Error(codes.NotFound, "Object not found"))
+			})
+		timerChannel := make(chan time.Time, 1)
+		timerChannel <- time.Unix(1006, 600)
+		clock.EXPECT().NewTimer(time.Second).Return(mock.NewMockTimer(ctrl), timerChannel)
+		alternateCancelled := make(chan struct{})
+		alternate.EXPECT().Get(gomock.Any(), helloDigest).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest) buffer.Buffer {
+				close(alternateCalled)
+				<-ctx.Done()
+				close(alternateCancelled)
+				return buffer.NewValidatedBufferFromByteSlice([]byte("Hello"))
+			})
+		clock.EXPECT().Now().Return(time.Unix(1006, 700))
+
+		_, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		testutil.RequireEqualStatus(t, status.Error(codes.NotFound, "Object not found"), err)
+		<-alternateCancelled
+	})
+
+	t.Run("BothFailing", func(t *testing.T) {
+		// If both requests fail, the error of the backend
+		// should be returned.
+		clock.EXPECT().Now().Return(time.Unix(1007, 0))
+		alternateCalled := make(chan struct{})
+		backend.EXPECT().Get(gomock.Any(), helloDigest).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest) buffer.Buffer {
+				<-alternateCalled
+				return buffer.NewBufferFromError(status.Error(codes.NotFound, "Object not found"))
+			})
+		timerChannel := make(chan time.Time, 1)
+		timerChannel <- time.Unix(1008, 0)
+		clock.EXPECT().NewTimer(time.Second).Return(mock.NewMockTimer(ctrl), timerChannel)
+		alternate.EXPECT().Get(gomock.Any(), helloDigest).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest) buffer.Buffer {
+				close(alternateCalled)
+				return buffer.NewBufferFromError(status.Error(codes.Unavailable, "Server offline"))
+			})
+		clock.EXPECT().Now().Return(time.Unix(1009, 0))
+
+		_, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		testutil.RequireEqualStatus(t, status.Error(codes.NotFound, "Object not found"), err)
+	})
+}
@@ -0,0 +1,116 @@
+package hedged
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/buildbarn/bb-storage/pkg/atomic"
+)
+
+// LatencyTracker is used by HedgedBlobAccess to keep track of the
+// latency of requests sent to its backend, and to determine how long
+// to wait for a response before sending a hedged request.
+type LatencyTracker interface {
+	GetHedgingDelay() time.Duration
+	RecordLatency(latency time.Duration)
+}
+
+// latencyTrackerRecomputationsPerWindow controls how often
+// percentileLatencyTracker recomputes the hedging delay. Recomputing it
+// for every request would require sorting the full window each time.
+const latencyTrackerRecomputationsPerWindow = 16
+
+type percentileLatencyTracker struct {
+	percentile        float64
+	minimumDelay      time.Duration
+	maximumDelay      time.Duration
+	recomputeInterval int
+
+	currentDelay atomic.Int64
+
+	lock                  sync.Mutex
+	samples               []time.Duration
+	nextSample            int
+	windowFull            bool
+	samplesSinceRecompute int
+	sorted                []time.Duration
+	recomputing           bool
+}
+
+// NewPercentileLatencyTracker creates a LatencyTracker that computes
+// the hedging delay by taking a percentile (e.g., 0.95) of the
+// latencies of the most recent requests. The delay is clamped to the
+// provided range. Until the window of recent requests has been filled,
+// the maximum delay is used.
+//
+// To keep the overhead of recording latencies low, the delay is not
+// recomputed for every request. It is recomputed whenever a sixteenth
+// of the window has been replaced. Sorting of the window is performed
+// outside of the lock, so that it doesn't block other requests.
+func NewPercentileLatencyTracker(windowSize int, percentile float64, minimumDelay, maximumDelay time.Duration) LatencyTracker {
+	recomputeInterval := windowSize / latencyTrackerRecomputationsPerWindow
+	if recomputeInterval < 1 {
+		recomputeInterval = 1
+	}
+	lt := &percentileLatencyTracker{
+		percentile:        percentile,
+		minimumDelay:      minimumDelay,
+		maximumDelay:      maximumDelay,
+		recomputeInterval: recomputeInterval,
+
+		samples: make([]time.Duration, windowSize),
+		sorted:  make([]time.Duration, windowSize),
+	}
+	lt.currentDelay.Initialize(int64(maximumDelay))
+	return lt
+}
+
+func (lt *percentileLatencyTracker) GetHedgingDelay() time.Duration {
+	return time.Duration(lt.currentDelay.Load())
+}
+
+func (lt *percentileLatencyTracker) RecordLatency(latency time.Duration) {
+	lt.lock.Lock()
+	lt.samples[lt.nextSample] = latency
+	lt.nextSample++
+	if lt.nextSample == len(lt.samples) {
+		lt.nextSample = 0
+		lt.windowFull = true
+	}
+	lt.samplesSinceRecompute++
+	if !lt.windowFull || lt.samplesSinceRecompute < lt.recomputeInterval || lt.recomputing {
+		lt.lock.Unlock()
+		return
+	}
+
+	// Take a copy of the window, so that it can be sorted without
+	// holding the lock. Only a single goroutine may do this at a
+	// time, as the copy is reused.
+	lt.samplesSinceRecompute = 0
+	lt.recomputing = true
+	sorted := lt.sorted
+	copy(sorted, lt.samples)
+	lt.lock.Unlock()
+
+	// Recompute the delay based on the latencies in the window.
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	index := int(math.Ceil(lt.percentile*float64(len(sorted)))) - 1
+	if index < 0 {
+		index = 0
+	} else if index >= len(sorted) {
+		index = len(sorted) - 1
+	}
+	delay := sorted[index]
+	if delay < lt.minimumDelay {
+		delay = lt.minimumDelay
+	} else if delay > lt.maximumDelay {
+		delay = lt.maximumDelay
+	}
+	lt.currentDelay.Store(int64(delay))
+
+	lt.lock.Lock()
+	lt.recomputing = false
+	lt.lock.Unlock()
+}
@@ -0,0 +1,54 @@
+package hedged_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/buildbarn/bb-storage/pkg/blobstore/hedged"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentileLatencyTracker(t *testing.T) {
+	latencyTracker := hedged.NewPercentileLatencyTracker(4, 0.75, 10*time.Millisecond, time.Second)
+
+	// Until the window is filled, the maximum delay is used.
+	require.Equal(t, time.Second, latencyTracker.GetHedgingDelay())
+	latencyTracker.RecordLatency(40 * time.Millisecond)
+	latencyTracker.RecordLatency(20 * time.Millisecond)
+	latencyTracker.RecordLatency(30 * time.Millisecond)
+	require.Equal(t, time.Second, latencyTracker.GetHedgingDelay())
+
+	// Once the window is filled, the percentile is used.
+	latencyTracker.RecordLatency(100 * time.Millisecond)
+	require.Equal(t, 40*time.Millisecond, latencyTracker.GetHedgingDelay())
+
+	// Old samples should be evicted from the window.
+	latencyTracker.RecordLatency(25 * time.Millisecond)
+	require.Equal(t, 30*time.Millisecond, latencyTracker.GetHedgingDelay())
+
+	// The delay should be clamped to the configured range.
+	latencyTracker.RecordLatency(time.Millisecond)
+	latencyTracker.RecordLatency(time.Millisecond)
+	latencyTracker.RecordLatency(time.Millisecond)
+	latencyTracker.RecordLatency(time.Millisecond)
+	require.Equal(t, 10*time.Millisecond, latencyTracker.GetHedgingDelay())
+	latencyTracker.RecordLatency(time.Minute)
+	latencyTracker.RecordLatency(time.Minute)
+	require.Equal(t, time.Second, latencyTracker.GetHedgingDelay())
+}
+
+func TestPercentileLatencyTrackerRecomputeInterval(t *testing.T) {
+	// With a window of 32 samples, the delay should only be
+	// recomputed after every two samples.
+	latencyTracker := hedged.NewPercentileLatencyTracker(32, 1.0, time.Millisecond, time.Second)
+
+	for i := 0; i < 32; i++ {
+		latencyTracker.RecordLatency(10 * time.Millisecond)
+	}
+	require.Equal(t, 10*time.Millisecond, latencyTracker.GetHedgingDelay())
+
+	latencyTracker.RecordLatency(20 * time.Millisecond)
+	require.Equal(t, 10*time.Millisecond, latencyTracker.GetHedgingDelay())
+	latencyTracker.RecordLatency(10 * time.Millisecond)
+	require.Equal(t, 20*time.Millisecond, latencyTracker.GetHedgingDelay())
+}
@@ -1,15 +1,19 @@
 package local
 
 import (
+	"io"
+
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
 	"github.com/buildbarn/bb-storage/pkg/digest"
 	pb "github.com/buildbarn/bb-storage/pkg/proto/blobstore/local"
 )
 
 // Block of storage that contains a sequence of blobs. Buffers returned
-// by Get() must remain valid, even if Release() is called.
+// by Get() and readers returned by GetRawReader() must remain valid,
+// even if Release() is called.
 type Block interface {
 	Get(digest digest.Digest, offsetBytes, sizeBytes int64, dataIntegrityCallback buffer.DataIntegrityCallback) buffer.Buffer
+	GetRawReader(offsetBytes, sizeBytes int64) io.ReadCloser
 	Put(offsetBytes int64, b buffer.Buffer) error
 	Release()
 }
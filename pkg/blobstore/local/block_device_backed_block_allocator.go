@@ -35,6 +35,17 @@ var (
 			Name:      "block_device_backed_block_allocator_releases_total",
 			Help:      "Number of times blocks managed by BlockDeviceBackedBlockAllocator were released",
 		})
+	blockDeviceBackedBlockAllocatorDiscards = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "buildbarn",
+			Subsystem: "blobstore",
+			Name:      "block_device_backed_block_allocator_discards_total",
+			Help:      "Number of times blocks managed by BlockDeviceBackedBlockAllocator were discarded upon release",
+		},
+		[]string{"outcome"})
+	blockDeviceBackedBlockAllocatorDiscardsSucceeded   = blockDeviceBackedBlockAllocatorDiscards.WithLabelValues("Succeeded")
+	blockDeviceBackedBlockAllocatorDiscardsUnsupported = blockDeviceBackedBlockAllocatorDiscards.WithLabelValues("Unsupported")
+	blockDeviceBackedBlockAllocatorDiscardsFailed      = blockDeviceBackedBlockAllocatorDiscards.WithLabelValues("Failed")
 
 	blockDeviceBackedBlockAllocatorGetsStarted = prometheus.NewCounter(
 		prometheus.CounterOpts{
@@ -53,86 +64,227 @@ var (
 )
 
 type blockDeviceBackedBlockAllocator struct {
-	blockDevice       blockdevice.BlockDevice
-	readBufferFactory blobstore.ReadBufferFactory
-	sectorSizeBytes   int
-	blockSizeBytes    int64
+	blockDevices          []blockdevice.BlockDevice
+	readBufferFactory     blobstore.ReadBufferFactory
+	sectorSizeBytes       int
+	blockSizeBytes        int64
+	discardReleasedBlocks bool
+
+	lock             sync.Mutex
+	freeLocations    []blockDeviceBackedBlockLocation
+	pendingDiscards  []blockDeviceBackedBlockLocation
+	discarderRunning bool
+}
 
-	lock        sync.Mutex
-	freeOffsets []int64
+// blockDeviceBackedBlockLocation is the location of a block that is
+// managed by BlockDeviceBackedBlockAllocator. The offset is expressed
+// in sectors.
+type blockDeviceBackedBlockLocation struct {
+	blockDeviceIndex int
+	offset           int64
+}
+
+// GetBlockDeviceBackedBlockLayout computes how a given number of
+// blocks can be spread across a set of block devices of a given size.
+// Blocks are made as large as possible, while still allowing all
+// blocks to be stored. The size of blocks in sectors is returned, and
+// the number of blocks to store on each block device.
+//
+// If the block devices are of unequal size, larger block devices store
+// more blocks. The number of blocks stored on each block device is
+// balanced as much as possible.
+func GetBlockDeviceBackedBlockLayout(sectorCounts []int64, blockCount int) (int64, []int, error) {
+	getBlockCounts := func(blockSectorCount int64) ([]int, int) {
+		blockCounts := make([]int, 0, len(sectorCounts))
+		totalBlockCount := 0
+		for _, sectorCount := range sectorCounts {
+			deviceBlockCount := int(sectorCount / blockSectorCount)
+			blockCounts = append(blockCounts, deviceBlockCount)
+			totalBlockCount += deviceBlockCount
+		}
+		return blockCounts, totalBlockCount
+	}
+
+	// Perform a binary search to find the largest block size for
+	// which all blocks can be stored.
+	var maximumSectorCount int64
+	for _, sectorCount := range sectorCounts {
+		if maximumSectorCount < sectorCount {
+			maximumSectorCount = sectorCount
+		}
+	}
+	low, high := int64(0), maximumSectorCount
+	for low < high {
+		mid := low + (high-low+1)/2
+		if _, totalBlockCount := getBlockCounts(mid); totalBlockCount >= blockCount {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+	if low == 0 {
+		return 0, nil, status.Errorf(codes.InvalidArgument, "Block devices are too small to store %d blocks", blockCount)
+	}
+
+	// The block devices may be capable of storing more blocks than
+	// requested. Remove excessive blocks from the block devices
+	// storing the most blocks.
+	blockCounts, totalBlockCount := getBlockCounts(low)
+	for ; totalBlockCount > blockCount; totalBlockCount-- {
+		largest := 0
+		for i, deviceBlockCount := range blockCounts {
+			if deviceBlockCount > blockCounts[largest] {
+				largest = i
+			}
+		}
+		blockCounts[largest]--
+	}
+	return low, blockCounts, nil
 }
 
 // NewBlockDeviceBackedBlockAllocator implements a BlockAllocator that
 // can be used by implementations of BlockList to store data. Blocks
-// created by this allocator are backed by a single BlockDevice. The
-// BlockDevice is partitioned into equally sized blocks that are stored
-// consecutively.
+// created by this allocator are backed by one or more BlockDevices.
+// Every BlockDevice is partitioned into equally sized blocks that are
+// stored consecutively. The number of blocks to store on each
+// BlockDevice can be computed using GetBlockDeviceBackedBlockLayout().
+//
+// Blocks are initially handed out by increasing offset, alternating
+// between BlockDevices. This causes I/O to be spread across all of
+// them. Later on, the least recently released blocks are reused. This
+// adds wear leveling to the system.
 //
-// Blocks are initially handed out by increasing offset. Later on, the
-// least recently released blocks are reused. This adds wear leveling to
-// the system.
+// If discardReleasedBlocks is set, blocks that are released are
+// discarded, so that space is returned to the underlying storage. This
+// is useful when using thin-provisioned volumes or sparse files, as it
+// causes disk usage to correspond with the amount of live data.
+// Discarding is performed by a background goroutine, as it may be slow
+// and blocks tend to be released while locks are held. Blocks only
+// become available for reuse after they have been discarded.
 //
 // This implementation also ensures that writes against underlying
 // storage are all performed at sector boundaries and sizes. This
 // ensures that no unnecessary reads are performed.
-func NewBlockDeviceBackedBlockAllocator(blockDevice blockdevice.BlockDevice, readBufferFactory blobstore.ReadBufferFactory, sectorSizeBytes int, blockSectorCount int64, blockCount int) BlockAllocator {
+func NewBlockDeviceBackedBlockAllocator(blockDevices []blockdevice.BlockDevice, readBufferFactory blobstore.ReadBufferFactory, sectorSizeBytes int, blockSectorCount int64, blockCounts []int, discardReleasedBlocks bool) BlockAllocator {
 	blockDeviceBackedBlockAllocatorPrometheusMetrics.Do(func() {
 		prometheus.MustRegister(blockDeviceBackedBlockAllocatorAllocations)
 		prometheus.MustRegister(blockDeviceBackedBlockAllocatorReleases)
+		prometheus.MustRegister(blockDeviceBackedBlockAllocatorDiscards)
 
 		prometheus.MustRegister(blockDeviceBackedBlockAllocatorGetsStarted)
 		prometheus.MustRegister(blockDeviceBackedBlockAllocatorGetsCompleted)
 	})
 
 	pa := &blockDeviceBackedBlockAllocator{
-		blockDevice:       blockDevice,
-		readBufferFactory: readBufferFactory,
-		sectorSizeBytes:   sectorSizeBytes,
-		blockSizeBytes:    blockSectorCount * int64(sectorSizeBytes),
+		blockDevices:          blockDevices,
+		readBufferFactory:     readBufferFactory,
+		sectorSizeBytes:       sectorSizeBytes,
+		blockSizeBytes:        blockSectorCount * int64(sectorSizeBytes),
+		discardReleasedBlocks: discardReleasedBlocks,
 	}
-	for i := 0; i < blockCount; i++ {
-		pa.freeOffsets = append(pa.freeOffsets, int64(i)*blockSectorCount)
+	for i, added := 0, true; added; i++ {
+		added = false
+		for blockDeviceIndex, blockCount := range blockCounts {
+			if i < blockCount {
+				pa.freeLocations = append(pa.freeLocations, blockDeviceBackedBlockLocation{
+					blockDeviceIndex: blockDeviceIndex,
+					offset:           int64(i) * blockSectorCount,
+				})
+				added = true
+			}
+		}
 	}
 	return pa
 }
 
-func (pa *blockDeviceBackedBlockAllocator) newBlockObject(offset int64) Block {
+func (pa *blockDeviceBackedBlockAllocator) newBlockObject(location blockDeviceBackedBlockLocation) Block {
 	blockDeviceBackedBlockAllocatorAllocations.Inc()
 	pb := &blockDeviceBackedBlock{
 		blockAllocator: pa,
-		offset:         offset,
+		blockDevice:    pa.blockDevices[location.blockDeviceIndex],
+		location:       location,
 	}
 	pb.usecount.Initialize(1)
 	return pb
 }
 
+func (pa *blockDeviceBackedBlockAllocator) getBlockLocationMessage(location blockDeviceBackedBlockLocation) *pb.BlockLocation {
+	return &pb.BlockLocation{
+		OffsetBytes:      location.offset * int64(pa.sectorSizeBytes),
+		SizeBytes:        pa.blockSizeBytes,
+		BlockDeviceIndex: uint32(location.blockDeviceIndex),
+	}
+}
+
+// releaseLocation makes the location of a block that is no longer in
+// use available for reuse. If discarding is enabled, the location is
+// first handed to a background goroutine that discards its contents.
+func (pa *blockDeviceBackedBlockAllocator) releaseLocation(location blockDeviceBackedBlockLocation) {
+	pa.lock.Lock()
+	defer pa.lock.Unlock()
+
+	if !pa.discardReleasedBlocks {
+		pa.freeLocations = append(pa.freeLocations, location)
+		blockDeviceBackedBlockAllocatorReleases.Inc()
+		return
+	}
+
+	// Discard blocks in the order in which they were released, so
+	// that the order in which they are reused is preserved.
+	pa.pendingDiscards = append(pa.pendingDiscards, location)
+	if !pa.discarderRunning {
+		pa.discarderRunning = true
+		go pa.processPendingDiscards()
+	}
+}
+
+// processPendingDiscards discards the contents of blocks that have
+// been released, until no more blocks are pending. Discarding is only
+// an optimization, so failures are ignored.
+func (pa *blockDeviceBackedBlockAllocator) processPendingDiscards() {
+	pa.lock.Lock()
+	for len(pa.pendingDiscards) > 0 {
+		location := pa.pendingDiscards[0]
+		pa.pendingDiscards = pa.pendingDiscards[1:]
+		pa.lock.Unlock()
+
+		if err := pa.blockDevices[location.blockDeviceIndex].Discard(location.offset*int64(pa.sectorSizeBytes), pa.blockSizeBytes); err == nil {
+			blockDeviceBackedBlockAllocatorDiscardsSucceeded.Inc()
+		} else if status.Code(err) == codes.Unimplemented {
+			blockDeviceBackedBlockAllocatorDiscardsUnsupported.Inc()
+		} else {
+			blockDeviceBackedBlockAllocatorDiscardsFailed.Inc()
+		}
+
+		pa.lock.Lock()
+		pa.freeLocations = append(pa.freeLocations, location)
+		blockDeviceBackedBlockAllocatorReleases.Inc()
+	}
+	pa.discarderRunning = false
+	pa.lock.Unlock()
+}
+
 func (pa *blockDeviceBackedBlockAllocator) NewBlock() (Block, *pb.BlockLocation, error) {
 	pa.lock.Lock()
 	defer pa.lock.Unlock()
 
-	if len(pa.freeOffsets) == 0 {
+	if len(pa.freeLocations) == 0 {
 		return nil, nil, status.Error(codes.Unavailable, "No unused blocks available")
 	}
-	offset := pa.freeOffsets[0]
-	pa.freeOffsets = pa.freeOffsets[1:]
-	return pa.newBlockObject(offset), &pb.BlockLocation{
-		OffsetBytes: offset * int64(pa.sectorSizeBytes),
-		SizeBytes:   pa.blockSizeBytes,
-	}, nil
+	location := pa.freeLocations[0]
+	pa.freeLocations = pa.freeLocations[1:]
+	return pa.newBlockObject(location), pa.getBlockLocationMessage(location), nil
 }
 
 func (pa *blockDeviceBackedBlockAllocator) NewBlockAtLocation(location *pb.BlockLocation) (Block, bool) {
 	pa.lock.Lock()
 	defer pa.lock.Unlock()
 
-	for i, offset := range pa.freeOffsets {
-		if proto.Equal(&pb.BlockLocation{
-			OffsetBytes: offset * int64(pa.sectorSizeBytes),
-			SizeBytes:   pa.blockSizeBytes,
-		}, location) {
-			pa.freeOffsets[i] = pa.freeOffsets[len(pa.freeOffsets)-1]
-			pa.freeOffsets = pa.freeOffsets[:len(pa.freeOffsets)-1]
-			return pa.newBlockObject(offset), true
+	for i, freeLocation := range pa.freeLocations {
+		if proto.Equal(pa.getBlockLocationMessage(freeLocation), location) {
+			pa.freeLocations[i] = pa.freeLocations[len(pa.freeLocations)-1]
+			pa.freeLocations = pa.freeLocations[:len(pa.freeLocations)-1]
+			return pa.newBlockObject(freeLocation), true
 		}
 	}
 	return nil, false
@@ -141,7 +293,8 @@ func (pa *blockDeviceBackedBlockAllocator) NewBlockAtLocation(location *pb.Block
 type blockDeviceBackedBlock struct {
 	usecount       atomic.Int64
 	blockAllocator *blockDeviceBackedBlockAllocator
-	offset         int64
+	blockDevice    blockdevice.BlockDevice
+	location       blockDeviceBackedBlockLocation
 }
 
 func (pb *blockDeviceBackedBlock) Release() {
@@ -150,33 +303,39 @@ func (pb *blockDeviceBackedBlock) Release() {
 	} else if c == 0 {
 		// Block has no remaining consumers. Allow the region in
 		// storage to be reused for new data.
-		pa := pb.blockAllocator
-		pa.lock.Lock()
-		pa.freeOffsets = append(pa.freeOffsets, pb.offset)
-		pa.lock.Unlock()
-		blockDeviceBackedBlockAllocatorReleases.Inc()
+		pb.blockAllocator.releaseLocation(pb.location)
 	}
 }
 
-func (pb *blockDeviceBackedBlock) Get(digest digest.Digest, offsetBytes, sizeBytes int64, dataIntegrityCallback buffer.DataIntegrityCallback) buffer.Buffer {
+// newReader creates a reader for a region of the block. The reader
+// holds a reference to the block, which is dropped upon closure.
+func (pb *blockDeviceBackedBlock) newReader(offsetBytes, sizeBytes int64) *blockDeviceBackedBlockReader {
 	if c := pb.usecount.Add(1); c <= 1 {
 		panic(fmt.Sprintf("Get(): Block has invalid reference count %d", c))
 	}
 	blockDeviceBackedBlockAllocatorGetsStarted.Inc()
 
+	return &blockDeviceBackedBlockReader{
+		SectionReader: *io.NewSectionReader(
+			pb.blockDevice,
+			pb.location.offset*int64(pb.blockAllocator.sectorSizeBytes)+offsetBytes,
+			sizeBytes),
+		block: pb,
+	}
+}
+
+func (pb *blockDeviceBackedBlock) Get(digest digest.Digest, offsetBytes, sizeBytes int64, dataIntegrityCallback buffer.DataIntegrityCallback) buffer.Buffer {
 	return pb.blockAllocator.readBufferFactory.NewBufferFromReaderAt(
 		digest,
-		&blockDeviceBackedBlockReader{
-			SectionReader: *io.NewSectionReader(
-				pb.blockAllocator.blockDevice,
-				pb.offset*int64(pb.blockAllocator.sectorSizeBytes)+offsetBytes,
-				sizeBytes),
-			block: pb,
-		},
+		pb.newReader(offsetBytes, sizeBytes),
 		sizeBytes,
 		dataIntegrityCallback)
 }
 
+func (pb *blockDeviceBackedBlock) GetRawReader(offsetBytes, sizeBytes int64) io.ReadCloser {
+	return pb.newReader(offsetBytes, sizeBytes)
+}
+
 func (pb *blockDeviceBackedBlock) Put(offsetBytes int64, b buffer.Buffer) error {
 	if pb.usecount.Load() <= 0 {
 		panic("Attempted to store buffer in unused block")
@@ -188,9 +347,9 @@ func (pb *blockDeviceBackedBlock) Put(offsetBytes int64, b buffer.Buffer) error
 	}
 
 	w := &blockDeviceBackedBlockWriter{
-		w:             pb.blockAllocator.blockDevice,
+		w:             pb.blockDevice,
 		partialSector: make([]byte, 0, pb.blockAllocator.sectorSizeBytes),
-		offset:        pb.offset + offsetBytes/int64(sectorSizeBytes),
+		offset:        pb.location.offset + offsetBytes/int64(sectorSizeBytes),
 	}
 
 	if err := b.IntoWriter(w); err != nil {
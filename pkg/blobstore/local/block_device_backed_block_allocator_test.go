@@ -2,11 +2,13 @@ package local_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/buildbarn/bb-storage/internal/mock"
 	"github.com/buildbarn/bb-storage/pkg/blobstore"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/local"
+	"github.com/buildbarn/bb-storage/pkg/blockdevice"
 	"github.com/buildbarn/bb-storage/pkg/digest"
 	pb "github.com/buildbarn/bb-storage/pkg/proto/blobstore/local"
 	"github.com/buildbarn/bb-storage/pkg/testutil"
@@ -21,7 +23,7 @@ func TestBlockDeviceBackedBlockAllocator(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
 	blockDevice := mock.NewMockBlockDevice(ctrl)
-	pa := local.NewBlockDeviceBackedBlockAllocator(blockDevice, blobstore.CASReadBufferFactory, 1, 100, 10)
+	pa := local.NewBlockDeviceBackedBlockAllocator([]blockdevice.BlockDevice{blockDevice}, blobstore.CASReadBufferFactory, 1, 100, []int{10}, false)
 
 	// Based on the size of the allocator, it should be possible to
 	// create ten blocks.
@@ -126,3 +128,154 @@ func TestBlockDeviceBackedBlockAllocator(t *testing.T) {
 	blockDevice.EXPECT().WriteAt([]byte("Hello"), int64(741)).Return(5, nil)
 	require.NoError(t, blocks[7].Put(41, buffer.NewValidatedBufferFromByteSlice([]byte("Hello"))))
 }
+
+func TestBlockDeviceBackedBlockAllocatorMultipleBlockDevices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	blockDevice0 := mock.NewMockBlockDevice(ctrl)
+	blockDevice1 := mock.NewMockBlockDevice(ctrl)
+	pa := local.NewBlockDeviceBackedBlockAllocator(
+		[]blockdevice.BlockDevice{blockDevice0, blockDevice1},
+		blobstore.CASReadBufferFactory,
+		1,
+		100,
+		[]int{2, 1},
+		false)
+
+	// Blocks should initially be handed out by alternating
+	// between block devices.
+	var blocks []local.Block
+	for _, expectedLocation := range []*pb.BlockLocation{
+		{OffsetBytes: 0, SizeBytes: 100, BlockDeviceIndex: 0},
+		{OffsetBytes: 0, SizeBytes: 100, BlockDeviceIndex: 1},
+		{OffsetBytes: 100, SizeBytes: 100, BlockDeviceIndex: 0},
+	} {
+		block, location, err := pa.NewBlock()
+		require.NoError(t, err)
+		testutil.RequireEqualProto(t, expectedLocation, location)
+		blocks = append(blocks, block)
+	}
+
+	_, _, err := pa.NewBlock()
+	require.Equal(t, err, status.Error(codes.Unavailable, "No unused blocks available"))
+
+	// Writes should be sent to the block device backing the block.
+	blockDevice1.EXPECT().WriteAt([]byte("Hello"), int64(17)).Return(5, nil)
+	require.NoError(t, blocks[1].Put(17, buffer.NewValidatedBufferFromByteSlice([]byte("Hello"))))
+	blockDevice0.EXPECT().WriteAt([]byte("Hello"), int64(117)).Return(5, nil)
+	require.NoError(t, blocks[2].Put(17, buffer.NewValidatedBufferFromByteSlice([]byte("Hello"))))
+
+	// NewBlockAtLocation() should take the block device index into
+	// account.
+	blocks[1].Release()
+	_, found := pa.NewBlockAtLocation(&pb.BlockLocation{
+		OffsetBytes: 0,
+		SizeBytes:   100,
+	})
+	require.False(t, found)
+	_, found = pa.NewBlockAtLocation(&pb.BlockLocation{
+		OffsetBytes:      0,
+		SizeBytes:        100,
+		BlockDeviceIndex: 1,
+	})
+	require.True(t, found)
+}
+
+func TestBlockDeviceBackedBlockAllocatorDiscard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	blockDevice := mock.NewMockBlockDevice(ctrl)
+	pa := local.NewBlockDeviceBackedBlockAllocator([]blockdevice.BlockDevice{blockDevice}, blobstore.CASReadBufferFactory, 1, 100, []int{5}, true)
+
+	var blocks []local.Block
+	for i := 0; i < 5; i++ {
+		block, _, err := pa.NewBlock()
+		require.NoError(t, err)
+		blocks = append(blocks, block)
+	}
+
+	// Releasing blocks should not block on discarding their
+	// contents, as blocks tend to be released while locks are
+	// held. Blocks should only become available for reuse after
+	// they have been discarded. Failures to discard blocks should
+	// not prevent them from being reused, as discarding is merely
+	// an optimization.
+	discardStarted := make(chan struct{})
+	discardRelease := make(chan struct{})
+	gomock.InOrder(
+		blockDevice.EXPECT().Discard(int64(300), int64(100)).DoAndReturn(
+			func(off, size int64) error {
+				close(discardStarted)
+				<-discardRelease
+				return nil
+			}),
+		blockDevice.EXPECT().Discard(int64(100), int64(100)).
+			Return(status.Error(codes.Unimplemented, "Discarding regions of devices is not supported on this platform")),
+		blockDevice.EXPECT().Discard(int64(400), int64(100)).
+			Return(status.Error(codes.Internal, "Disk on fire")))
+	blocks[3].Release()
+	<-discardStarted
+	blocks[1].Release()
+	blocks[4].Release()
+
+	_, _, err := pa.NewBlock()
+	require.Equal(t, err, status.Error(codes.Unavailable, "No unused blocks available"))
+	_, found := pa.NewBlockAtLocation(&pb.BlockLocation{
+		OffsetBytes: 300,
+		SizeBytes:   100,
+	})
+	require.False(t, found)
+
+	// Once discarding has completed, the blocks should be reused
+	// in the order in which they were released.
+	close(discardRelease)
+	for _, i := range []int{3, 1, 4} {
+		var location *pb.BlockLocation
+		require.Eventually(t, func() bool {
+			blocks[i], location, err = pa.NewBlock()
+			return err == nil
+		}, time.Minute, time.Millisecond)
+		testutil.RequireEqualProto(t, &pb.BlockLocation{
+			OffsetBytes: int64(i) * 100,
+			SizeBytes:   100,
+		}, location)
+	}
+}
+
+func TestGetBlockDeviceBackedBlockLayout(t *testing.T) {
+	t.Run("SingleBlockDevice", func(t *testing.T) {
+		blockSectorCount, blockCounts, err := local.GetBlockDeviceBackedBlockLayout([]int64{1005}, 10)
+		require.NoError(t, err)
+		require.Equal(t, int64(100), blockSectorCount)
+		require.Equal(t, []int{10}, blockCounts)
+	})
+
+	t.Run("EquallySized", func(t *testing.T) {
+		blockSectorCount, blockCounts, err := local.GetBlockDeviceBackedBlockLayout([]int64{1000, 1000}, 10)
+		require.NoError(t, err)
+		require.Equal(t, int64(200), blockSectorCount)
+		require.Equal(t, []int{5, 5}, blockCounts)
+	})
+
+	t.Run("DifferentlySized", func(t *testing.T) {
+		// Larger block devices should store more blocks.
+		blockSectorCount, blockCounts, err := local.GetBlockDeviceBackedBlockLayout([]int64{1000, 500}, 10)
+		require.NoError(t, err)
+		require.Equal(t, int64(142), blockSectorCount)
+		require.Equal(t, []int{7, 3}, blockCounts)
+	})
+
+	t.Run("ExcessiveBlocks", func(t *testing.T) {
+		// Both block devices are capable of storing two blocks.
+		// Only three blocks should be created.
+		blockSectorCount, blockCounts, err := local.GetBlockDeviceBackedBlockLayout([]int64{1000, 1000}, 3)
+		require.NoError(t, err)
+		require.Equal(t, int64(500), blockSectorCount)
+		require.Equal(t, []int{1, 2}, blockCounts)
+	})
+
+	t.Run("TooSmall", func(t *testing.T) {
+		_, _, err := local.GetBlockDeviceBackedBlockLayout([]int64{5, 4}, 10)
+		require.Equal(t, status.Error(codes.InvalidArgument, "Block devices are too small to store 10 blocks"), err)
+	})
+}
@@ -1,6 +1,8 @@
 package local
 
 import (
+	"io"
+
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
 	"github.com/buildbarn/bb-storage/pkg/digest"
 )
@@ -27,8 +29,8 @@ type BlockListPutFinalizer func() (int64, error)
 // space in the block is consumed.
 //
 // BlockList is only partially thread-safe. The BlockReferenceResolver
-// methods and BlockList.Get() can be invoked in parallel (e.g., under a
-// read lock), while BlockList.PopFront(), BlockList.PushBack(),
+// methods, BlockList.Get() and BlockList.GetRawReader() can be invoked
+// in parallel (e.g., under a read lock), while BlockList.PopFront(), BlockList.PushBack(),
 // BlockList.HasSpace(), BlockList.Put() and BlockListPutFinalizer must
 // run exclusively (e.g., under a write lock). BlockListPutWriter is
 // safe to call without holding any locks.
@@ -45,6 +47,12 @@ type BlockList interface {
 	// Get a blob from a given block in the BlockList.
 	Get(blockIndex int, digest digest.Digest, offsetBytes, sizeBytes int64, dataIntegrityCallback buffer.DataIntegrityCallback) buffer.Buffer
 
+	// GetRawReader returns a reader for the contents of a blob in a
+	// given block in the BlockList. Unlike Get(), the data is not
+	// validated against a digest. This can be used to inspect blobs
+	// whose digest is not known.
+	GetRawReader(blockIndex int, offsetBytes, sizeBytes int64) io.ReadCloser
+
 	// HasSpace returns whether a given block in the BlockList is
 	// capable of storing an additional blob of a given size.
 	HasSpace(blockIndex int, sizeBytes int64) bool
@@ -2,6 +2,8 @@ package local
 
 import (
 	"bytes"
+	"io"
+	"io/ioutil"
 
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
 	"github.com/buildbarn/bb-storage/pkg/digest"
@@ -41,6 +43,10 @@ func (ib inMemoryBlock) Get(digest digest.Digest, offsetBytes, sizeBytes int64,
 	return buffer.NewValidatedBufferFromByteSlice(ib.data[offsetBytes : offsetBytes+sizeBytes])
 }
 
+func (ib inMemoryBlock) GetRawReader(offsetBytes, sizeBytes int64) io.ReadCloser {
+	return ioutil.NopCloser(bytes.NewReader(ib.data[offsetBytes : offsetBytes+sizeBytes]))
+}
+
 func (ib inMemoryBlock) Put(offsetBytes int64, b buffer.Buffer) error {
 	return b.IntoWriter(bytes.NewBuffer(ib.data[offsetBytes:offsetBytes]))
 }
@@ -0,0 +1,38 @@
+package local
+
+import (
+	"github.com/buildbarn/bb-storage/pkg/util"
+)
+
+// MigrateKeyLocationMap copies all entries contained in a
+// LocationRecordArray that was used by a HashingKeyLocationMap into
+// another KeyLocationMap. This can be used to change the size of the
+// key-location map, as entries are rehashed in the process.
+//
+// Only entries that are still reachable are migrated. Entries that are
+// invalid, or that are not stored at the index at which
+// HashingKeyLocationMap expects them, are skipped. The number of
+// entries that were migrated is returned.
+//
+// Both LocationRecordArrays must use the same BlockReferenceResolver.
+// The source and the destination must not share any storage, as
+// entries would otherwise be overwritten while being migrated.
+func MigrateKeyLocationMap(source LocationRecordArray, sourceRecordsCount int, hashInitialization uint64, destination KeyLocationMap) (int, error) {
+	migrated := 0
+	for index := 0; index < sourceRecordsCount; index++ {
+		record, err := source.Get(index)
+		if err == ErrLocationRecordInvalid {
+			continue
+		} else if err != nil {
+			return migrated, util.StatusWrapf(err, "Failed to read location record at index %d", index)
+		}
+		if int(record.RecordKey.Hash(hashInitialization)%uint64(sourceRecordsCount)) != index {
+			continue
+		}
+		if err := destination.Put(record.RecordKey.Key, record.Location); err != nil {
+			return migrated, util.StatusWrapf(err, "Failed to migrate location record at index %d", index)
+		}
+		migrated++
+	}
+	return migrated, nil
+}
@@ -0,0 +1,55 @@
+package local_test
+
+import (
+	"testing"
+
+	"github.com/buildbarn/bb-storage/internal/mock"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/local"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMigrateKeyLocationMap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	source := mock.NewMockLocationRecordArray(ctrl)
+	destination := mock.NewMockKeyLocationMap(ctrl)
+
+	const recordsCount = 3
+	helloKey := local.NewKeyFromString(digest.MustNewDigest("", "8b1a9953c4611296a827abf8c47804d7", 5).GetKey(digest.KeyWithoutInstance))
+	helloRecord := local.LocationRecord{
+		RecordKey: local.LocationRecordKey{Key: helloKey, Attempt: 7},
+		Location:  local.Location{BlockIndex: 4, OffsetBytes: 100, SizeBytes: 5},
+	}
+	helloSlot := int(helloRecord.RecordKey.Hash(12345) % recordsCount)
+
+	t.Run("Success", func(t *testing.T) {
+		// The entry is stored both at the right index and at the
+		// index after that. Only the former should be migrated.
+		// The attempt counter should not be preserved, as the
+		// entry is rehashed.
+		for index := 0; index < recordsCount; index++ {
+			if index == helloSlot || index == (helloSlot+1)%recordsCount {
+				source.EXPECT().Get(index).Return(helloRecord, nil)
+			} else {
+				source.EXPECT().Get(index).Return(local.LocationRecord{}, local.ErrLocationRecordInvalid)
+			}
+		}
+		destination.EXPECT().Put(helloKey, helloRecord.Location)
+
+		migrated, err := local.MigrateKeyLocationMap(source, recordsCount, 12345, destination)
+		require.NoError(t, err)
+		require.Equal(t, 1, migrated)
+	})
+
+	t.Run("IOError", func(t *testing.T) {
+		source.EXPECT().Get(0).Return(local.LocationRecord{}, status.Error(codes.Internal, "Disk on fire"))
+
+		_, err := local.MigrateKeyLocationMap(source, recordsCount, 12345, destination)
+		require.Equal(t, status.Error(codes.Internal, "Failed to read location record at index 0: Disk on fire"), err)
+	})
+}
@@ -1,6 +1,8 @@
 package local
 
 import (
+	"io"
+
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
 	"github.com/buildbarn/bb-storage/pkg/digest"
 )
@@ -40,9 +42,9 @@ type LocationBlobPutFinalizer func() (Location, error)
 // remain valid over time), all Locations provided to Get() must be
 // validated using a BlockReferenceResolver.
 //
-// LocationBlobMap is only partially thread-safe. LocationBlobMap.Get()
-// and LocationBlobGetter can be invoked in parallel (e.g., under a read
-// lock), while LocationBlobMap.Put() and LocationBlobPutFinalizer must
+// LocationBlobMap is only partially thread-safe. LocationBlobMap.Get(),
+// LocationBlobMap.GetRawReader() and LocationBlobGetter can be invoked
+// in parallel (e.g., under a read lock), while LocationBlobMap.Put() and LocationBlobPutFinalizer must
 // run exclusively (e.g., under a write lock). LocationBlobPutWriter is
 // safe to call without holding any locks.
 type LocationBlobMap interface {
@@ -61,6 +63,19 @@ type LocationBlobMap interface {
 	// LocationBlobGetters is invoked.
 	Get(location Location) (LocationBlobGetter, bool)
 
+	// GetRawReader returns a reader for the contents of a blob
+	// stored in the map, without validating the data against a
+	// digest. This can be used to inspect blobs whose digest is not
+	// known, such as when scrubbing storage.
+	//
+	// In addition to that, a DataIntegrityCallback is returned that
+	// can be invoked by the caller after the contents of the blob
+	// have been found to be corrupted. This has the same effect as
+	// data corruption being detected through Get(). Unlike the
+	// LocationBlobGetter, both the reader and the callback remain
+	// valid after calls to Put().
+	GetRawReader(location Location) (io.ReadCloser, buffer.DataIntegrityCallback)
+
 	// Put a new blob to storage.
 	//
 	// This function returns a LocationBlobPutWriter, which must be
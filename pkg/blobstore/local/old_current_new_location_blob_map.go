@@ -1,6 +1,7 @@
 package local
 
 import (
+	"io"
 	"sync"
 	"time"
 
@@ -233,22 +234,40 @@ func (lbm *OldCurrentNewLocationBlobMap) increaseTotalBlocksToBeReleased(newValu
 	}
 }
 
+// newDataIntegrityCallback creates a DataIntegrityCallback that, upon
+// being notified of data corruption, causes the block at a given index
+// and all blocks preceding it to be released. The absolute index of the
+// block is computed immediately, so that the callback remains valid
+// after blocks are released.
+func (lbm *OldCurrentNewLocationBlobMap) newDataIntegrityCallback(blockIndex int) buffer.DataIntegrityCallback {
+	totalBlocksToBeReleased := lbm.totalBlocksReleased + uint64(blockIndex) + 1
+	return func(dataIsValid bool) {
+		if !dataIsValid {
+			if blocksReleased := lbm.increaseTotalBlocksToBeReleased(totalBlocksToBeReleased); blocksReleased > 0 {
+				lbm.errorLogger.Log(status.Errorf(codes.Internal, "Releasing %d blocks due to a data integrity error", blocksReleased))
+			}
+		}
+	}
+}
+
 // Get information about a blob based on its Location. A
 // LocationBlobGetter is returned that can be used to fetch the blob's
 // contents.
 func (lbm *OldCurrentNewLocationBlobMap) Get(location Location) (LocationBlobGetter, bool) {
 	return func(digest digest.Digest) buffer.Buffer {
-		totalBlocksToBeReleased := lbm.totalBlocksReleased + uint64(location.BlockIndex) + 1
-		return lbm.blockList.Get(location.BlockIndex, digest, location.OffsetBytes, location.SizeBytes, func(dataIsValid bool) {
-			if !dataIsValid {
-				if blocksReleased := lbm.increaseTotalBlocksToBeReleased(totalBlocksToBeReleased); blocksReleased > 0 {
-					lbm.errorLogger.Log(status.Errorf(codes.Internal, "Releasing %d blocks due to a data integrity error", blocksReleased))
-				}
-			}
-		})
+		return lbm.blockList.Get(location.BlockIndex, digest, location.OffsetBytes, location.SizeBytes, lbm.newDataIntegrityCallback(location.BlockIndex))
 	}, location.BlockIndex < len(lbm.oldBlocks)
 }
 
+// GetRawReader returns a reader for the contents of a blob based on its
+// Location, without validating the data. The DataIntegrityCallback
+// that is returned releases blocks in the same way as when data
+// corruption is detected through Get().
+func (lbm *OldCurrentNewLocationBlobMap) GetRawReader(location Location) (io.ReadCloser, buffer.DataIntegrityCallback) {
+	return lbm.blockList.GetRawReader(location.BlockIndex, location.OffsetBytes, location.SizeBytes),
+		lbm.newDataIntegrityCallback(location.BlockIndex)
+}
+
 // startAllocatingFromBlock resets the counters used to determine from
 // which "new" block to allocate data. This function is called whenever
 // the list of "new" blocks changes.
@@ -1,6 +1,8 @@
 package local_test
 
 import (
+	"bytes"
+	"io/ioutil"
 	"testing"
 
 	"github.com/buildbarn/bb-storage/internal/mock"
@@ -167,3 +169,51 @@ func TestOldCurrentNewLocationBlobMapDataCorruption(t *testing.T) {
 	_, err = locationBlobPutWriter(buffer.NewBufferFromError(status.Error(codes.Unknown, "Client hung up")))()
 	require.Equal(t, status.Error(codes.Unknown, "Client hung up"), err)
 }
+
+func TestOldCurrentNewLocationBlobMapGetRawReader(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	blockList := mock.NewMockBlockList(ctrl)
+	errorLogger := mock.NewMockErrorLogger(ctrl)
+	locationBlobMap := local.NewOldCurrentNewLocationBlobMap(
+		blockList,
+		local.NewImmutableBlockListGrowthPolicy(
+			/* currentBlocksCount = */ 4,
+			/* newBlocksCount = */ 4),
+		errorLogger,
+		"cas",
+		/* blockSizeBytes = */ 16,
+		/* oldBlocksCount = */ 2,
+		/* newBlocksCount = */ 4,
+		/* initialBlocksCount = */ 10)
+
+	// Raw readers should give access to data without validating it.
+	blockList.EXPECT().GetRawReader(2, int64(10), int64(5)).
+		Return(ioutil.NopCloser(bytes.NewBufferString("xyzzy")))
+
+	r, dataIntegrityCallback := locationBlobMap.GetRawReader(local.Location{
+		BlockIndex:  2,
+		OffsetBytes: 10,
+		SizeBytes:   5,
+	})
+	data, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, []byte("xyzzy"), data)
+	require.NoError(t, r.Close())
+
+	// Reporting data corruption through the callback should cause
+	// blocks to be released, just like when Get() is used.
+	errorLogger.EXPECT().Log(status.Error(codes.Internal, "Releasing 3 blocks due to a data integrity error"))
+	dataIntegrityCallback(false)
+
+	blockList.EXPECT().BlockReferenceToBlockIndex(local.BlockReference{
+		EpochID:        72,
+		BlocksFromLast: 7,
+	}).Return(2, uint64(0xb8e12b9fbe428eba), true)
+
+	_, _, found := locationBlobMap.BlockReferenceToBlockIndex(local.BlockReference{
+		EpochID:        72,
+		BlocksFromLast: 7,
+	})
+	require.False(t, found)
+}
@@ -24,8 +24,9 @@ type PeriodicSyncer struct {
 	sourceLock *sync.RWMutex
 	source     PersistentStateSource
 
-	storeLock sync.Mutex
-	store     PersistentStateStore
+	storeLock                        sync.Mutex
+	store                            PersistentStateStore
+	keyLocationMapMigrationCompleted bool
 
 	lastSynchronizationTime time.Time
 }
@@ -41,7 +42,7 @@ type DataSyncer func() error
 
 // NewPeriodicSyncer creates a new PeriodicSyncer according to the
 // arguments provided.
-func NewPeriodicSyncer(source PersistentStateSource, sourceLock *sync.RWMutex, store PersistentStateStore, clock clock.Clock, errorLogger util.ErrorLogger, errorRetryInterval, minimumEpochInterval time.Duration, keyLocationMapHashInitialization uint64, dataSyncer DataSyncer) *PeriodicSyncer {
+func NewPeriodicSyncer(source PersistentStateSource, sourceLock *sync.RWMutex, store PersistentStateStore, clock clock.Clock, errorLogger util.ErrorLogger, errorRetryInterval, minimumEpochInterval time.Duration, keyLocationMapHashInitialization uint64, keyLocationMapMigrationCompleted bool, dataSyncer DataSyncer) *PeriodicSyncer {
 	return &PeriodicSyncer{
 		clock:                            clock,
 		errorLogger:                      errorLogger,
@@ -52,8 +53,9 @@ func NewPeriodicSyncer(source PersistentStateSource, sourceLock *sync.RWMutex, s
 
 		source:                  source,
 		sourceLock:              sourceLock,
-		store:                   store,
-		lastSynchronizationTime: clock.Now(),
+		store:                            store,
+		keyLocationMapMigrationCompleted: keyLocationMapMigrationCompleted,
+		lastSynchronizationTime:          clock.Now(),
 	}
 }
 
@@ -79,6 +81,7 @@ func (ps *PeriodicSyncer) writePersistentState() error {
 		OldestEpochId:                    oldestEpochID,
 		Blocks:                           blocks,
 		KeyLocationMapHashInitialization: ps.keyLocationMapHashInitialization,
+		KeyLocationMapMigrationCompleted: ps.keyLocationMapMigrationCompleted,
 	}); err != nil {
 		return err
 	}
@@ -99,6 +102,17 @@ func (ps *PeriodicSyncer) writePersistentStateRetrying() {
 	}
 }
 
+// MarkKeyLocationMapMigrationCompleted records in the persistent state
+// that the key-location map has been populated with the entries of the
+// configured migration source. The persistent state is written
+// immediately, so that migration is not repeated after a restart.
+func (ps *PeriodicSyncer) MarkKeyLocationMapMigrationCompleted() error {
+	ps.storeLock.Lock()
+	ps.keyLocationMapMigrationCompleted = true
+	ps.storeLock.Unlock()
+	return ps.writePersistentState()
+}
+
 // ProcessBlockRelease waits for a single block to be released by a
 // PersistentBlockList. It causes the persistent state of the
 // PersistentBlockList to be extracted and written to a file.
@@ -8,7 +8,9 @@ import (
 	"github.com/buildbarn/bb-storage/internal/mock"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/local"
 	pb "github.com/buildbarn/bb-storage/pkg/proto/blobstore/local"
+	"github.com/buildbarn/bb-storage/pkg/testutil"
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -33,6 +35,7 @@ func TestPeriodicSyncerProcessBlockRelease(t *testing.T) {
 		30*time.Second,
 		time.Minute,
 		0xdf280dd45b2c39e,
+		false,
 		dataSyncer.Call)
 
 	blockReleaseWakeup := make(chan struct{}, 1)
@@ -133,6 +136,7 @@ func TestPeriodicSyncerProcessBlockPut(t *testing.T) {
 		30*time.Second,
 		time.Minute,
 		0xdf280dd45b2c39e,
+		false,
 		dataSyncer.Call)
 
 	blockPutWakeup := make(chan struct{}, 1)
@@ -206,3 +210,42 @@ func TestPeriodicSyncerProcessBlockPut(t *testing.T) {
 
 	periodicSyncer.ProcessBlockPut()
 }
+
+func TestPeriodicSyncerMarkKeyLocationMapMigrationCompleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	source := mock.NewMockPersistentStateSource(ctrl)
+	var sourceLock sync.RWMutex
+	store := mock.NewMockPersistentStateStore(ctrl)
+	clock := mock.NewMockClock(ctrl)
+	clock.EXPECT().Now().Return(time.Unix(1000, 0))
+	periodicSyncer := local.NewPeriodicSyncer(
+		source,
+		&sourceLock,
+		store,
+		clock,
+		mock.NewMockErrorLogger(ctrl),
+		30*time.Second,
+		time.Minute,
+		0xdf280dd45b2c39e,
+		false,
+		mock.NewMockDataSyncer(ctrl).Call)
+
+	// Completion of the migration should cause the persistent state
+	// to be written immediately. Failures should be propagated.
+	source.EXPECT().GetPersistentState().Return(uint32(7), nil).Times(2)
+	store.EXPECT().WritePersistentState(&pb.PersistentState{
+		OldestEpochId:                    7,
+		KeyLocationMapHashInitialization: 0xdf280dd45b2c39e,
+		KeyLocationMapMigrationCompleted: true,
+	}).Return(status.Error(codes.Internal, "Disk on fire"))
+	testutil.RequireEqualStatus(t, status.Error(codes.Internal, "Disk on fire"), periodicSyncer.MarkKeyLocationMapMigrationCompleted())
+
+	store.EXPECT().WritePersistentState(&pb.PersistentState{
+		OldestEpochId:                    7,
+		KeyLocationMapHashInitialization: 0xdf280dd45b2c39e,
+		KeyLocationMapMigrationCompleted: true,
+	})
+	source.EXPECT().NotifyPersistentStateWritten()
+	require.NoError(t, periodicSyncer.MarkKeyLocationMapMigrationCompleted())
+}
@@ -1,6 +1,8 @@
 package local
 
 import (
+	"io"
+
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
 	"github.com/buildbarn/bb-storage/pkg/digest"
 	pb "github.com/buildbarn/bb-storage/pkg/proto/blobstore/local"
@@ -262,6 +264,12 @@ func (bl *PersistentBlockList) Get(index int, digest digest.Digest, offsetBytes,
 	return bl.blocks[index].block.block.Get(digest, offsetBytes, sizeBytes, dataIntegrityCallback)
 }
 
+// GetRawReader returns a reader for the contents of a blob in a given
+// block, without validating the data.
+func (bl *PersistentBlockList) GetRawReader(index int, offsetBytes, sizeBytes int64) io.ReadCloser {
+	return bl.blocks[index].block.block.GetRawReader(offsetBytes, sizeBytes)
+}
+
 func (bl *PersistentBlockList) toSectors(sizeBytes int64) int64 {
 	// Determine the number of sectors needed to store the object.
 	//
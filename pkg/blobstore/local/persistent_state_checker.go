@@ -0,0 +1,288 @@
+package local
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	pb "github.com/buildbarn/bb-storage/pkg/proto/blobstore/local"
+	"github.com/buildbarn/bb-storage/pkg/util"
+
+	"google.golang.org/grpc/codes"
+)
+
+// LocationRecordStatus is the outcome of checking a single entry in a
+// key-location map that is stored on a block device.
+type LocationRecordStatus int
+
+const (
+	// LocationRecordStatusEmpty indicates that the entry has never
+	// been written, or has been invalidated.
+	LocationRecordStatusEmpty LocationRecordStatus = iota
+	// LocationRecordStatusValid indicates that the entry refers to
+	// data that has been persisted, and that the contents of the
+	// blob match the key of the entry.
+	LocationRecordStatusValid
+	// LocationRecordStatusOutOfEpoch indicates that the entry
+	// refers to an epoch that is not part of the persistent state.
+	// Such entries are either stale, or were written after the
+	// persistent state was last updated. They are ignored by
+	// LocalBlobAccess.
+	LocationRecordStatusOutOfEpoch
+	// LocationRecordStatusChecksumMismatch indicates that the
+	// checksum of the entry is invalid. Such entries are ignored by
+	// LocalBlobAccess.
+	LocationRecordStatusChecksumMismatch
+	// LocationRecordStatusMisplaced indicates that the entry is not
+	// stored at the index at which HashingKeyLocationMap expects it.
+	// Such entries cannot be looked up by LocalBlobAccess.
+	LocationRecordStatusMisplaced
+	// LocationRecordStatusDangling indicates that the entry refers
+	// to a region of a block to which no data was written, as far
+	// as the persistent state is concerned.
+	LocationRecordStatusDangling
+	// LocationRecordStatusBlobCorrupt indicates that the contents
+	// of the blob to which the entry refers do not match the key of
+	// the entry.
+	LocationRecordStatusBlobCorrupt
+)
+
+var locationRecordStatusNames = [...]string{
+	LocationRecordStatusEmpty:            "Empty",
+	LocationRecordStatusValid:            "Valid",
+	LocationRecordStatusOutOfEpoch:       "OutOfEpoch",
+	LocationRecordStatusChecksumMismatch: "ChecksumMismatch",
+	LocationRecordStatusMisplaced:        "Misplaced",
+	LocationRecordStatusDangling:         "Dangling",
+	LocationRecordStatusBlobCorrupt:      "BlobCorrupt",
+}
+
+func (s LocationRecordStatus) String() string {
+	return locationRecordStatusNames[s]
+}
+
+// KeyValidator is used by PersistentStateChecker and Scrubber to check
+// whether the contents of a blob correspond to the key under which it
+// is stored.
+type KeyValidator func(key Key, r io.Reader) (bool, error)
+
+// NewCASKeyValidator creates a KeyValidator for blobs stored in the
+// Content Addressable Storage (CAS). As keys are irreversible, the
+// digest function and instance name of a blob cannot be derived from
+// its key. This implementation therefore computes a checksum of the
+// blob using all supported digest functions, and considers the blob to
+// be valid if any of the resulting digests yields the expected key.
+//
+// Keys are computed both without an instance name, and with each of
+// the instance names provided. The latter is needed to validate
+// entries created by LocalBlobAccess when hierarchical instance names
+// are enabled.
+func NewCASKeyValidator(instanceNames []digest.InstanceName) KeyValidator {
+	return func(key Key, r io.Reader) (bool, error) {
+		generators := make([]*digest.Generator, 0, len(digest.SupportedDigestFunctions))
+		writers := make([]io.Writer, 0, len(digest.SupportedDigestFunctions))
+		for _, digestFunction := range digest.SupportedDigestFunctions {
+			function, err := digest.EmptyInstanceName.GetDigestFunction(digestFunction)
+			if err != nil {
+				return false, err
+			}
+			generator := function.NewGenerator()
+			generators = append(generators, generator)
+			writers = append(writers, generator)
+		}
+		if _, err := io.Copy(io.MultiWriter(writers...), r); err != nil {
+			return false, util.StatusWrapWithCode(err, codes.Internal, "Failed to read blob")
+		}
+
+		for _, generator := range generators {
+			blobDigest := generator.Sum()
+			if NewKeyFromString(blobDigest.GetKey(digest.KeyWithoutInstance)) == key {
+				return true, nil
+			}
+			for _, instanceName := range instanceNames {
+				function, err := instanceName.GetDigestFunction(blobDigest.GetDigestFunction().GetEnumValue())
+				if err != nil {
+					return false, err
+				}
+				candidateDigest, err := function.NewDigest(blobDigest.GetHashString(), blobDigest.GetSizeBytes())
+				if err != nil {
+					return false, err
+				}
+				if NewKeyFromString(candidateDigest.GetKey(digest.KeyWithInstance)) == key {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	}
+}
+
+type checkerBlock struct {
+	blocksDevice     io.ReaderAt
+	offsetBytes      int64
+	writeOffsetBytes int64
+}
+
+type checkerEpoch struct {
+	hashSeed       uint64
+	lastBlockIndex int
+}
+
+// PersistentStateChecker can be used to check the consistency of the
+// data stored by a LocalBlobAccess that has persistency enabled, and
+// stores both its blocks and its key-location map on block devices.
+// It is intended to be used while LocalBlobAccess is not running, for
+// example after an unclean shutdown.
+type PersistentStateChecker struct {
+	keyLocationMap     io.ReaderAt
+	recordsCount       int
+	hashInitialization uint64
+	keyValidator       KeyValidator
+
+	oldestEpochID uint32
+	blocks        []checkerBlock
+	epochs        []checkerEpoch
+}
+
+// NewPersistentStateChecker creates a PersistentStateChecker for a
+// given persistent state. The layout of the block devices storing
+// blocks must be provided, as blocks are only restored by
+// LocalBlobAccess if their location matches this layout. This layout
+// can be computed using GetBlockDeviceBackedBlockLayout().
+//
+// Like NewPersistentBlockList, this function returns the number of
+// blocks that could be restored. If this is lower than the number of
+// blocks in the persistent state, LocalBlobAccess will discard the
+// remaining blocks upon startup.
+//
+// The key validator is optional. If not provided, the contents of
+// blobs are not checked.
+func NewPersistentStateChecker(persistentState *pb.PersistentState, blocksDevices []io.ReaderAt, sectorSizeBytes int, blockSectorCount int64, blockCounts []int, keyLocationMap io.ReaderAt, recordsCount int, keyValidator KeyValidator) (*PersistentStateChecker, int) {
+	c := &PersistentStateChecker{
+		keyLocationMap:     keyLocationMap,
+		recordsCount:       recordsCount,
+		hashInitialization: persistentState.KeyLocationMapHashInitialization,
+		keyValidator:       keyValidator,
+		oldestEpochID:      persistentState.OldestEpochId,
+	}
+
+	// Restore blocks in the same way as PersistentBlockList and
+	// BlockDeviceBackedBlockAllocator do. Blocks need to be located
+	// at one of the offsets handed out by the allocator, and may
+	// not be used more than once.
+	blockSizeBytes := blockSectorCount * int64(sectorSizeBytes)
+	type blockLocation struct {
+		blockDeviceIndex uint32
+		offsetBytes      int64
+	}
+	blocksUsed := map[blockLocation]struct{}{}
+	for _, blockState := range persistentState.Blocks {
+		location := blockState.BlockLocation
+		if location == nil ||
+			location.SizeBytes != blockSizeBytes ||
+			blockSizeBytes == 0 ||
+			location.OffsetBytes%blockSizeBytes != 0 ||
+			location.OffsetBytes < 0 ||
+			location.BlockDeviceIndex >= uint32(len(blockCounts)) ||
+			location.OffsetBytes/blockSizeBytes >= int64(blockCounts[location.BlockDeviceIndex]) {
+			break
+		}
+		key := blockLocation{
+			blockDeviceIndex: location.BlockDeviceIndex,
+			offsetBytes:      location.OffsetBytes,
+		}
+		if _, ok := blocksUsed[key]; ok {
+			break
+		}
+		blocksUsed[key] = struct{}{}
+
+		for _, hashSeed := range blockState.EpochHashSeeds {
+			c.epochs = append(c.epochs, checkerEpoch{
+				hashSeed:       hashSeed,
+				lastBlockIndex: len(c.blocks),
+			})
+		}
+		c.blocks = append(c.blocks, checkerBlock{
+			blocksDevice:     blocksDevices[location.BlockDeviceIndex],
+			offsetBytes:      location.OffsetBytes,
+			writeOffsetBytes: blockState.WriteOffsetBytes,
+		})
+	}
+	return c, len(c.blocks)
+}
+
+// GetRecordsCount returns the number of entries in the key-location
+// map.
+func (c *PersistentStateChecker) GetRecordsCount() int {
+	return c.recordsCount
+}
+
+// CheckLocationRecord checks the consistency of a single entry in the
+// key-location map, and the blob to which it refers.
+func (c *PersistentStateChecker) CheckLocationRecord(index int) (LocationRecordStatus, error) {
+	var record [BlockDeviceBackedLocationRecordSize]byte
+	if _, err := c.keyLocationMap.ReadAt(record[:], int64(index)*BlockDeviceBackedLocationRecordSize); err != nil {
+		return 0, util.StatusWrapfWithCode(err, codes.Internal, "Failed to read location record at index %d", index)
+	}
+	if record == [BlockDeviceBackedLocationRecordSize]byte{} {
+		return LocationRecordStatusEmpty, nil
+	}
+
+	// Resolve the block reference in the same way as
+	// PersistentBlockList.BlockReferenceToBlockIndex().
+	epochIndex := binary.LittleEndian.Uint32(record[:]) - c.oldestEpochID
+	if epochIndex >= uint32(len(c.epochs)) {
+		return LocationRecordStatusOutOfEpoch, nil
+	}
+	epoch := c.epochs[epochIndex]
+	blocksFromLast := int(binary.LittleEndian.Uint16(record[4:]))
+	if blocksFromLast > epoch.lastBlockIndex {
+		return LocationRecordStatusOutOfEpoch, nil
+	}
+	block := c.blocks[epoch.lastBlockIndex-blocksFromLast]
+
+	if computeChecksumForRecord(&record, epoch.hashSeed) != binary.LittleEndian.Uint64(record[4+2+sha256.Size+4+8+8:]) {
+		return LocationRecordStatusChecksumMismatch, nil
+	}
+
+	recordKey := LocationRecordKey{
+		Attempt: binary.LittleEndian.Uint32(record[4+2+sha256.Size:]),
+	}
+	copy(recordKey.Key[:], record[4+2:])
+	if int(recordKey.Hash(c.hashInitialization)%uint64(c.recordsCount)) != index {
+		return LocationRecordStatusMisplaced, nil
+	}
+
+	offsetBytes := int64(binary.LittleEndian.Uint64(record[4+2+sha256.Size+4:]))
+	sizeBytes := int64(binary.LittleEndian.Uint64(record[4+2+sha256.Size+4+8:]))
+	if offsetBytes < 0 || sizeBytes < 0 || offsetBytes > block.writeOffsetBytes || sizeBytes > block.writeOffsetBytes-offsetBytes {
+		return LocationRecordStatusDangling, nil
+	}
+
+	if c.keyValidator != nil {
+		valid, err := c.keyValidator(
+			recordKey.Key,
+			io.NewSectionReader(block.blocksDevice, block.offsetBytes+offsetBytes, sizeBytes))
+		if err != nil {
+			return 0, util.StatusWrapf(err, "Failed to validate blob referenced by location record at index %d", index)
+		}
+		if !valid {
+			return LocationRecordStatusBlobCorrupt, nil
+		}
+	}
+	return LocationRecordStatusValid, nil
+}
+
+// InvalidateBlockDeviceBackedLocationRecord clears an entry in a
+// key-location map that is stored on a block device, causing
+// LocalBlobAccess to treat it as if it was never written.
+//
+// As HashingKeyLocationMap stops probing when it encounters an invalid
+// entry, entries that collided with the invalidated entry may no
+// longer be found. This only causes these objects to go missing.
+func InvalidateBlockDeviceBackedLocationRecord(w io.WriterAt, index int) error {
+	var record [BlockDeviceBackedLocationRecordSize]byte
+	_, err := w.WriteAt(record[:], int64(index)*BlockDeviceBackedLocationRecordSize)
+	return err
+}
@@ -0,0 +1,174 @@
+package local_test
+
+import (
+	"bytes"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildbarn/bb-storage/internal/mock"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/local"
+	"github.com/buildbarn/bb-storage/pkg/blockdevice"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	pb "github.com/buildbarn/bb-storage/pkg/proto/blobstore/local"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersistentStateChecker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	// Blocks of 100 bytes, of which the second and third are in
+	// use. The third block contains the string "Hello".
+	blocksData := make([]byte, 400)
+	copy(blocksData[200:], "Hello")
+	persistentState := &pb.PersistentState{
+		OldestEpochId: 5,
+		Blocks: []*pb.BlockState{
+			{
+				BlockLocation:    &pb.BlockLocation{OffsetBytes: 100, SizeBytes: 100},
+				WriteOffsetBytes: 20,
+				EpochHashSeeds:   []uint64{111},
+			},
+			{
+				BlockLocation:    &pb.BlockLocation{OffsetBytes: 200, SizeBytes: 100},
+				WriteOffsetBytes: 10,
+				EpochHashSeeds:   []uint64{222},
+			},
+			{
+				// Does not match the layout of the block
+				// device. This block is discarded.
+				BlockLocation:    &pb.BlockLocation{OffsetBytes: 150, SizeBytes: 100},
+				WriteOffsetBytes: 10,
+				EpochHashSeeds:   []uint64{333},
+			},
+		},
+		KeyLocationMapHashInitialization: 12345,
+	}
+	const recordsCount = 1024
+
+	helloKey := local.NewKeyFromString(digest.MustNewDigest("", "8b1a9953c4611296a827abf8c47804d7", 5).GetKey(digest.KeyWithoutInstance))
+	halloKey := local.NewKeyFromString(digest.MustNewDigest("", "d1bf93299de1b68e6d382c893bf1215f", 5).GetKey(digest.KeyWithoutInstance))
+	helloRecordKey := local.LocationRecordKey{Key: helloKey}
+	helloSlot := int(helloRecordKey.Hash(12345) % recordsCount)
+	halloRecordKey := local.LocationRecordKey{Key: halloKey}
+	halloSlot := int(halloRecordKey.Hash(12345) % recordsCount)
+
+	// Creates a key-location map containing a single record, and a
+	// PersistentStateChecker that reads from it.
+	newChecker := func(t *testing.T, index int, recordKey local.LocationRecordKey, location local.Location, blockReference local.BlockReference, hashSeed uint64) (*local.PersistentStateChecker, blockdevice.BlockDevice) {
+		f, _, _, err := blockdevice.NewBlockDeviceFromFile(
+			filepath.Join(t.TempDir(), "key_location_map"),
+			recordsCount*local.BlockDeviceBackedLocationRecordSize,
+			true)
+		require.NoError(t, err)
+
+		resolver := mock.NewMockBlockReferenceResolver(ctrl)
+		resolver.EXPECT().BlockIndexToBlockReference(location.BlockIndex).Return(blockReference, hashSeed)
+		require.NoError(t, local.NewBlockDeviceBackedLocationRecordArray(f, resolver).Put(index, local.LocationRecord{
+			RecordKey: recordKey,
+			Location:  location,
+		}))
+
+		checker, blockCount := local.NewPersistentStateChecker(
+			persistentState,
+			[]io.ReaderAt{bytes.NewReader(blocksData)},
+			1,
+			100,
+			[]int{4},
+			f,
+			recordsCount,
+			local.NewCASKeyValidator(nil))
+		require.Equal(t, 2, blockCount)
+		return checker, f
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		checker, f := newChecker(t, helloSlot, helloRecordKey, local.Location{BlockIndex: 1, OffsetBytes: 0, SizeBytes: 5}, local.BlockReference{EpochID: 6}, 222)
+
+		status, err := checker.CheckLocationRecord(helloSlot)
+		require.NoError(t, err)
+		require.Equal(t, local.LocationRecordStatusValid, status)
+
+		// Other entries in the key-location map are empty.
+		status, err = checker.CheckLocationRecord((helloSlot + 1) % recordsCount)
+		require.NoError(t, err)
+		require.Equal(t, local.LocationRecordStatusEmpty, status)
+
+		// Invalidating the entry should cause it to be empty.
+		require.NoError(t, local.InvalidateBlockDeviceBackedLocationRecord(f, helloSlot))
+		status, err = checker.CheckLocationRecord(helloSlot)
+		require.NoError(t, err)
+		require.Equal(t, local.LocationRecordStatusEmpty, status)
+	})
+
+	t.Run("ValidBLAKE3", func(t *testing.T) {
+		// Blobs may also have been stored using digest functions
+		// that cannot be inferred from the length of the hash.
+		g := digest.MustNewFunction("", digest.DigestFunctionBLAKE3).NewGenerator()
+		g.Write([]byte("Hello"))
+		blake3RecordKey := local.LocationRecordKey{Key: local.NewKeyFromString(g.Sum().GetKey(digest.KeyWithoutInstance))}
+		blake3Slot := int(blake3RecordKey.Hash(12345) % recordsCount)
+		checker, _ := newChecker(t, blake3Slot, blake3RecordKey, local.Location{BlockIndex: 1, OffsetBytes: 0, SizeBytes: 5}, local.BlockReference{EpochID: 6}, 222)
+
+		status, err := checker.CheckLocationRecord(blake3Slot)
+		require.NoError(t, err)
+		require.Equal(t, local.LocationRecordStatusValid, status)
+	})
+
+	t.Run("EpochPredatesBlock", func(t *testing.T) {
+		// Epoch 5 was created when the second block was the
+		// last block. It cannot be used to refer to the third
+		// block.
+		checker, _ := newChecker(t, helloSlot, helloRecordKey, local.Location{BlockIndex: 1, OffsetBytes: 0, SizeBytes: 5}, local.BlockReference{EpochID: 5, BlocksFromLast: 1}, 111)
+
+		status, err := checker.CheckLocationRecord(helloSlot)
+		require.NoError(t, err)
+		require.Equal(t, local.LocationRecordStatusOutOfEpoch, status)
+	})
+
+	t.Run("OutOfEpoch", func(t *testing.T) {
+		checker, _ := newChecker(t, helloSlot, helloRecordKey, local.Location{BlockIndex: 1, OffsetBytes: 0, SizeBytes: 5}, local.BlockReference{EpochID: 7}, 333)
+
+		status, err := checker.CheckLocationRecord(helloSlot)
+		require.NoError(t, err)
+		require.Equal(t, local.LocationRecordStatusOutOfEpoch, status)
+	})
+
+	t.Run("ChecksumMismatch", func(t *testing.T) {
+		checker, _ := newChecker(t, helloSlot, helloRecordKey, local.Location{BlockIndex: 1, OffsetBytes: 0, SizeBytes: 5}, local.BlockReference{EpochID: 6}, 999)
+
+		status, err := checker.CheckLocationRecord(helloSlot)
+		require.NoError(t, err)
+		require.Equal(t, local.LocationRecordStatusChecksumMismatch, status)
+	})
+
+	t.Run("Misplaced", func(t *testing.T) {
+		misplacedSlot := (helloSlot + 1) % recordsCount
+		checker, _ := newChecker(t, misplacedSlot, helloRecordKey, local.Location{BlockIndex: 1, OffsetBytes: 0, SizeBytes: 5}, local.BlockReference{EpochID: 6}, 222)
+
+		status, err := checker.CheckLocationRecord(misplacedSlot)
+		require.NoError(t, err)
+		require.Equal(t, local.LocationRecordStatusMisplaced, status)
+	})
+
+	t.Run("Dangling", func(t *testing.T) {
+		// Only the first 10 bytes of the third block have been
+		// written, according to the persistent state.
+		checker, _ := newChecker(t, helloSlot, helloRecordKey, local.Location{BlockIndex: 1, OffsetBytes: 8, SizeBytes: 5}, local.BlockReference{EpochID: 6}, 222)
+
+		status, err := checker.CheckLocationRecord(helloSlot)
+		require.NoError(t, err)
+		require.Equal(t, local.LocationRecordStatusDangling, status)
+	})
+
+	t.Run("BlobCorrupt", func(t *testing.T) {
+		// The record refers to "Hello", while its key
+		// corresponds to "Hallo".
+		checker, _ := newChecker(t, halloSlot, halloRecordKey, local.Location{BlockIndex: 1, OffsetBytes: 0, SizeBytes: 5}, local.BlockReference{EpochID: 6}, 222)
+
+		status, err := checker.CheckLocationRecord(halloSlot)
+		require.NoError(t, err)
+		require.Equal(t, local.LocationRecordStatusBlobCorrupt, status)
+	})
+}
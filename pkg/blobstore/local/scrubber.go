@@ -0,0 +1,197 @@
+package local
+
+import (
+	"sync"
+	"time"
+
+	"github.com/buildbarn/bb-storage/pkg/clock"
+	"github.com/buildbarn/bb-storage/pkg/util"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	scrubberPrometheusMetrics sync.Once
+
+	scrubberLocationRecords = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "buildbarn",
+			Subsystem: "blobstore",
+			Name:      "scrubber_location_records_total",
+			Help:      "Number of entries in the key-location map that were processed by the scrubber",
+		},
+		[]string{"storage_type", "outcome"})
+	scrubberBytesRead = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "buildbarn",
+			Subsystem: "blobstore",
+			Name:      "scrubber_bytes_read_total",
+			Help:      "Number of bytes of blob contents read by the scrubber",
+		},
+		[]string{"storage_type"})
+	scrubberPassesCompleted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "buildbarn",
+			Subsystem: "blobstore",
+			Name:      "scrubber_passes_completed_total",
+			Help:      "Number of times the scrubber processed all entries in the key-location map",
+		},
+		[]string{"storage_type"})
+	scrubberPassProgress = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "buildbarn",
+			Subsystem: "blobstore",
+			Name:      "scrubber_pass_progress_ratio",
+			Help:      "Fraction of entries in the key-location map that have been processed by the scrubber during the current pass",
+		},
+		[]string{"storage_type"})
+)
+
+// scrubberMinimumSleepDuration is the minimum amount of time the
+// scrubber sleeps to apply rate limiting. Shorter delays are
+// accumulated, so that processing many small or empty entries does not
+// require a timer to be created for each of them.
+const scrubberMinimumSleepDuration = 10 * time.Millisecond
+
+// Scrubber walks over all entries in a LocationRecordArray, reading the
+// blobs to which they refer and checking whether their contents still
+// match their keys. This allows silent data corruption to be detected
+// for blobs that are rarely read by clients.
+//
+// When data corruption is detected, the scrubber calls into the
+// DataIntegrityCallback provided by LocationBlobMap. This causes blocks
+// to be released in the same way as when data corruption is detected
+// while a client reads the blob.
+type Scrubber struct {
+	lock                 *sync.RWMutex
+	locationRecordArray  LocationRecordArray
+	locationRecordsCount int
+	locationBlobMap      LocationBlobMap
+	keyValidator         KeyValidator
+	clock                clock.Clock
+	errorLogger          util.ErrorLogger
+	bytesPerSecond       int64
+	passInterval         time.Duration
+
+	nextIndex    int
+	pendingSleep time.Duration
+
+	locationRecordsInvalid   prometheus.Counter
+	locationRecordsValid     prometheus.Counter
+	locationRecordsCorrupted prometheus.Counter
+	locationRecordsFailed    prometheus.Counter
+	bytesRead                prometheus.Counter
+	passesCompleted          prometheus.Counter
+	passProgress             prometheus.Gauge
+}
+
+// NewScrubber creates a new Scrubber. The scrubber reads blobs at a
+// rate of at most bytesPerSecond, so that its impact on clients remains
+// limited. Every entry is accounted for as if it were at least
+// BlockDeviceBackedLocationRecordSize bytes in size, so that entries
+// that are empty or invalid are rate limited as well. Once all entries
+// have been processed, it waits for passInterval before starting the
+// next pass.
+func NewScrubber(lock *sync.RWMutex, locationRecordArray LocationRecordArray, locationRecordsCount int, locationBlobMap LocationBlobMap, keyValidator KeyValidator, clock clock.Clock, errorLogger util.ErrorLogger, storageType string, bytesPerSecond int64, passInterval time.Duration) *Scrubber {
+	scrubberPrometheusMetrics.Do(func() {
+		prometheus.MustRegister(scrubberLocationRecords)
+		prometheus.MustRegister(scrubberBytesRead)
+		prometheus.MustRegister(scrubberPassesCompleted)
+		prometheus.MustRegister(scrubberPassProgress)
+	})
+
+	return &Scrubber{
+		lock:                 lock,
+		locationRecordArray:  locationRecordArray,
+		locationRecordsCount: locationRecordsCount,
+		locationBlobMap:      locationBlobMap,
+		keyValidator:         keyValidator,
+		clock:                clock,
+		errorLogger:          errorLogger,
+		bytesPerSecond:       bytesPerSecond,
+		passInterval:         passInterval,
+
+		locationRecordsInvalid:   scrubberLocationRecords.WithLabelValues(storageType, "Invalid"),
+		locationRecordsValid:     scrubberLocationRecords.WithLabelValues(storageType, "Valid"),
+		locationRecordsCorrupted: scrubberLocationRecords.WithLabelValues(storageType, "Corrupted"),
+		locationRecordsFailed:    scrubberLocationRecords.WithLabelValues(storageType, "Failed"),
+		bytesRead:                scrubberBytesRead.WithLabelValues(storageType),
+		passesCompleted:          scrubberPassesCompleted.WithLabelValues(storageType),
+		passProgress:             scrubberPassProgress.WithLabelValues(storageType),
+	}
+}
+
+func (s *Scrubber) sleep(d time.Duration) {
+	if d > 0 {
+		_, t := s.clock.NewTimer(d)
+		<-t
+	}
+}
+
+// ProcessLocationRecord scrubs the next entry in the LocationRecordArray.
+// This function is blocking, as it also applies rate limiting. It is
+// intended to be called in a loop.
+func (s *Scrubber) ProcessLocationRecord() {
+	index := s.nextIndex
+	s.scrubLocationRecord(index)
+
+	s.nextIndex = index + 1
+	if s.nextIndex >= s.locationRecordsCount {
+		s.nextIndex = 0
+		s.passesCompleted.Inc()
+		s.passProgress.Set(0)
+		s.sleep(s.passInterval)
+	} else {
+		s.passProgress.Set(float64(s.nextIndex) / float64(s.locationRecordsCount))
+	}
+}
+
+func (s *Scrubber) scrubLocationRecord(index int) {
+	sizeBytes := s.readLocationRecord(index)
+	if s.bytesPerSecond > 0 {
+		s.pendingSleep += time.Duration(float64(BlockDeviceBackedLocationRecordSize+sizeBytes) / float64(s.bytesPerSecond) * float64(time.Second))
+		if s.pendingSleep >= scrubberMinimumSleepDuration {
+			s.sleep(s.pendingSleep)
+			s.pendingSleep = 0
+		}
+	}
+}
+
+// readLocationRecord reads a single entry from the LocationRecordArray
+// and validates the contents of the blob to which it refers. It
+// returns the number of bytes of blob contents that were read.
+func (s *Scrubber) readLocationRecord(index int) int64 {
+	// Only hold the lock while obtaining a reader. The reader holds
+	// a reference to the block, meaning that the blob's contents
+	// remain accessible after the lock is dropped.
+	s.lock.RLock()
+	record, err := s.locationRecordArray.Get(index)
+	if err != nil {
+		s.lock.RUnlock()
+		if err == ErrLocationRecordInvalid {
+			s.locationRecordsInvalid.Inc()
+		} else {
+			s.locationRecordsFailed.Inc()
+			s.errorLogger.Log(util.StatusWrapf(err, "Failed to scrub location record at index %d", index))
+		}
+		return 0
+	}
+	r, dataIntegrityCallback := s.locationBlobMap.GetRawReader(record.Location)
+	s.lock.RUnlock()
+
+	valid, err := s.keyValidator(record.RecordKey.Key, r)
+	r.Close()
+	sizeBytes := record.Location.SizeBytes
+	s.bytesRead.Add(float64(sizeBytes))
+	if err != nil {
+		// I/O errors are not an indication that the data
+		// itself is corrupted. Leave the blob in place.
+		s.locationRecordsFailed.Inc()
+		s.errorLogger.Log(util.StatusWrapf(err, "Failed to scrub blob referenced by location record at index %d", index))
+	} else if valid {
+		s.locationRecordsValid.Inc()
+	} else {
+		s.locationRecordsCorrupted.Inc()
+		dataIntegrityCallback(false)
+	}
+	return sizeBytes
+}
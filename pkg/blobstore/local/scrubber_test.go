@@ -0,0 +1,129 @@
+package local_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"sync"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/buildbarn/bb-storage/internal/mock"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/local"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/golang/mock/gomock"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestScrubber(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	var lock sync.RWMutex
+	locationRecordArray := mock.NewMockLocationRecordArray(ctrl)
+	locationBlobMap := mock.NewMockLocationBlobMap(ctrl)
+	clock := mock.NewMockClock(ctrl)
+	errorLogger := mock.NewMockErrorLogger(ctrl)
+	scrubber := local.NewScrubber(
+		&lock,
+		locationRecordArray,
+		4,
+		locationBlobMap,
+		local.NewCASKeyValidator(nil),
+		clock,
+		errorLogger,
+		"cas",
+		2,
+		time.Minute)
+
+	helloRecord := local.LocationRecord{
+		RecordKey: local.LocationRecordKey{
+			Key: local.NewKeyFromString(digest.MustNewDigest("", "8b1a9953c4611296a827abf8c47804d7", 5).GetKey(digest.KeyWithoutInstance)),
+		},
+		Location: local.Location{BlockIndex: 3, OffsetBytes: 100, SizeBytes: 5},
+	}
+	expectSleep := func(d time.Duration) {
+		timerChannel := make(chan time.Time, 1)
+		timerChannel <- time.Unix(1000, 0)
+		clock.EXPECT().NewTimer(d).Return(mock.NewMockTimer(ctrl), timerChannel)
+	}
+
+	// Entry containing a blob whose contents match its key. Every
+	// entry is accounted for as if it contained the size of a
+	// location record in addition to the blob. Reading 66+5 bytes at
+	// a rate of two bytes per second should cause the scrubber to
+	// sleep for 35.5 seconds.
+	locationRecordArray.EXPECT().Get(0).Return(helloRecord, nil)
+	locationBlobMap.EXPECT().GetRawReader(helloRecord.Location).Return(
+		ioutil.NopCloser(bytes.NewBufferString("Hello")),
+		func(dataIsValid bool) { t.Fatal("Valid data should not be reported") })
+	expectSleep(35500 * time.Millisecond)
+	scrubber.ProcessLocationRecord()
+
+	// Entries that are invalid should be skipped, but still be
+	// subject to rate limiting.
+	locationRecordArray.EXPECT().Get(1).Return(local.LocationRecord{}, local.ErrLocationRecordInvalid)
+	expectSleep(33 * time.Second)
+	scrubber.ProcessLocationRecord()
+
+	// Entry containing a blob whose contents do not match its key.
+	// This should be reported through the DataIntegrityCallback.
+	locationRecordArray.EXPECT().Get(2).Return(helloRecord, nil)
+	dataIntegrityCallback := mock.NewMockDataIntegrityCallback(ctrl)
+	locationBlobMap.EXPECT().GetRawReader(helloRecord.Location).Return(
+		ioutil.NopCloser(bytes.NewBufferString("Hallo")),
+		dataIntegrityCallback.Call)
+	dataIntegrityCallback.EXPECT().Call(false)
+	expectSleep(35500 * time.Millisecond)
+	scrubber.ProcessLocationRecord()
+
+	// I/O errors should not be treated as data corruption. As this
+	// is the last entry, the scrubber should wait before starting
+	// the next pass.
+	locationRecordArray.EXPECT().Get(3).Return(helloRecord, nil)
+	locationBlobMap.EXPECT().GetRawReader(helloRecord.Location).Return(
+		ioutil.NopCloser(iotest.ErrReader(status.Error(codes.Internal, "Disk on fire"))),
+		func(dataIsValid bool) { t.Fatal("I/O errors should not be reported") })
+	errorLogger.EXPECT().Log(status.Error(codes.Internal, "Failed to scrub blob referenced by location record at index 3: Failed to read blob: Disk on fire"))
+	expectSleep(35500 * time.Millisecond)
+	expectSleep(time.Minute)
+	scrubber.ProcessLocationRecord()
+
+	// The next pass should start at the first entry again.
+	locationRecordArray.EXPECT().Get(0).Return(local.LocationRecord{}, local.ErrLocationRecordInvalid)
+	expectSleep(33 * time.Second)
+	scrubber.ProcessLocationRecord()
+}
+
+func TestScrubberAccumulatedSleep(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	var lock sync.RWMutex
+	locationRecordArray := mock.NewMockLocationRecordArray(ctrl)
+	clock := mock.NewMockClock(ctrl)
+	scrubber := local.NewScrubber(
+		&lock,
+		locationRecordArray,
+		10,
+		mock.NewMockLocationBlobMap(ctrl),
+		local.NewCASKeyValidator(nil),
+		clock,
+		mock.NewMockErrorLogger(ctrl),
+		"cas",
+		128*local.BlockDeviceBackedLocationRecordSize,
+		time.Minute)
+
+	// Processing an invalid entry takes 1/128th of a second worth
+	// of bandwidth. Such short delays should be accumulated, so
+	// that the scrubber only sleeps every other entry.
+	for i := 0; i < 4; i++ {
+		locationRecordArray.EXPECT().Get(i).Return(local.LocationRecord{}, local.ErrLocationRecordInvalid)
+		if i%2 == 1 {
+			timerChannel := make(chan time.Time, 1)
+			timerChannel <- time.Unix(1000, 0)
+			clock.EXPECT().NewTimer(15625 * time.Microsecond).Return(mock.NewMockTimer(ctrl), timerChannel)
+		}
+		scrubber.ProcessLocationRecord()
+	}
+}
@@ -1,6 +1,8 @@
 package local
 
 import (
+	"io"
+
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
 	"github.com/buildbarn/bb-storage/pkg/digest"
 	"github.com/buildbarn/bb-storage/pkg/random"
@@ -120,6 +122,10 @@ func (bl *volatileBlockList) Get(index int, digest digest.Digest, offsetBytes, s
 	return bl.blocks[index].block.block.Get(digest, offsetBytes, sizeBytes, dataIntegrityCallback)
 }
 
+func (bl *volatileBlockList) GetRawReader(index int, offsetBytes, sizeBytes int64) io.ReadCloser {
+	return bl.blocks[index].block.block.GetRawReader(offsetBytes, sizeBytes)
+}
+
 func (bl *volatileBlockList) toSectors(sizeBytes int64) int64 {
 	// Determine the number of sectors needed to store the object.
 	//
@@ -0,0 +1,111 @@
+package mirrored
+
+import (
+	"sync"
+	"time"
+
+	"github.com/buildbarn/bb-storage/pkg/clock"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+)
+
+const (
+	// Bounds of the amount of time to wait before replaying a
+	// catch-up journal again, after the previous attempt failed.
+	catchUpJournalMinimumReplayBackoff = time.Second
+	catchUpJournalMaximumReplayBackoff = 5 * time.Minute
+)
+
+// catchUpJournal keeps track of blobs that could not be written to one
+// of the backends of MirroredBlobAccess, because the backend was
+// unavailable. Once the backend becomes available again, these blobs
+// are replicated from the other backend.
+//
+// The journal has a bounded size. Blobs that don't fit are not
+// recorded. These are repaired lazily when MirroredBlobAccess detects
+// inconsistencies between backends as part of Get() and FindMissing().
+//
+// If replaying the journal fails, subsequent attempts are delayed
+// using exponential backoff. This prevents a backend that is flapping
+// from being flooded with replication requests.
+type catchUpJournal struct {
+	maximumSize int
+	clock       clock.Clock
+
+	lock              sync.Mutex
+	digests           map[digest.Digest]struct{}
+	replaying         bool
+	replayBackoff     time.Duration
+	nextReplayAttempt time.Time
+}
+
+func newCatchUpJournal(maximumSize int, clock clock.Clock) *catchUpJournal {
+	return &catchUpJournal{
+		maximumSize: maximumSize,
+		clock:       clock,
+		digests:     map[digest.Digest]struct{}{},
+	}
+}
+
+// add a blob to the journal. This function returns false if the
+// journal is full.
+func (j *catchUpJournal) add(blobDigest digest.Digest) bool {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if _, ok := j.digests[blobDigest]; ok {
+		return true
+	}
+	if len(j.digests) >= j.maximumSize {
+		return false
+	}
+	j.digests[blobDigest] = struct{}{}
+	return true
+}
+
+// startReplay removes all blobs from the journal, so that they may be
+// replicated. This function returns false if the journal is empty, if
+// another replay is still in progress, or if a previous replay failed
+// recently.
+func (j *catchUpJournal) startReplay() (digest.Set, bool) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.replaying || len(j.digests) == 0 || (j.replayBackoff > 0 && j.clock.Now().Before(j.nextReplayAttempt)) {
+		return digest.EmptySet, false
+	}
+	digests := digest.NewSetBuilder()
+	for blobDigest := range j.digests {
+		digests.Add(blobDigest)
+	}
+	j.digests = map[digest.Digest]struct{}{}
+	j.replaying = true
+	return digests.Build(), true
+}
+
+// finishReplay marks a replay started through startReplay() as
+// completed. Blobs that failed to replicate are added to the journal
+// again, so that they are retried as part of the next replay. The next
+// replay is delayed in that case.
+func (j *catchUpJournal) finishReplay(failed digest.Set) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if failed.Empty() {
+		j.replayBackoff = 0
+	} else {
+		j.replayBackoff *= 2
+		if j.replayBackoff < catchUpJournalMinimumReplayBackoff {
+			j.replayBackoff = catchUpJournalMinimumReplayBackoff
+		} else if j.replayBackoff > catchUpJournalMaximumReplayBackoff {
+			j.replayBackoff = catchUpJournalMaximumReplayBackoff
+		}
+		j.nextReplayAttempt = j.clock.Now().Add(j.replayBackoff)
+	}
+	for _, blobDigest := range failed.Items() {
+		if len(j.digests) >= j.maximumSize {
+			break
+		}
+		j.digests[blobDigest] = struct{}{}
+	}
+	j.replaying = false
+}
@@ -3,11 +3,14 @@ package mirrored
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/buildbarn/bb-storage/pkg/atomic"
 	"github.com/buildbarn/bb-storage/pkg/blobstore"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/replication"
+	"github.com/buildbarn/bb-storage/pkg/capabilities"
+	"github.com/buildbarn/bb-storage/pkg/clock"
 	"github.com/buildbarn/bb-storage/pkg/digest"
 	"github.com/buildbarn/bb-storage/pkg/util"
 	"github.com/prometheus/client_golang/prometheus"
@@ -30,14 +33,32 @@ var (
 		[]string{"direction"})
 	mirroredBlobAccessFindMissingSynchronizationsFromAToB = mirroredBlobAccessFindMissingSynchronizations.WithLabelValues("FromAToB")
 	mirroredBlobAccessFindMissingSynchronizationsFromBToA = mirroredBlobAccessFindMissingSynchronizations.WithLabelValues("FromBToA")
+
+	mirroredBlobAccessCatchUpJournalBlobs = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "buildbarn",
+			Subsystem: "blobstore",
+			Name:      "mirrored_blob_access_catch_up_journal_blobs_total",
+			Help:      "Number of blobs processed by the catch-up journal while running in degraded mode",
+		},
+		[]string{"backend", "operation"})
 )
 
 type mirroredBlobAccess struct {
+	capabilities.Provider
+
 	backendA       blobstore.BlobAccess
 	backendB       blobstore.BlobAccess
 	replicatorAToB replication.BlobReplicator
 	replicatorBToA replication.BlobReplicator
+	errorLogger    util.ErrorLogger
 	round          atomic.Uint32
+
+	// Catch-up journals of blobs that could not be written to
+	// either backend. These are only set when degraded mode is
+	// enabled.
+	journalA *catchUpJournal
+	journalB *catchUpJournal
 }
 
 // NewMirroredBlobAccess creates a BlobAccess that applies operations to
@@ -45,30 +66,100 @@ type mirroredBlobAccess struct {
 // inconsistencies between the two storage backends are detected (i.e.,
 // a blob is only present in one of the backends), the blob is
 // replicated.
-func NewMirroredBlobAccess(backendA, backendB blobstore.BlobAccess, replicatorAToB, replicatorBToA replication.BlobReplicator) blobstore.BlobAccess {
+//
+// If maximumJournalSize is positive, degraded mode is enabled. In this
+// mode requests continue to be served by one of the backends if the
+// other one fails with UNAVAILABLE. Blobs that could not be written to
+// the unavailable backend are recorded in a catch-up journal of the
+// provided size. Once the backend processes requests successfully
+// again, the blobs in the journal are replicated to it. Failures to
+// replay the journal are reported through the ErrorLogger, after which
+// replaying is retried with exponential backoff.
+//
+// The capabilities reported by this backend are the intersection of
+// those of both backends, as requests may be sent to either of them.
+func NewMirroredBlobAccess(backendA, backendB blobstore.BlobAccess, replicatorAToB, replicatorBToA replication.BlobReplicator, maximumJournalSize int, clock clock.Clock, errorLogger util.ErrorLogger) blobstore.BlobAccess {
 	mirroredBlobAccessPrometheusMetrics.Do(func() {
 		prometheus.MustRegister(mirroredBlobAccessFindMissingSynchronizations)
+		prometheus.MustRegister(mirroredBlobAccessCatchUpJournalBlobs)
 	})
 
-	return &mirroredBlobAccess{
+	ba := &mirroredBlobAccess{
+		Provider:       capabilities.NewIntersectingProvider([]capabilities.Provider{backendA, backendB}, clock, time.Minute),
 		backendA:       backendA,
 		backendB:       backendB,
 		replicatorAToB: replicatorAToB,
 		replicatorBToA: replicatorBToA,
+		errorLogger:    errorLogger,
+	}
+	if maximumJournalSize > 0 {
+		ba.journalA = newCatchUpJournal(maximumJournalSize, clock)
+		ba.journalB = newCatchUpJournal(maximumJournalSize, clock)
+	}
+	return ba
+}
+
+func (ba *mirroredBlobAccess) isDegradedModeEnabled() bool {
+	return ba.journalA != nil
+}
+
+// isUnavailable returns whether an error returned by one of the
+// backends may be ignored, because degraded mode is enabled.
+func (ba *mirroredBlobAccess) isUnavailable(err error) bool {
+	return ba.isDegradedModeEnabled() && status.Code(err) == codes.Unavailable
+}
+
+// recordMissedWrite adds a blob to the catch-up journal of a backend,
+// so that it is replicated once the backend is available again.
+func recordMissedWrite(journal *catchUpJournal, backendName string, blobDigest digest.Digest) {
+	if journal.add(blobDigest) {
+		mirroredBlobAccessCatchUpJournalBlobs.WithLabelValues(backendName, "Added").Inc()
+	} else {
+		mirroredBlobAccessCatchUpJournalBlobs.WithLabelValues(backendName, "Dropped").Inc()
 	}
 }
 
+// catchUpJournalReplayTimeout is the maximum amount of time a single
+// attempt to replay a catch-up journal may take.
+const catchUpJournalReplayTimeout = 5 * time.Minute
+
+// replayJournal replicates all blobs recorded in the catch-up journal
+// of a backend in the background. This function is called after a
+// request against the backend succeeds, as that indicates that the
+// backend is available again.
+func (ba *mirroredBlobAccess) replayJournal(journal *catchUpJournal, backendName string, replicator replication.BlobReplicator) {
+	if journal == nil {
+		return
+	}
+	digests, ok := journal.startReplay()
+	if !ok {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), catchUpJournalReplayTimeout)
+		err := replicator.ReplicateMultiple(ctx, digests)
+		cancel()
+		if err != nil {
+			ba.errorLogger.Log(util.StatusWrapf(err, "Failed to replay catch-up journal of %s", backendName))
+			journal.finishReplay(digests)
+			return
+		}
+		mirroredBlobAccessCatchUpJournalBlobs.WithLabelValues(backendName, "Replayed").Add(float64(digests.Length()))
+		journal.finishReplay(digest.EmptySet)
+	}()
+}
+
 func (ba *mirroredBlobAccess) Get(ctx context.Context, digest digest.Digest) buffer.Buffer {
 	// Alternate requests between storage backends.
-	var firstBackend blobstore.BlobAccess
+	var firstBackend, secondBackend blobstore.BlobAccess
 	var firstBackendName, secondBackendName string
 	var replicator replication.BlobReplicator
 	if ba.round.Add(1)%2 == 1 {
-		firstBackend = ba.backendA
+		firstBackend, secondBackend = ba.backendA, ba.backendB
 		firstBackendName, secondBackendName = "Backend A", "Backend B"
 		replicator = ba.replicatorBToA
 	} else {
-		firstBackend = ba.backendB
+		firstBackend, secondBackend = ba.backendB, ba.backendA
 		firstBackendName, secondBackendName = "Backend B", "Backend A"
 		replicator = ba.replicatorAToB
 	}
@@ -76,7 +167,9 @@ func (ba *mirroredBlobAccess) Get(ctx context.Context, digest digest.Digest) buf
 	return buffer.WithErrorHandler(
 		firstBackend.Get(ctx, digest),
 		&mirroredErrorHandler{
+			blobAccess:        ba,
 			firstBackendName:  firstBackendName,
+			secondBackend:     secondBackend,
 			secondBackendName: secondBackendName,
 			replicator:        replicator,
 			context:           ctx,
@@ -92,12 +185,30 @@ func (ba *mirroredBlobAccess) Put(ctx context.Context, digest digest.Digest, b b
 		errAChan <- ba.backendA.Put(ctx, digest, b1)
 	}()
 	errB := ba.backendB.Put(ctx, digest, b2)
-	if errA := <-errAChan; errA != nil {
+	errA := <-errAChan
+
+	// In degraded mode, tolerate one of the backends being
+	// unavailable. Record the write that was missed, so that it
+	// can be replayed later.
+	if errA == nil && ba.isUnavailable(errB) {
+		recordMissedWrite(ba.journalB, "Backend B", digest)
+		ba.replayJournal(ba.journalA, "Backend A", ba.replicatorBToA)
+		return nil
+	}
+	if errB == nil && ba.isUnavailable(errA) {
+		recordMissedWrite(ba.journalA, "Backend A", digest)
+		ba.replayJournal(ba.journalB, "Backend B", ba.replicatorAToB)
+		return nil
+	}
+
+	if errA != nil {
 		return util.StatusWrap(errA, "Backend A")
 	}
 	if errB != nil {
 		return util.StatusWrap(errB, "Backend B")
 	}
+	ba.replayJournal(ba.journalA, "Backend A", ba.replicatorBToA)
+	ba.replayJournal(ba.journalB, "Backend B", ba.replicatorAToB)
 	return nil
 }
 
@@ -119,6 +230,19 @@ func (ba *mirroredBlobAccess) FindMissing(ctx context.Context, digests digest.Se
 	}()
 	resultsB := callFindMissing(ctx, ba.backendB, digests)
 	resultsA := <-resultsAChan
+
+	// In degraded mode, tolerate one of the backends being
+	// unavailable. As there is no way to synchronize blobs between
+	// backends, simply return the results of the other backend.
+	if resultsA.err == nil && ba.isUnavailable(resultsB.err) {
+		ba.replayJournal(ba.journalA, "Backend A", ba.replicatorBToA)
+		return resultsA.missing, nil
+	}
+	if resultsB.err == nil && ba.isUnavailable(resultsA.err) {
+		ba.replayJournal(ba.journalB, "Backend B", ba.replicatorAToB)
+		return resultsB.missing, nil
+	}
+
 	if resultsA.err != nil {
 		return digest.EmptySet, util.StatusWrap(resultsA.err, "Backend A")
 	}
@@ -144,11 +268,15 @@ func (ba *mirroredBlobAccess) FindMissing(ctx context.Context, digests digest.Se
 	if errBToA != nil {
 		return digest.EmptySet, util.StatusWrap(errBToA, "Failed to synchronize from backend B to backend A")
 	}
+	ba.replayJournal(ba.journalA, "Backend A", ba.replicatorBToA)
+	ba.replayJournal(ba.journalB, "Backend B", ba.replicatorAToB)
 	return missingFromBoth, nil
 }
 
 type mirroredErrorHandler struct {
+	blobAccess        *mirroredBlobAccess
 	firstBackendName  string
+	secondBackend     blobstore.BlobAccess
 	secondBackendName string
 	replicator        replication.BlobReplicator
 	context           context.Context
@@ -164,6 +292,15 @@ func (eh *mirroredErrorHandler) OnError(err error) (buffer.Buffer, error) {
 	// triggered the error.
 	if status.Code(err) != codes.NotFound {
 		if !eh.attemptedBothBackends() {
+			// In degraded mode, read the object from the
+			// other storage backend if the first one is
+			// unavailable. There is no point in attempting
+			// to repair the object.
+			if eh.blobAccess.isUnavailable(err) {
+				b := eh.secondBackend.Get(eh.context, eh.digest)
+				eh.replicator = nil
+				return b, nil
+			}
 			return nil, util.StatusWrap(err, eh.firstBackendName)
 		}
 		return nil, util.StatusWrap(err, eh.secondBackendName)
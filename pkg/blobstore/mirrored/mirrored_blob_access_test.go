@@ -3,12 +3,15 @@ package mirrored_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/buildbarn/bb-storage/internal/mock"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/mirrored"
+	"github.com/buildbarn/bb-storage/pkg/clock"
 	"github.com/buildbarn/bb-storage/pkg/digest"
 	"github.com/buildbarn/bb-storage/pkg/testutil"
+	"github.com/buildbarn/bb-storage/pkg/util"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
@@ -34,7 +37,7 @@ func TestMirroredBlobAccessGet(t *testing.T) {
 			backendA.EXPECT().Get(ctx, blobDigest).Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello world"))),
 		)
 
-		blobAccess := mirrored.NewMirroredBlobAccess(backendA, backendB, replicatorAToB, replicatorBToA)
+		blobAccess := mirrored.NewMirroredBlobAccess(backendA, backendB, replicatorAToB, replicatorBToA, 0, clock.SystemClock, util.DefaultErrorLogger)
 		for i := 0; i < 3; i++ {
 			data, err := blobAccess.Get(ctx, blobDigest).ToByteSlice(100)
 			require.NoError(t, err)
@@ -49,7 +52,7 @@ func TestMirroredBlobAccessGet(t *testing.T) {
 		backendA.EXPECT().Get(ctx, blobDigest).Return(buffer.NewBufferFromError(status.Error(codes.NotFound, "Blob not found")))
 		replicatorBToA.EXPECT().ReplicateSingle(ctx, blobDigest).Return(buffer.NewBufferFromError(status.Error(codes.NotFound, "Blob not found")))
 
-		blobAccess := mirrored.NewMirroredBlobAccess(backendA, backendB, replicatorAToB, replicatorBToA)
+		blobAccess := mirrored.NewMirroredBlobAccess(backendA, backendB, replicatorAToB, replicatorBToA, 0, clock.SystemClock, util.DefaultErrorLogger)
 		_, err := blobAccess.Get(ctx, blobDigest).ToByteSlice(100)
 		testutil.RequireEqualStatus(t, status.Error(codes.NotFound, "Blob not found"), err)
 	})
@@ -60,7 +63,7 @@ func TestMirroredBlobAccessGet(t *testing.T) {
 		backendA.EXPECT().Get(ctx, blobDigest).Return(buffer.NewBufferFromError(status.Error(codes.NotFound, "Blob not found")))
 		replicatorBToA.EXPECT().ReplicateSingle(ctx, blobDigest).Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello world")))
 
-		blobAccess := mirrored.NewMirroredBlobAccess(backendA, backendB, replicatorAToB, replicatorBToA)
+		blobAccess := mirrored.NewMirroredBlobAccess(backendA, backendB, replicatorAToB, replicatorBToA, 0, clock.SystemClock, util.DefaultErrorLogger)
 		data, err := blobAccess.Get(ctx, blobDigest).ToByteSlice(100)
 		require.NoError(t, err)
 		require.Equal(t, []byte("Hello world"), data)
@@ -71,7 +74,7 @@ func TestMirroredBlobAccessGet(t *testing.T) {
 
 		// In case of fatal errors, the name of the backend
 		// should be prepended.
-		blobAccess := mirrored.NewMirroredBlobAccess(backendA, backendB, replicatorAToB, replicatorBToA)
+		blobAccess := mirrored.NewMirroredBlobAccess(backendA, backendB, replicatorAToB, replicatorBToA, 0, clock.SystemClock, util.DefaultErrorLogger)
 		_, err := blobAccess.Get(ctx, blobDigest).ToByteSlice(100)
 		testutil.RequireEqualStatus(t, status.Error(codes.Internal, "Backend A: Server on fire"), err)
 	})
@@ -80,7 +83,7 @@ func TestMirroredBlobAccessGet(t *testing.T) {
 		backendA.EXPECT().Get(ctx, blobDigest).Return(buffer.NewBufferFromError(status.Error(codes.NotFound, "Blob not found")))
 		replicatorBToA.EXPECT().ReplicateSingle(ctx, blobDigest).Return(buffer.NewBufferFromError(status.Error(codes.Internal, "Server on fire")))
 
-		blobAccess := mirrored.NewMirroredBlobAccess(backendA, backendB, replicatorAToB, replicatorBToA)
+		blobAccess := mirrored.NewMirroredBlobAccess(backendA, backendB, replicatorAToB, replicatorBToA, 0, clock.SystemClock, util.DefaultErrorLogger)
 		_, err := blobAccess.Get(ctx, blobDigest).ToByteSlice(100)
 		testutil.RequireEqualStatus(t, status.Error(codes.Internal, "Backend B: Server on fire"), err)
 	})
@@ -94,7 +97,7 @@ func TestMirroredBlobAccessPut(t *testing.T) {
 	replicatorAToB := mock.NewMockBlobReplicator(ctrl)
 	replicatorBToA := mock.NewMockBlobReplicator(ctrl)
 	blobDigest := digest.MustNewDigest("default", "64ec88ca00b268e5ba1a35678a1b5316d212f4f366b2477232534a8aeca37f3c", 11)
-	blobAccess := mirrored.NewMirroredBlobAccess(backendA, backendB, replicatorAToB, replicatorBToA)
+	blobAccess := mirrored.NewMirroredBlobAccess(backendA, backendB, replicatorAToB, replicatorBToA, 0, clock.SystemClock, util.DefaultErrorLogger)
 
 	t.Run("Success", func(t *testing.T) {
 		backendA.EXPECT().Put(gomock.Any(), blobDigest, gomock.Any()).DoAndReturn(
@@ -168,7 +171,7 @@ func TestMirroredBlobAccessFindMissing(t *testing.T) {
 	onlyOnB := digestB.ToSingletonSet()
 	missingFromA := digest.NewSetBuilder().Add(digestNone).Add(digestB).Build()
 	missingFromB := digest.NewSetBuilder().Add(digestNone).Add(digestA).Build()
-	blobAccess := mirrored.NewMirroredBlobAccess(backendA, backendB, replicatorAToB, replicatorBToA)
+	blobAccess := mirrored.NewMirroredBlobAccess(backendA, backendB, replicatorAToB, replicatorBToA, 0, clock.SystemClock, util.DefaultErrorLogger)
 
 	t.Run("Success", func(t *testing.T) {
 		// Listings of both backends should be requested.
@@ -225,3 +228,176 @@ func TestMirroredBlobAccessFindMissing(t *testing.T) {
 		testutil.RequireEqualStatus(t, status.Error(codes.Internal, "Failed to synchronize from backend B to backend A: Server on fire"), err)
 	})
 }
+
+func TestMirroredBlobAccessDegradedMode(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	backendA := mock.NewMockBlobAccess(ctrl)
+	backendB := mock.NewMockBlobAccess(ctrl)
+	replicatorAToB := mock.NewMockBlobReplicator(ctrl)
+	replicatorBToA := mock.NewMockBlobReplicator(ctrl)
+	digest1 := digest.MustNewDigest("default", "64ec88ca00b268e5ba1a35678a1b5316d212f4f366b2477232534a8aeca37f3c", 11)
+	digest2 := digest.MustNewDigest("default", "c0535e4be2b79ffd93291305436bf889314e4a3faec05ecffcbb7df31ad9e51a", 12)
+
+	t.Run("GetUnavailable", func(t *testing.T) {
+		// If the first backend is unavailable, the object
+		// should be read from the second backend.
+		backendA.EXPECT().Get(ctx, digest1).Return(buffer.NewBufferFromError(status.Error(codes.Unavailable, "Server offline")))
+		backendB.EXPECT().Get(ctx, digest1).Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello world")))
+
+		blobAccess := mirrored.NewMirroredBlobAccess(backendA, backendB, replicatorAToB, replicatorBToA, 10, clock.SystemClock, util.DefaultErrorLogger)
+		data, err := blobAccess.Get(ctx, digest1).ToByteSlice(100)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello world"), data)
+	})
+
+	t.Run("GetUnavailableBoth", func(t *testing.T) {
+		backendA.EXPECT().Get(ctx, digest1).Return(buffer.NewBufferFromError(status.Error(codes.Unavailable, "Server offline")))
+		backendB.EXPECT().Get(ctx, digest1).Return(buffer.NewBufferFromError(status.Error(codes.Unavailable, "Server offline")))
+
+		blobAccess := mirrored.NewMirroredBlobAccess(backendA, backendB, replicatorAToB, replicatorBToA, 10, clock.SystemClock, util.DefaultErrorLogger)
+		_, err := blobAccess.Get(ctx, digest1).ToByteSlice(100)
+		testutil.RequireEqualStatus(t, status.Error(codes.Unavailable, "Backend B: Server offline"), err)
+	})
+
+	t.Run("PutUnavailableBoth", func(t *testing.T) {
+		// Degraded mode can only be used if at least one of
+		// the backends is available.
+		backendA.EXPECT().Put(ctx, digest1, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+				b.Discard()
+				return status.Error(codes.Unavailable, "Server offline")
+			})
+		backendB.EXPECT().Put(ctx, digest1, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+				b.Discard()
+				return status.Error(codes.Unavailable, "Server offline")
+			})
+
+		blobAccess := mirrored.NewMirroredBlobAccess(backendA, backendB, replicatorAToB, replicatorBToA, 10, clock.SystemClock, util.DefaultErrorLogger)
+		testutil.RequireEqualStatus(
+			t,
+			status.Error(codes.Unavailable, "Backend A: Server offline"),
+			blobAccess.Put(ctx, digest1, buffer.NewValidatedBufferFromByteSlice([]byte("Hello world"))))
+	})
+
+	t.Run("PutCatchUp", func(t *testing.T) {
+		blobAccess := mirrored.NewMirroredBlobAccess(backendA, backendB, replicatorAToB, replicatorBToA, 1, clock.SystemClock, util.DefaultErrorLogger)
+
+		// Write two objects while backend B is unavailable.
+		// Only the first one fits in the catch-up journal.
+		for _, blobDigest := range []digest.Digest{digest1, digest2} {
+			backendA.EXPECT().Put(ctx, blobDigest, gomock.Any()).DoAndReturn(
+				func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+					_, err := b.ToByteSlice(100)
+					return err
+				})
+			backendB.EXPECT().Put(ctx, blobDigest, gomock.Any()).DoAndReturn(
+				func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+					b.Discard()
+					return status.Error(codes.Unavailable, "Server offline")
+				})
+			require.NoError(t, blobAccess.Put(ctx, blobDigest, buffer.NewValidatedBufferFromByteSlice([]byte("Hello world"))))
+		}
+
+		// Once backend B becomes available again, the journal
+		// should be replayed in the background.
+		backendA.EXPECT().FindMissing(ctx, digest2.ToSingletonSet()).Return(digest.EmptySet, nil).Times(2)
+		backendB.EXPECT().FindMissing(ctx, digest2.ToSingletonSet()).Return(digest.EmptySet, nil).Times(2)
+		replicatorAToB.EXPECT().ReplicateMultiple(ctx, digest.EmptySet).Return(nil).Times(2)
+		replicatorBToA.EXPECT().ReplicateMultiple(ctx, digest.EmptySet).Return(nil).Times(2)
+		replicated := make(chan struct{})
+		replicatorAToB.EXPECT().ReplicateMultiple(gomock.Any(), digest1.ToSingletonSet()).DoAndReturn(
+			func(ctx context.Context, digests digest.Set) error {
+				close(replicated)
+				return nil
+			})
+
+		missing, err := blobAccess.FindMissing(ctx, digest2.ToSingletonSet())
+		require.NoError(t, err)
+		require.Equal(t, digest.EmptySet, missing)
+		<-replicated
+
+		// The journal should be empty afterwards, meaning
+		// subsequent requests don't trigger any replication.
+		missing, err = blobAccess.FindMissing(ctx, digest2.ToSingletonSet())
+		require.NoError(t, err)
+		require.Equal(t, digest.EmptySet, missing)
+	})
+
+	t.Run("PutCatchUpFailure", func(t *testing.T) {
+		clock := mock.NewMockClock(ctrl)
+		errorLogger := mock.NewMockErrorLogger(ctrl)
+		blobAccess := mirrored.NewMirroredBlobAccess(backendA, backendB, replicatorAToB, replicatorBToA, 10, clock, errorLogger)
+
+		// Write an object while backend B is unavailable.
+		backendA.EXPECT().Put(ctx, digest1, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+				_, err := b.ToByteSlice(100)
+				return err
+			})
+		backendB.EXPECT().Put(ctx, digest1, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+				b.Discard()
+				return status.Error(codes.Unavailable, "Server offline")
+			})
+		require.NoError(t, blobAccess.Put(ctx, digest1, buffer.NewValidatedBufferFromByteSlice([]byte("Hello world"))))
+
+		expectFindMissing := func() {
+			backendA.EXPECT().FindMissing(ctx, digest2.ToSingletonSet()).Return(digest.EmptySet, nil)
+			backendB.EXPECT().FindMissing(ctx, digest2.ToSingletonSet()).Return(digest.EmptySet, nil)
+			replicatorAToB.EXPECT().ReplicateMultiple(ctx, digest.EmptySet).Return(nil)
+			replicatorBToA.EXPECT().ReplicateMultiple(ctx, digest.EmptySet).Return(nil)
+		}
+
+		// Failures to replay the journal should be logged.
+		expectFindMissing()
+		replicatorAToB.EXPECT().ReplicateMultiple(gomock.Any(), digest1.ToSingletonSet()).
+			Return(status.Error(codes.Unavailable, "Server offline"))
+		errorLogger.EXPECT().Log(status.Error(codes.Unavailable, "Failed to replay catch-up journal of Backend B: Server offline"))
+		finished := make(chan struct{})
+		clock.EXPECT().Now().DoAndReturn(func() time.Time {
+			close(finished)
+			return time.Unix(1000, 0)
+		})
+
+		missing, err := blobAccess.FindMissing(ctx, digest2.ToSingletonSet())
+		require.NoError(t, err)
+		require.Equal(t, digest.EmptySet, missing)
+		<-finished
+
+		// The next attempt to replay the journal should only be
+		// made after a backoff period has passed.
+		expectFindMissing()
+		clock.EXPECT().Now().Return(time.Unix(1000, 999999999))
+		missing, err = blobAccess.FindMissing(ctx, digest2.ToSingletonSet())
+		require.NoError(t, err)
+		require.Equal(t, digest.EmptySet, missing)
+
+		expectFindMissing()
+		clock.EXPECT().Now().Return(time.Unix(1001, 0))
+		replicated := make(chan struct{})
+		replicatorAToB.EXPECT().ReplicateMultiple(gomock.Any(), digest1.ToSingletonSet()).DoAndReturn(
+			func(ctx context.Context, digests digest.Set) error {
+				close(replicated)
+				return nil
+			})
+		missing, err = blobAccess.FindMissing(ctx, digest2.ToSingletonSet())
+		require.NoError(t, err)
+		require.Equal(t, digest.EmptySet, missing)
+		<-replicated
+	})
+
+	t.Run("FindMissingUnavailable", func(t *testing.T) {
+		// If one of the backends is unavailable, the results
+		// of the other backend should be returned as is.
+		allDigests := digest.NewSetBuilder().Add(digest1).Add(digest2).Build()
+		backendA.EXPECT().FindMissing(ctx, allDigests).Return(digest.EmptySet, status.Error(codes.Unavailable, "Server offline"))
+		backendB.EXPECT().FindMissing(ctx, allDigests).Return(digest2.ToSingletonSet(), nil)
+
+		blobAccess := mirrored.NewMirroredBlobAccess(backendA, backendB, replicatorAToB, replicatorBToA, 10, clock.SystemClock, util.DefaultErrorLogger)
+		missing, err := blobAccess.FindMissing(ctx, allDigests)
+		require.NoError(t, err)
+		require.Equal(t, digest2.ToSingletonSet(), missing)
+	})
+}
@@ -0,0 +1,311 @@
+package quorum
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/buildbarn/bb-storage/pkg/atomic"
+	"github.com/buildbarn/bb-storage/pkg/blobstore"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/replication"
+	"github.com/buildbarn/bb-storage/pkg/capabilities"
+	"github.com/buildbarn/bb-storage/pkg/clock"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/util"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	quorumBlobAccessPrometheusMetrics sync.Once
+
+	quorumBlobAccessFindMissingSynchronizations = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "buildbarn",
+			Subsystem: "blobstore",
+			Name:      "quorum_blob_access_find_missing_synchronizations",
+			Help:      "Number of blobs synchronized in FindMissing()",
+			Buckets:   append([]float64{0}, prometheus.ExponentialBuckets(1.0, 2.0, 16)...),
+		})
+)
+
+type quorumBlobAccess struct {
+	capabilities.Provider
+
+	backends    []blobstore.BlobAccess
+	readQuorum  int
+	writeQuorum int
+	replicators [][]replication.BlobReplicator
+	errorLogger util.ErrorLogger
+	round       atomic.Uint32
+}
+
+// replicationTimeout is the maximum amount of time synchronizing blobs
+// between replicas in the background may take.
+const replicationTimeout = time.Minute
+
+// NewQuorumBlobAccess creates a BlobAccess that stores blobs in a list
+// of replicas. It generalizes MirroredBlobAccess to an arbitrary number
+// of backends, while tolerating the failure of some of them.
+//
+// Put() succeeds if the blob could be written to at least writeQuorum
+// backends. Get() tries backends in round robin order, until the blob
+// is found, or until readQuorum backends have reported that the blob
+// does not exist. FindMissing() requires at least readQuorum backends
+// to respond. Blobs are only reported as missing if they are absent
+// from all of these backends. Blobs that are only present in some of
+// the backends are replicated to the others in the background. Errors
+// that occur while doing so are reported through the ErrorLogger.
+//
+// Put() and FindMissing() return as soon as the quorum has been
+// reached. They do not wait for the remaining backends, whose calls
+// complete in the background for as long as the caller's context
+// permits.
+//
+// The caller must ensure that readQuorum + writeQuorum exceeds the
+// number of backends. This guarantees that every read quorum overlaps
+// with every write quorum. replicators[i][j] is used to copy blobs
+// from backends[i] to backends[j].
+//
+// The capabilities reported by this backend are the intersection of
+// those of all backends, as requests may be sent to any of them.
+func NewQuorumBlobAccess(backends []blobstore.BlobAccess, readQuorum, writeQuorum int, replicators [][]replication.BlobReplicator, errorLogger util.ErrorLogger) blobstore.BlobAccess {
+	quorumBlobAccessPrometheusMetrics.Do(func() {
+		prometheus.MustRegister(quorumBlobAccessFindMissingSynchronizations)
+	})
+
+	capabilitiesProviders := make([]capabilities.Provider, 0, len(backends))
+	for _, backend := range backends {
+		capabilitiesProviders = append(capabilitiesProviders, backend)
+	}
+	return &quorumBlobAccess{
+		Provider:    capabilities.NewIntersectingProvider(capabilitiesProviders, clock.SystemClock, time.Minute),
+		backends:    backends,
+		readQuorum:  readQuorum,
+		writeQuorum: writeQuorum,
+		replicators: replicators,
+		errorLogger: errorLogger,
+	}
+}
+
+func (ba *quorumBlobAccess) Get(ctx context.Context, digest digest.Digest) buffer.Buffer {
+	// Rotate between storage backends to spread the load equally.
+	firstIndex := int((ba.round.Add(1) - 1) % uint32(len(ba.backends)))
+	return buffer.WithErrorHandler(
+		ba.backends[firstIndex].Get(ctx, digest),
+		&quorumErrorHandler{
+			blobAccess: ba,
+			context:    ctx,
+			digest:     digest,
+			firstIndex: firstIndex,
+		})
+}
+
+// replicaResult is the outcome of a call against a single replica,
+// as reported to Put() and FindMissing() by the goroutines that call
+// into the backends.
+type replicaResult struct {
+	index   int
+	missing digest.Set
+	err     error
+}
+
+// getFirstError returns the error of the replica with the lowest index
+// that failed, annotated with that index.
+func getFirstError(errs []error) error {
+	for index, err := range errs {
+		if err != nil {
+			return util.StatusWrapf(err, "Replica %d", index)
+		}
+	}
+	return nil
+}
+
+func (ba *quorumBlobAccess) Put(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+	// Store the object in all backends. The results channel has
+	// enough capacity to hold all results, so that replicas that
+	// complete after we've returned don't block.
+	results := make(chan replicaResult, len(ba.backends))
+	for indexIter := range ba.backends {
+		index := indexIter
+		bReplica := b
+		if index < len(ba.backends)-1 {
+			b, bReplica = b.CloneStream()
+		}
+		go func() {
+			results <- replicaResult{
+				index: index,
+				err:   ba.backends[index].Put(ctx, digest, bReplica),
+			}
+		}()
+	}
+
+	// Return as soon as the write quorum has been reached, or
+	// when it can no longer be reached.
+	errs := make([]error, len(ba.backends))
+	successes, failures := 0, 0
+	for successes < ba.writeQuorum && len(ba.backends)-failures >= ba.writeQuorum {
+		result := <-results
+		if result.err == nil {
+			successes++
+		} else {
+			errs[result.index] = result.err
+			failures++
+		}
+	}
+	if successes < ba.writeQuorum {
+		return util.StatusWrapf(getFirstError(errs), "Write quorum not reached, as only %d of %d replicas succeeded", successes, ba.writeQuorum)
+	}
+	return nil
+}
+
+func (ba *quorumBlobAccess) FindMissing(ctx context.Context, digests digest.Set) (digest.Set, error) {
+	// Call FindMissing() on all backends.
+	results := make(chan replicaResult, len(ba.backends))
+	for indexIter, backendIter := range ba.backends {
+		index, backend := indexIter, backendIter
+		go func() {
+			missing, err := backend.FindMissing(ctx, digests)
+			results <- replicaResult{
+				index:   index,
+				missing: missing,
+				err:     err,
+			}
+		}()
+	}
+
+	// Only consider the results of backends that responded before
+	// the read quorum was reached. Fail if the read quorum cannot
+	// be reached.
+	missingPerBackend := make([]digest.Set, len(ba.backends))
+	respondingIndices := make([]int, 0, len(ba.backends))
+	errs := make([]error, len(ba.backends))
+	failures := 0
+	for len(respondingIndices) < ba.readQuorum && len(ba.backends)-failures >= ba.readQuorum {
+		result := <-results
+		if result.err == nil {
+			missingPerBackend[result.index] = result.missing
+			respondingIndices = append(respondingIndices, result.index)
+		} else {
+			errs[result.index] = result.err
+			failures++
+		}
+	}
+	if len(respondingIndices) < ba.readQuorum {
+		return digest.EmptySet, util.StatusWrapf(getFirstError(errs), "Read quorum not reached, as only %d of %d replicas succeeded", len(respondingIndices), ba.readQuorum)
+	}
+	sort.Ints(respondingIndices)
+
+	// Objects are only missing if none of the responding backends
+	// have them. Determine which objects need to be replicated
+	// from one backend to the other.
+	missingFromBackends := map[digest.Digest][]int{}
+	for _, index := range respondingIndices {
+		for _, blobDigest := range missingPerBackend[index].Items() {
+			missingFromBackends[blobDigest] = append(missingFromBackends[blobDigest], index)
+		}
+	}
+	missingFromAll := digest.NewSetBuilder()
+	replicationsPerPair := make([][]digest.SetBuilder, len(ba.backends))
+	synchronizations := 0
+	for blobDigest, missingIndices := range missingFromBackends {
+		if len(missingIndices) == len(respondingIndices) {
+			missingFromAll.Add(blobDigest)
+			continue
+		}
+		sourceIndex := getFirstIndexNotIn(respondingIndices, missingIndices)
+		if replicationsPerPair[sourceIndex] == nil {
+			replicationsPerPair[sourceIndex] = make([]digest.SetBuilder, 0, len(ba.backends))
+			for range ba.backends {
+				replicationsPerPair[sourceIndex] = append(replicationsPerPair[sourceIndex], digest.NewSetBuilder())
+			}
+		}
+		for _, sinkIndex := range missingIndices {
+			replicationsPerPair[sourceIndex][sinkIndex].Add(blobDigest)
+			synchronizations++
+		}
+	}
+	quorumBlobAccessFindMissingSynchronizations.Observe(float64(synchronizations))
+
+	// Replicate objects to lagging backends. As the objects are
+	// present in at least one of the backends, there is no need to
+	// let the caller wait for this to complete. Failures are not
+	// fatal, as the objects can still be read from the source.
+	for sourceIndexIter, replications := range replicationsPerPair {
+		for sinkIndexIter, digestsIter := range replications {
+			sourceIndex, sinkIndex, digests := sourceIndexIter, sinkIndexIter, digestsIter
+			if digests.Length() > 0 {
+				go func() {
+					ctx, cancel := context.WithTimeout(context.Background(), replicationTimeout)
+					defer cancel()
+					if err := ba.replicators[sourceIndex][sinkIndex].ReplicateMultiple(ctx, digests.Build()); err != nil {
+						ba.errorLogger.Log(util.StatusWrapf(err, "Failed to synchronize from replica %d to replica %d", sourceIndex, sinkIndex))
+					}
+				}()
+			}
+		}
+	}
+	return missingFromAll.Build(), nil
+}
+
+// getFirstIndexNotIn returns the first element of a list of backend
+// indices that is not part of another list of backend indices.
+func getFirstIndexNotIn(indices, excluded []int) int {
+	for _, index := range indices {
+		found := false
+		for _, excludedIndex := range excluded {
+			if index == excludedIndex {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return index
+		}
+	}
+	panic("All indices are excluded")
+}
+
+// quorumErrorHandler is used by QuorumBlobAccess.Get() to fall back to
+// other backends in case a blob cannot be read.
+type quorumErrorHandler struct {
+	blobAccess    *quorumBlobAccess
+	context       context.Context
+	digest        digest.Digest
+	firstIndex    int
+	attempts      int
+	notFoundCount int
+	firstErr      error
+}
+
+func (eh *quorumErrorHandler) OnError(err error) (buffer.Buffer, error) {
+	ba := eh.blobAccess
+	index := (eh.firstIndex + eh.attempts) % len(ba.backends)
+	eh.attempts++
+	if status.Code(err) == codes.NotFound {
+		// If a read quorum has determined that the object does
+		// not exist, there is no need to continue. At least
+		// one of these backends would have been part of the
+		// write quorum.
+		eh.notFoundCount++
+		if eh.notFoundCount >= ba.readQuorum {
+			return nil, err
+		}
+	} else if eh.firstErr == nil {
+		eh.firstErr = util.StatusWrapf(err, "Replica %d", index)
+	}
+
+	if eh.attempts >= len(ba.backends) {
+		// Backends either returned NOT_FOUND or failed, but
+		// not enough of them returned NOT_FOUND to be certain
+		// the object does not exist.
+		return nil, eh.firstErr
+	}
+	return ba.backends[(eh.firstIndex+eh.attempts)%len(ba.backends)].Get(eh.context, eh.digest), nil
+}
+
+func (eh *quorumErrorHandler) Done() {}
@@ -0,0 +1,308 @@
+package quorum_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/buildbarn/bb-storage/internal/mock"
+	"github.com/buildbarn/bb-storage/pkg/blobstore"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/quorum"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/replication"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/testutil"
+	"github.com/buildbarn/bb-storage/pkg/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestQuorumBlobAccessGet(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	backend0 := mock.NewMockBlobAccess(ctrl)
+	backend1 := mock.NewMockBlobAccess(ctrl)
+	backend2 := mock.NewMockBlobAccess(ctrl)
+	backends := []blobstore.BlobAccess{backend0, backend1, backend2}
+	blobDigest := digest.MustNewDigest("default", "64ec88ca00b268e5ba1a35678a1b5316d212f4f366b2477232534a8aeca37f3c", 11)
+
+	t.Run("Success", func(t *testing.T) {
+		// Requests should rotate between backends to spread
+		// the load between backends equally.
+		gomock.InOrder(
+			backend0.EXPECT().Get(ctx, blobDigest).Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello world"))),
+			backend1.EXPECT().Get(ctx, blobDigest).Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello world"))),
+			backend2.EXPECT().Get(ctx, blobDigest).Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello world"))),
+			backend0.EXPECT().Get(ctx, blobDigest).Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello world"))),
+		)
+
+		blobAccess := quorum.NewQuorumBlobAccess(backends, 2, 2, nil, util.DefaultErrorLogger)
+		for i := 0; i < 4; i++ {
+			data, err := blobAccess.Get(ctx, blobDigest).ToByteSlice(100)
+			require.NoError(t, err)
+			require.Equal(t, []byte("Hello world"), data)
+		}
+	})
+
+	t.Run("NotFoundQuorum", func(t *testing.T) {
+		// Once a read quorum of backends has reported that the
+		// object does not exist, there is no need to consult
+		// the remaining backends.
+		backend0.EXPECT().Get(ctx, blobDigest).Return(buffer.NewBufferFromError(status.Error(codes.NotFound, "Blob not found")))
+		backend1.EXPECT().Get(ctx, blobDigest).Return(buffer.NewBufferFromError(status.Error(codes.NotFound, "Blob not found")))
+
+		blobAccess := quorum.NewQuorumBlobAccess(backends, 2, 2, nil, util.DefaultErrorLogger)
+		_, err := blobAccess.Get(ctx, blobDigest).ToByteSlice(100)
+		testutil.RequireEqualStatus(t, status.Error(codes.NotFound, "Blob not found"), err)
+	})
+
+	t.Run("Fallback", func(t *testing.T) {
+		// Backends that don't have the object or are
+		// unavailable should be skipped.
+		backend0.EXPECT().Get(ctx, blobDigest).Return(buffer.NewBufferFromError(status.Error(codes.Unavailable, "Server offline")))
+		backend1.EXPECT().Get(ctx, blobDigest).Return(buffer.NewBufferFromError(status.Error(codes.NotFound, "Blob not found")))
+		backend2.EXPECT().Get(ctx, blobDigest).Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello world")))
+
+		blobAccess := quorum.NewQuorumBlobAccess(backends, 2, 2, nil, util.DefaultErrorLogger)
+		data, err := blobAccess.Get(ctx, blobDigest).ToByteSlice(100)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello world"), data)
+	})
+
+	t.Run("NoQuorum", func(t *testing.T) {
+		// If too many backends fail, it cannot be determined
+		// whether the object exists. The first error should be
+		// returned.
+		backend0.EXPECT().Get(ctx, blobDigest).Return(buffer.NewBufferFromError(status.Error(codes.Unavailable, "Server offline")))
+		backend1.EXPECT().Get(ctx, blobDigest).Return(buffer.NewBufferFromError(status.Error(codes.NotFound, "Blob not found")))
+		backend2.EXPECT().Get(ctx, blobDigest).Return(buffer.NewBufferFromError(status.Error(codes.Internal, "Server on fire")))
+
+		blobAccess := quorum.NewQuorumBlobAccess(backends, 2, 2, nil, util.DefaultErrorLogger)
+		_, err := blobAccess.Get(ctx, blobDigest).ToByteSlice(100)
+		testutil.RequireEqualStatus(t, status.Error(codes.Unavailable, "Replica 0: Server offline"), err)
+	})
+}
+
+func TestQuorumBlobAccessPut(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	backend0 := mock.NewMockBlobAccess(ctrl)
+	backend1 := mock.NewMockBlobAccess(ctrl)
+	backend2 := mock.NewMockBlobAccess(ctrl)
+	blobAccess := quorum.NewQuorumBlobAccess([]blobstore.BlobAccess{backend0, backend1, backend2}, 2, 2, nil, util.DefaultErrorLogger)
+	blobDigest := digest.MustNewDigest("default", "64ec88ca00b268e5ba1a35678a1b5316d212f4f366b2477232534a8aeca37f3c", 11)
+
+	// Put() may return before all backends have completed. Track
+	// outstanding calls, so that every test can wait for them. Calls
+	// may be delayed until others have completed, so that results
+	// are deterministic.
+	var outstanding sync.WaitGroup
+	expectPut := func(backend *mock.MockBlobAccess, err error, after <-chan struct{}) <-chan struct{} {
+		outstanding.Add(1)
+		done := make(chan struct{})
+		backend.EXPECT().Put(ctx, blobDigest, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+				defer outstanding.Done()
+				defer close(done)
+				if err == nil {
+					data, err := b.ToByteSlice(100)
+					require.NoError(t, err)
+					require.Equal(t, []byte("Hello world"), data)
+				} else {
+					b.Discard()
+				}
+				if after != nil {
+					<-after
+				}
+				return err
+			})
+		return done
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		expectPut(backend0, nil, nil)
+		expectPut(backend1, nil, nil)
+		expectPut(backend2, nil, nil)
+
+		require.NoError(t, blobAccess.Put(ctx, blobDigest, buffer.NewValidatedBufferFromByteSlice([]byte("Hello world"))))
+		outstanding.Wait()
+	})
+
+	t.Run("QuorumReached", func(t *testing.T) {
+		// Failures of a minority of backends should be
+		// tolerated.
+		expectPut(backend0, nil, nil)
+		expectPut(backend1, status.Error(codes.Unavailable, "Server offline"), nil)
+		expectPut(backend2, nil, nil)
+
+		require.NoError(t, blobAccess.Put(ctx, blobDigest, buffer.NewValidatedBufferFromByteSlice([]byte("Hello world"))))
+		outstanding.Wait()
+	})
+
+	t.Run("SlowReplica", func(t *testing.T) {
+		// Put() should return as soon as the write quorum has
+		// been reached, without waiting for the slowest
+		// backend to complete.
+		unblock := make(chan struct{})
+		expectPut(backend0, nil, nil)
+		expectPut(backend1, nil, unblock)
+		expectPut(backend2, nil, nil)
+
+		require.NoError(t, blobAccess.Put(ctx, blobDigest, buffer.NewValidatedBufferFromByteSlice([]byte("Hello world"))))
+		close(unblock)
+		outstanding.Wait()
+	})
+
+	t.Run("QuorumNotReached", func(t *testing.T) {
+		done1 := expectPut(backend1, nil, nil)
+		expectPut(backend0, status.Error(codes.Unavailable, "Server offline"), done1)
+		expectPut(backend2, status.Error(codes.Internal, "Server on fire"), done1)
+
+		testutil.RequireEqualStatus(
+			t,
+			status.Error(codes.Unavailable, "Write quorum not reached, as only 1 of 2 replicas succeeded: Replica 0: Server offline"),
+			blobAccess.Put(ctx, blobDigest, buffer.NewValidatedBufferFromByteSlice([]byte("Hello world"))))
+		outstanding.Wait()
+	})
+}
+
+func TestQuorumBlobAccessFindMissing(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	backend0 := mock.NewMockBlobAccess(ctrl)
+	backend1 := mock.NewMockBlobAccess(ctrl)
+	backend2 := mock.NewMockBlobAccess(ctrl)
+	replicator0To1 := mock.NewMockBlobReplicator(ctrl)
+	replicator0To2 := mock.NewMockBlobReplicator(ctrl)
+	replicator1To0 := mock.NewMockBlobReplicator(ctrl)
+	errorLogger := mock.NewMockErrorLogger(ctrl)
+	blobAccess := quorum.NewQuorumBlobAccess(
+		[]blobstore.BlobAccess{backend0, backend1, backend2},
+		2,
+		2,
+		[][]replication.BlobReplicator{
+			{nil, replicator0To1, replicator0To2},
+			{replicator1To0, nil, mock.NewMockBlobReplicator(ctrl)},
+			{mock.NewMockBlobReplicator(ctrl), mock.NewMockBlobReplicator(ctrl), nil},
+		},
+		errorLogger)
+
+	digest1 := digest.MustNewDigest("default", "00000000000000000000000000000001", 1)
+	digest2 := digest.MustNewDigest("default", "00000000000000000000000000000002", 2)
+	digest3 := digest.MustNewDigest("default", "00000000000000000000000000000003", 3)
+	allDigests := digest.NewSetBuilder().Add(digest1).Add(digest2).Add(digest3).Build()
+
+	// FindMissing() returns as soon as the read quorum has been
+	// reached. To obtain deterministic results, let backends
+	// respond only after the others have done so.
+	expectFindMissing := func(backend *mock.MockBlobAccess, missing digest.Set, err error, after <-chan struct{}) <-chan struct{} {
+		done := make(chan struct{})
+		backend.EXPECT().FindMissing(ctx, allDigests).DoAndReturn(
+			func(ctx context.Context, digests digest.Set) (digest.Set, error) {
+				if after != nil {
+					<-after
+				}
+				defer close(done)
+				return missing, err
+			})
+		return done
+	}
+	expectReplicateMultiple := func(replicator *mock.MockBlobReplicator, digests digest.Set, err error) <-chan struct{} {
+		done := make(chan struct{})
+		replicator.EXPECT().ReplicateMultiple(gomock.Any(), digests).DoAndReturn(
+			func(ctx context.Context, digests digest.Set) error {
+				close(done)
+				return err
+			})
+		return done
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		// Digest 1 is missing from all backends. Digest 2 is
+		// only missing from backend 0. Digest 3 is only
+		// present in backend 0. Backend 2 responds after the
+		// quorum has been reached, meaning its results are
+		// ignored.
+		unblock := make(chan struct{})
+		done0 := expectFindMissing(backend0, digest.NewSetBuilder().Add(digest1).Add(digest2).Build(), nil, nil)
+		expectFindMissing(backend1, digest.NewSetBuilder().Add(digest1).Add(digest3).Build(), nil, done0)
+		done2 := expectFindMissing(backend2, digest.NewSetBuilder().Add(digest1).Build(), nil, unblock)
+		replicated1To0 := expectReplicateMultiple(replicator1To0, digest2.ToSingletonSet(), nil)
+		replicated0To1 := expectReplicateMultiple(replicator0To1, digest3.ToSingletonSet(), nil)
+
+		missing, err := blobAccess.FindMissing(ctx, allDigests)
+		require.NoError(t, err)
+		require.Equal(t, digest1.ToSingletonSet(), missing)
+
+		close(unblock)
+		<-done2
+		<-replicated1To0
+		<-replicated0To1
+	})
+
+	t.Run("QuorumReached", func(t *testing.T) {
+		// Failures of a minority of backends should be
+		// tolerated. Their results should be ignored.
+		done2 := expectFindMissing(backend2, digest.EmptySet, status.Error(codes.Unavailable, "Server offline"), nil)
+		done0 := expectFindMissing(backend0, digest.NewSetBuilder().Add(digest1).Add(digest2).Build(), nil, done2)
+		expectFindMissing(backend1, digest.NewSetBuilder().Add(digest1).Build(), nil, done0)
+		replicated1To0 := expectReplicateMultiple(replicator1To0, digest2.ToSingletonSet(), nil)
+
+		missing, err := blobAccess.FindMissing(ctx, allDigests)
+		require.NoError(t, err)
+		require.Equal(t, digest1.ToSingletonSet(), missing)
+
+		<-replicated1To0
+	})
+
+	t.Run("MultipleSinks", func(t *testing.T) {
+		// Digest 3 is only present in backend 0, while backend
+		// 1 is unavailable. It should be replicated to backend
+		// 2.
+		done1 := expectFindMissing(backend1, digest.EmptySet, status.Error(codes.Unavailable, "Server offline"), nil)
+		done0 := expectFindMissing(backend0, digest.EmptySet, nil, done1)
+		expectFindMissing(backend2, digest3.ToSingletonSet(), nil, done0)
+		replicated0To2 := expectReplicateMultiple(replicator0To2, digest3.ToSingletonSet(), nil)
+
+		missing, err := blobAccess.FindMissing(ctx, allDigests)
+		require.NoError(t, err)
+		require.Equal(t, digest.EmptySet, missing)
+
+		<-replicated0To2
+	})
+
+	t.Run("QuorumNotReached", func(t *testing.T) {
+		done0 := expectFindMissing(backend0, digest.EmptySet, nil, nil)
+		expectFindMissing(backend1, digest.EmptySet, status.Error(codes.Unavailable, "Server offline"), done0)
+		expectFindMissing(backend2, digest.EmptySet, status.Error(codes.Unavailable, "Server offline"), done0)
+
+		_, err := blobAccess.FindMissing(ctx, allDigests)
+		testutil.RequireEqualStatus(t, status.Error(codes.Unavailable, "Read quorum not reached, as only 1 of 2 replicas succeeded: Replica 1: Server offline"), err)
+	})
+
+	t.Run("ReplicationFailure", func(t *testing.T) {
+		// Failures to replicate objects should not cause
+		// FindMissing() to fail, as the objects are still
+		// present in at least one backend.
+		unblock := make(chan struct{})
+		done0 := expectFindMissing(backend0, digest2.ToSingletonSet(), nil, nil)
+		expectFindMissing(backend1, digest.EmptySet, nil, done0)
+		done2 := expectFindMissing(backend2, digest.EmptySet, nil, unblock)
+		replicator1To0.EXPECT().ReplicateMultiple(gomock.Any(), digest2.ToSingletonSet()).
+			Return(status.Error(codes.Internal, "Server on fire"))
+		logged := make(chan struct{})
+		errorLogger.EXPECT().Log(status.Error(codes.Internal, "Failed to synchronize from replica 1 to replica 0: Server on fire")).
+			Do(func(err error) { close(logged) })
+
+		missing, err := blobAccess.FindMissing(ctx, allDigests)
+		require.NoError(t, err)
+		require.Equal(t, digest.EmptySet, missing)
+
+		close(unblock)
+		<-done2
+		<-logged
+	})
+}
@@ -0,0 +1,243 @@
+package blobstore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/buildbarn/bb-storage/pkg/clock"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RateLimits describes the limits that RateLimitingBlobAccess enforces
+// for all requests whose instance name matches a given prefix. Limits
+// that are set to zero are not enforced.
+type RateLimits struct {
+	InstanceNamePrefix   digest.InstanceName
+	RequestsPerSecond    float64
+	ReadBytesPerSecond   float64
+	WriteBytesPerSecond  float64
+	MaximumBlobSizeBytes int64
+}
+
+// tokenBucket implements the token bucket algorithm. The bucket is
+// capable of holding up to one second worth of tokens. Requests for
+// more tokens than this are permitted when the bucket is full, causing
+// the bucket to go into debt.
+type tokenBucket struct {
+	rate       float64
+	tokens     float64
+	lastUpdate time.Time
+}
+
+func (tb *tokenBucket) take(now time.Time, count float64) bool {
+	if tb.rate <= 0 {
+		return true
+	}
+	if elapsed := now.Sub(tb.lastUpdate).Seconds(); elapsed > 0 {
+		tb.tokens += elapsed * tb.rate
+		if tb.tokens > tb.rate {
+			tb.tokens = tb.rate
+		}
+		tb.lastUpdate = now
+	}
+	required := count
+	if required > tb.rate {
+		required = tb.rate
+	}
+	if tb.tokens < required {
+		return false
+	}
+	tb.tokens -= count
+	return true
+}
+
+type rateLimiter struct {
+	limits RateLimits
+
+	lock       sync.Mutex
+	requests   tokenBucket
+	readBytes  tokenBucket
+	writeBytes tokenBucket
+}
+
+func (rl *rateLimiter) checkBlobSize(sizeBytes int64) error {
+	if rl.limits.MaximumBlobSizeBytes > 0 && sizeBytes > rl.limits.MaximumBlobSizeBytes {
+		return status.Errorf(codes.ResourceExhausted, "Blob is %d bytes in size, while instance name prefix %#v is limited to %d bytes", sizeBytes, rl.limits.InstanceNamePrefix.String(), rl.limits.MaximumBlobSizeBytes)
+	}
+	return nil
+}
+
+func (rl *rateLimiter) takeRequestLocked(now time.Time) error {
+	if !rl.requests.take(now, 1) {
+		return status.Errorf(codes.ResourceExhausted, "Request rate limit of instance name prefix %#v exceeded", rl.limits.InstanceNamePrefix.String())
+	}
+	return nil
+}
+
+func (rl *rateLimiter) takeRequest(now time.Time) error {
+	rl.lock.Lock()
+	defer rl.lock.Unlock()
+	return rl.takeRequestLocked(now)
+}
+
+func (rl *rateLimiter) takeBytesLocked(now time.Time, bucket *tokenBucket, sizeBytes int64, direction string) error {
+	if !bucket.take(now, float64(sizeBytes)) {
+		return status.Errorf(codes.ResourceExhausted, "%s bandwidth limit of instance name prefix %#v exceeded", direction, rl.limits.InstanceNamePrefix.String())
+	}
+	return nil
+}
+
+// takeBytes only accounts for the bandwidth of a transfer. It is used
+// in case the request itself has already been accounted for.
+func (rl *rateLimiter) takeBytes(now time.Time, bucket *tokenBucket, sizeBytes int64, direction string) error {
+	if err := rl.checkBlobSize(sizeBytes); err != nil {
+		return err
+	}
+
+	rl.lock.Lock()
+	defer rl.lock.Unlock()
+	return rl.takeBytesLocked(now, bucket, sizeBytes, direction)
+}
+
+func (rl *rateLimiter) takeTransfer(now time.Time, bucket *tokenBucket, sizeBytes int64, direction string) error {
+	if err := rl.checkBlobSize(sizeBytes); err != nil {
+		return err
+	}
+
+	rl.lock.Lock()
+	defer rl.lock.Unlock()
+	if err := rl.takeRequestLocked(now); err != nil {
+		return err
+	}
+	return rl.takeBytesLocked(now, bucket, sizeBytes, direction)
+}
+
+type rateLimitingBlobAccess struct {
+	BlobAccess
+	clock                clock.Clock
+	instanceNamePrefixes *digest.InstanceNameTrie
+	rateLimiters         []*rateLimiter
+	contentAddressed     bool
+}
+
+// NewRateLimitingBlobAccess creates a decorator for BlobAccess that
+// enforces limits on the rate of requests, the bandwidth of reads and
+// writes, and the maximum size of objects. These limits are applied
+// per instance name prefix, which makes it possible to prevent a single
+// tenant from saturating storage that is shared with others. Requests
+// that exceed the limits fail with RESOURCE_EXHAUSTED.
+//
+// Limits are shared by all instance names matching a given prefix. If
+// multiple prefixes match, the longest one is used. Requests for
+// instance names that don't match any of the prefixes are not limited.
+//
+// If contentAddressed is set, the size of objects is obtained from
+// their digests. This permits calls to Get() to be rejected without
+// contacting the backend. Otherwise, the bandwidth of reads can only
+// be accounted for once the backend has returned the object.
+func NewRateLimitingBlobAccess(base BlobAccess, clock clock.Clock, limits []RateLimits, contentAddressed bool) BlobAccess {
+	ba := &rateLimitingBlobAccess{
+		BlobAccess:           base,
+		clock:                clock,
+		instanceNamePrefixes: digest.NewInstanceNameTrie(),
+		contentAddressed:     contentAddressed,
+	}
+	for i, l := range limits {
+		ba.instanceNamePrefixes.Set(l.InstanceNamePrefix, i)
+		ba.rateLimiters = append(ba.rateLimiters, &rateLimiter{
+			limits:     l,
+			requests:   tokenBucket{rate: l.RequestsPerSecond},
+			readBytes:  tokenBucket{rate: l.ReadBytesPerSecond},
+			writeBytes: tokenBucket{rate: l.WriteBytesPerSecond},
+		})
+	}
+	return ba
+}
+
+func (ba *rateLimitingBlobAccess) getRateLimiter(instanceName digest.InstanceName) *rateLimiter {
+	if idx := ba.instanceNamePrefixes.GetLongestPrefix(instanceName); idx >= 0 {
+		return ba.rateLimiters[idx]
+	}
+	return nil
+}
+
+func (ba *rateLimitingBlobAccess) Get(ctx context.Context, digest digest.Digest) buffer.Buffer {
+	rl := ba.getRateLimiter(digest.GetInstanceName())
+	if rl == nil {
+		return ba.BlobAccess.Get(ctx, digest)
+	}
+
+	if ba.contentAddressed {
+		// The size of the object is known up front, meaning the
+		// limits can be enforced without contacting the backend.
+		if err := rl.takeTransfer(ba.clock.Now(), &rl.readBytes, digest.GetSizeBytes(), "Read"); err != nil {
+			return buffer.NewBufferFromError(err)
+		}
+		return ba.BlobAccess.Get(ctx, digest)
+	}
+
+	// Count the request before contacting the backend, so that
+	// clients cannot issue requests for non-existent objects at an
+	// unlimited rate. The bandwidth can only be accounted for once
+	// the size of the object is known.
+	if err := rl.takeRequest(ba.clock.Now()); err != nil {
+		return buffer.NewBufferFromError(err)
+	}
+	b := ba.BlobAccess.Get(ctx, digest)
+	sizeBytes, err := b.GetSizeBytes()
+	if err != nil {
+		return b
+	}
+	if err := rl.takeBytes(ba.clock.Now(), &rl.readBytes, sizeBytes, "Read"); err != nil {
+		b.Discard()
+		return buffer.NewBufferFromError(err)
+	}
+	return b
+}
+
+func (ba *rateLimitingBlobAccess) Put(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+	rl := ba.getRateLimiter(digest.GetInstanceName())
+	if rl == nil {
+		return ba.BlobAccess.Put(ctx, digest, b)
+	}
+
+	sizeBytes, err := b.GetSizeBytes()
+	if err != nil {
+		b.Discard()
+		return err
+	}
+	if err := rl.takeTransfer(ba.clock.Now(), &rl.writeBytes, sizeBytes, "Write"); err != nil {
+		b.Discard()
+		return err
+	}
+	return ba.BlobAccess.Put(ctx, digest, b)
+}
+
+func (ba *rateLimitingBlobAccess) FindMissing(ctx context.Context, digests digest.Set) (digest.Set, error) {
+	// Count the request once against every instance name prefix
+	// for which digests are provided.
+	now := ba.clock.Now()
+	seenInstanceNames := map[digest.InstanceName]struct{}{}
+	seenRateLimiters := map[*rateLimiter]struct{}{}
+	for _, blobDigest := range digests.Items() {
+		instanceName := blobDigest.GetInstanceName()
+		if _, ok := seenInstanceNames[instanceName]; ok {
+			continue
+		}
+		seenInstanceNames[instanceName] = struct{}{}
+		if rl := ba.getRateLimiter(instanceName); rl != nil {
+			if _, ok := seenRateLimiters[rl]; !ok {
+				seenRateLimiters[rl] = struct{}{}
+				if err := rl.takeRequest(now); err != nil {
+					return digest.EmptySet, err
+				}
+			}
+		}
+	}
+	return ba.BlobAccess.FindMissing(ctx, digests)
+}
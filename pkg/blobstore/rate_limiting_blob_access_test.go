@@ -0,0 +1,167 @@
+package blobstore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/buildbarn/bb-storage/internal/mock"
+	"github.com/buildbarn/bb-storage/pkg/blobstore"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/testutil"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRateLimitingBlobAccess(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	baseBlobAccess := mock.NewMockBlobAccess(ctrl)
+	clock := mock.NewMockClock(ctrl)
+	blobAccess := blobstore.NewRateLimitingBlobAccess(baseBlobAccess, clock, []blobstore.RateLimits{
+		{
+			InstanceNamePrefix: digest.MustNewInstanceName("teams/a"),
+			RequestsPerSecond:  2,
+		},
+		{
+			InstanceNamePrefix:   digest.MustNewInstanceName("teams/b"),
+			ReadBytesPerSecond:   10,
+			WriteBytesPerSecond:  10,
+			MaximumBlobSizeBytes: 20,
+		},
+	}, true)
+
+	t.Run("Unlimited", func(t *testing.T) {
+		// Instance names that don't match any prefix should not
+		// be subject to any limits.
+		blobDigest := digest.MustNewDigest("teams/c", "8b1a9953c4611296a827abf8c47804d7", 5)
+		baseBlobAccess.EXPECT().Get(ctx, blobDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello"))).
+			Times(5)
+
+		for i := 0; i < 5; i++ {
+			data, err := blobAccess.Get(ctx, blobDigest).ToByteSlice(1000)
+			require.NoError(t, err)
+			require.Equal(t, []byte("Hello"), data)
+		}
+	})
+
+	t.Run("RequestRate", func(t *testing.T) {
+		// Requests against instance names matching the same
+		// prefix share the same limit.
+		digest1 := digest.MustNewDigest("teams/a/project1", "8b1a9953c4611296a827abf8c47804d7", 5)
+		digest2 := digest.MustNewDigest("teams/a/project2", "8b1a9953c4611296a827abf8c47804d7", 5)
+		clock.EXPECT().Now().Return(time.Unix(1000, 0)).Times(3)
+		baseBlobAccess.EXPECT().FindMissing(ctx, digest1.ToSingletonSet()).Return(digest.EmptySet, nil)
+		baseBlobAccess.EXPECT().FindMissing(ctx, digest2.ToSingletonSet()).Return(digest.EmptySet, nil)
+
+		_, err := blobAccess.FindMissing(ctx, digest1.ToSingletonSet())
+		require.NoError(t, err)
+		_, err = blobAccess.FindMissing(ctx, digest2.ToSingletonSet())
+		require.NoError(t, err)
+		_, err = blobAccess.FindMissing(ctx, digest1.ToSingletonSet())
+		testutil.RequireEqualStatus(t, status.Error(codes.ResourceExhausted, "Request rate limit of instance name prefix \"teams/a\" exceeded"), err)
+
+		// Tokens should be replenished over time.
+		clock.EXPECT().Now().Return(time.Unix(1000, 500000000))
+		baseBlobAccess.EXPECT().FindMissing(ctx, digest1.ToSingletonSet()).Return(digest.EmptySet, nil)
+		_, err = blobAccess.FindMissing(ctx, digest1.ToSingletonSet())
+		require.NoError(t, err)
+	})
+
+	t.Run("MaximumBlobSize", func(t *testing.T) {
+		blobDigest := digest.MustNewDigest("teams/b", "3538d378083b9afa5ffad767f7269509", 21)
+		clock.EXPECT().Now().Return(time.Unix(1500, 0))
+
+		testutil.RequireEqualStatus(
+			t,
+			status.Error(codes.ResourceExhausted, "Blob is 21 bytes in size, while instance name prefix \"teams/b\" is limited to 20 bytes"),
+			blobAccess.Put(ctx, blobDigest, buffer.NewValidatedBufferFromByteSlice(make([]byte, 21))))
+	})
+
+	t.Run("MaximumBlobSizeGet", func(t *testing.T) {
+		// The size of objects in the CAS is known up front,
+		// meaning the backend should not be contacted for
+		// objects that are too large.
+		blobDigest := digest.MustNewDigest("teams/b", "3538d378083b9afa5ffad767f7269509", 21)
+		clock.EXPECT().Now().Return(time.Unix(1500, 0))
+
+		_, err := blobAccess.Get(ctx, blobDigest).ToByteSlice(1000)
+		testutil.RequireEqualStatus(t, status.Error(codes.ResourceExhausted, "Blob is 21 bytes in size, while instance name prefix \"teams/b\" is limited to 20 bytes"), err)
+	})
+
+	t.Run("ReadBandwidth", func(t *testing.T) {
+		// The first read may exceed the bandwidth, as the bucket
+		// is full. The next read should be rejected until the
+		// debt has been paid off.
+		largeDigest := digest.MustNewDigest("teams/b", "3538d378083b9afa5ffad767f7269509", 15)
+		clock.EXPECT().Now().Return(time.Unix(2000, 0))
+		baseBlobAccess.EXPECT().Get(ctx, largeDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice(make([]byte, 15)))
+		_, err := blobAccess.Get(ctx, largeDigest).ToByteSlice(1000)
+		require.NoError(t, err)
+
+		helloDigest := digest.MustNewDigest("teams/b", "8b1a9953c4611296a827abf8c47804d7", 5)
+		clock.EXPECT().Now().Return(time.Unix(2000, 800000000))
+		_, err = blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		testutil.RequireEqualStatus(t, status.Error(codes.ResourceExhausted, "Read bandwidth limit of instance name prefix \"teams/b\" exceeded"), err)
+
+		// Writes use a separate bucket.
+		clock.EXPECT().Now().Return(time.Unix(2000, 900000000))
+		baseBlobAccess.EXPECT().Put(ctx, helloDigest, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+				b.Discard()
+				return nil
+			})
+		require.NoError(t, blobAccess.Put(ctx, helloDigest, buffer.NewValidatedBufferFromByteSlice([]byte("Hello"))))
+
+		clock.EXPECT().Now().Return(time.Unix(2001, 0))
+		baseBlobAccess.EXPECT().Get(ctx, helloDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello")))
+		data, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+	})
+}
+
+func TestRateLimitingBlobAccessNotContentAddressed(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	baseBlobAccess := mock.NewMockBlobAccess(ctrl)
+	clock := mock.NewMockClock(ctrl)
+	blobAccess := blobstore.NewRateLimitingBlobAccess(baseBlobAccess, clock, []blobstore.RateLimits{
+		{
+			InstanceNamePrefix: digest.MustNewInstanceName("teams/a"),
+			RequestsPerSecond:  2,
+			ReadBytesPerSecond: 10,
+		},
+	}, false)
+
+	blobDigest := digest.MustNewDigest("teams/a", "8b1a9953c4611296a827abf8c47804d7", 5)
+
+	// The size of objects is not known up front. The bandwidth
+	// should be accounted for once the backend returns the object.
+	clock.EXPECT().Now().Return(time.Unix(1000, 0)).Times(2)
+	baseBlobAccess.EXPECT().Get(ctx, blobDigest).
+		Return(buffer.NewValidatedBufferFromByteSlice(make([]byte, 15)))
+	_, err := blobAccess.Get(ctx, blobDigest).ToByteSlice(1000)
+	require.NoError(t, err)
+
+	// The bandwidth debt of the previous read should cause the
+	// next read to be rejected.
+	clock.EXPECT().Now().Return(time.Unix(1000, 100000000)).Times(2)
+	baseBlobAccess.EXPECT().Get(ctx, blobDigest).
+		Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello")))
+	_, err = blobAccess.Get(ctx, blobDigest).ToByteSlice(1000)
+	testutil.RequireEqualStatus(t, status.Error(codes.ResourceExhausted, "Read bandwidth limit of instance name prefix \"teams/a\" exceeded"), err)
+
+	// The request rate should be enforced without contacting the
+	// backend.
+	clock.EXPECT().Now().Return(time.Unix(1000, 200000000))
+	_, err = blobAccess.Get(ctx, blobDigest).ToByteSlice(1000)
+	testutil.RequireEqualStatus(t, status.Error(codes.ResourceExhausted, "Request rate limit of instance name prefix \"teams/a\" exceeded"), err)
+}
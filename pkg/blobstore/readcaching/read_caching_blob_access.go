@@ -3,6 +3,7 @@ package readcaching
 import (
 	"context"
 
+	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
 	"github.com/buildbarn/bb-storage/pkg/blobstore"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/replication"
@@ -49,6 +50,10 @@ func (ba *readCachingBlobAccess) FindMissing(ctx context.Context, digests digest
 	return ba.slow.FindMissing(ctx, digests)
 }
 
+func (ba *readCachingBlobAccess) GetCapabilities(ctx context.Context, instanceName digest.InstanceName) (*remoteexecution.ServerCapabilities, error) {
+	return ba.slow.GetCapabilities(ctx, instanceName)
+}
+
 type readCachingErrorHandler struct {
 	replicator replication.BlobReplicator
 	context    context.Context
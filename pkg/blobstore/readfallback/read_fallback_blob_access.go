@@ -3,6 +3,7 @@ package readfallback
 import (
 	"context"
 
+	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
 	"github.com/buildbarn/bb-storage/pkg/blobstore"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/replication"
@@ -64,6 +65,10 @@ func (ba *readFallbackBlobAccess) FindMissing(ctx context.Context, digests diges
 	return missingInBoth, nil
 }
 
+func (ba *readFallbackBlobAccess) GetCapabilities(ctx context.Context, instanceName digest.InstanceName) (*remoteexecution.ServerCapabilities, error) {
+	return ba.primary.GetCapabilities(ctx, instanceName)
+}
+
 type readFallbackErrorHandler struct {
 	replicator replication.BlobReplicator
 	context    context.Context
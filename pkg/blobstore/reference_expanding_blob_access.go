@@ -11,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/buildbarn/bb-storage/pkg/capabilities"
 	cloud_aws "github.com/buildbarn/bb-storage/pkg/cloud/aws"
 	"github.com/buildbarn/bb-storage/pkg/digest"
 	"github.com/buildbarn/bb-storage/pkg/proto/icas"
@@ -22,6 +23,8 @@ import (
 )
 
 type referenceExpandingBlobAccess struct {
+	capabilities.Provider
+
 	blobAccess              BlobAccess
 	httpClient              *http.Client
 	s3Client                cloud_aws.S3Client
@@ -42,8 +45,12 @@ func getHTTPRangeHeader(reference *icas.Reference) string {
 // Storage (CAS) backend. Any object requested through this BlobAccess
 // will cause its reference to be loaded from the ICAS, followed by
 // fetching its data from the referenced location.
-func NewReferenceExpandingBlobAccess(blobAccess BlobAccess, httpClient *http.Client, s3Client cloud_aws.S3Client, maximumMessageSizeBytes int) BlobAccess {
+//
+// As the ICAS backend is incapable of reporting the capabilities of
+// the resulting CAS, these need to be provided separately.
+func NewReferenceExpandingBlobAccess(blobAccess BlobAccess, httpClient *http.Client, s3Client cloud_aws.S3Client, maximumMessageSizeBytes int, capabilitiesProvider capabilities.Provider) BlobAccess {
 	return &referenceExpandingBlobAccess{
+		Provider:                capabilitiesProvider,
 		blobAccess:              blobAccess,
 		httpClient:              httpClient,
 		s3Client:                s3Client,
@@ -34,7 +34,8 @@ func TestReferenceExpandingBlobAccessGet(t *testing.T) {
 		baseBlobAccess,
 		&http.Client{Transport: roundTripper},
 		s3Client,
-		100)
+		100,
+		nil)
 	helloDigest := digest.MustNewDigest("instance", "8b1a9953c4611296a827abf8c47804d7", 5)
 
 	t.Run("BackendError", func(t *testing.T) {
@@ -333,7 +334,8 @@ func TestReferenceExpandingBlobAccessPut(t *testing.T) {
 		baseBlobAccess,
 		&http.Client{Transport: roundTripper},
 		s3Client,
-		100)
+		100,
+		nil)
 
 	t.Run("Failure", func(t *testing.T) {
 		// It is not possible to write objects using
@@ -362,7 +364,8 @@ func TestReferenceExpandingBlobAccessFindMissing(t *testing.T) {
 		baseBlobAccess,
 		&http.Client{Transport: roundTripper},
 		s3Client,
-		100)
+		100,
+		nil)
 
 	digests := digest.NewSetBuilder().
 		Add(digest.MustNewDigest("instance", "8b1a9953c4611296a827abf8c47804d7", 5)).
@@ -0,0 +1,188 @@
+package blobstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/buildbarn/bb-storage/pkg/clock"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/random"
+	"github.com/buildbarn/bb-storage/pkg/util"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type retryingBlobAccess struct {
+	BlobAccess
+	clock                    clock.Clock
+	randomNumberGenerator    random.ThreadSafeGenerator
+	maximumAttempts          int
+	initialBackoff           time.Duration
+	maximumBackoff           time.Duration
+	maximumPutRetrySizeBytes int64
+	circuitBreaker           CircuitBreaker
+}
+
+// NewRetryingBlobAccess creates a decorator for BlobAccess that retries
+// operations that fail with UNAVAILABLE. Between attempts, it waits
+// for an exponentially increasing amount of time, to which jitter is
+// applied.
+//
+// Get() is retried through Buffer's ErrorHandler. This means that
+// transfers of CAS objects that fail after data has already been
+// returned are resumed at the offset at which they failed. FindMissing()
+// is always retried. As Put() consumes the provided buffer, it is only
+// retried for objects up to maximumPutRetrySizeBytes in size, which
+// are held in memory for the duration of the call. This option should
+// only be enabled for storage types whose objects are immutable, such
+// as the Content Addressable Storage (CAS).
+//
+// All requests are subject to a CircuitBreaker, allowing requests to
+// fail quickly while the backend is known to be unavailable.
+func NewRetryingBlobAccess(base BlobAccess, clock clock.Clock, randomNumberGenerator random.ThreadSafeGenerator, maximumAttempts int, initialBackoff, maximumBackoff time.Duration, maximumPutRetrySizeBytes int64, circuitBreaker CircuitBreaker) BlobAccess {
+	return &retryingBlobAccess{
+		BlobAccess:               base,
+		clock:                    clock,
+		randomNumberGenerator:    randomNumberGenerator,
+		maximumAttempts:          maximumAttempts,
+		initialBackoff:           initialBackoff,
+		maximumBackoff:           maximumBackoff,
+		maximumPutRetrySizeBytes: maximumPutRetrySizeBytes,
+		circuitBreaker:           circuitBreaker,
+	}
+}
+
+// shouldRetry returns whether an operation that failed with a given
+// error after a number of attempts should be attempted once more.
+func (ba *retryingBlobAccess) shouldRetry(err error, attempts int) bool {
+	return status.Code(err) == codes.Unavailable && attempts < ba.maximumAttempts
+}
+
+// waitBeforeRetry sleeps before performing the next attempt of an
+// operation. The amount of time doubles for every attempt, and is
+// randomized to lie between 50% and 100% of that value, so that
+// clients don't retry in lockstep.
+func (ba *retryingBlobAccess) waitBeforeRetry(ctx context.Context, attempts int) error {
+	backoff := ba.initialBackoff
+	for i := 1; i < attempts && backoff < ba.maximumBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > ba.maximumBackoff {
+		backoff = ba.maximumBackoff
+	}
+	backoff -= time.Duration(ba.randomNumberGenerator.Int63n(int64(backoff/2) + 1))
+
+	timer, timerChannel := ba.clock.NewTimer(backoff)
+	select {
+	case <-timerChannel:
+		return nil
+	case <-ctx.Done():
+		timer.Stop()
+		return util.StatusFromContext(ctx)
+	}
+}
+
+// retry calls a function until it succeeds, fails with an error that
+// cannot be retried, or the maximum number of attempts is reached.
+func (ba *retryingBlobAccess) retry(ctx context.Context, f func() error) error {
+	for attempts := 1; ; attempts++ {
+		if err := ba.circuitBreaker.Allow(); err != nil {
+			return err
+		}
+		err := f()
+		ba.circuitBreaker.ReportResult(err)
+		if !ba.shouldRetry(err, attempts) {
+			return err
+		}
+		if err := ba.waitBeforeRetry(ctx, attempts); err != nil {
+			return err
+		}
+	}
+}
+
+func (ba *retryingBlobAccess) Get(ctx context.Context, digest digest.Digest) buffer.Buffer {
+	if err := ba.circuitBreaker.Allow(); err != nil {
+		return buffer.NewBufferFromError(err)
+	}
+	return buffer.WithErrorHandler(
+		ba.BlobAccess.Get(ctx, digest),
+		&retryingErrorHandler{
+			blobAccess: ba,
+			context:    ctx,
+			digest:     digest,
+			attempts:   1,
+			pending:    true,
+		})
+}
+
+func (ba *retryingBlobAccess) Put(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+	if sizeBytes := digest.GetSizeBytes(); ba.maximumPutRetrySizeBytes <= 0 || sizeBytes > ba.maximumPutRetrySizeBytes {
+		// Object cannot be retained, meaning only a single
+		// attempt can be made.
+		if err := ba.circuitBreaker.Allow(); err != nil {
+			b.Discard()
+			return err
+		}
+		err := ba.BlobAccess.Put(ctx, digest, b)
+		ba.circuitBreaker.ReportResult(err)
+		return err
+	}
+
+	data, err := b.ToByteSlice(int(ba.maximumPutRetrySizeBytes))
+	if err != nil {
+		return err
+	}
+	return ba.retry(ctx, func() error {
+		return ba.BlobAccess.Put(ctx, digest, buffer.NewValidatedBufferFromByteSlice(data))
+	})
+}
+
+func (ba *retryingBlobAccess) FindMissing(ctx context.Context, digests digest.Set) (digest.Set, error) {
+	var missing digest.Set
+	if err := ba.retry(ctx, func() error {
+		var err error
+		missing, err = ba.BlobAccess.FindMissing(ctx, digests)
+		return err
+	}); err != nil {
+		return digest.EmptySet, err
+	}
+	return missing, nil
+}
+
+type retryingErrorHandler struct {
+	blobAccess *retryingBlobAccess
+	context    context.Context
+	digest     digest.Digest
+	attempts   int
+
+	// Whether the outcome of the most recent attempt still needs
+	// to be reported to the circuit breaker.
+	pending bool
+}
+
+func (eh *retryingErrorHandler) OnError(err error) (buffer.Buffer, error) {
+	ba := eh.blobAccess
+	ba.circuitBreaker.ReportResult(err)
+	eh.pending = false
+	if !ba.shouldRetry(err, eh.attempts) {
+		return nil, err
+	}
+	if err := ba.waitBeforeRetry(eh.context, eh.attempts); err != nil {
+		return nil, err
+	}
+	if err := ba.circuitBreaker.Allow(); err != nil {
+		return nil, err
+	}
+	eh.attempts++
+	eh.pending = true
+	return ba.BlobAccess.Get(eh.context, eh.digest), nil
+}
+
+func (eh *retryingErrorHandler) Done() {
+	// Report success if the last attempt did not fail.
+	if eh.pending {
+		eh.blobAccess.circuitBreaker.ReportResult(nil)
+	}
+}
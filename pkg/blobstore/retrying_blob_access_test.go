@@ -0,0 +1,171 @@
+package blobstore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/buildbarn/bb-storage/internal/mock"
+	"github.com/buildbarn/bb-storage/pkg/blobstore"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/random"
+	"github.com/buildbarn/bb-storage/pkg/testutil"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryingBlobAccess(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	baseBlobAccess := mock.NewMockBlobAccess(ctrl)
+	clock := mock.NewMockClock(ctrl)
+	blobAccess := blobstore.NewRetryingBlobAccess(
+		baseBlobAccess,
+		clock,
+		random.FastThreadSafeGenerator,
+		/* maximumAttempts = */ 3,
+		/* initialBackoff = */ time.Second,
+		/* maximumBackoff = */ 10*time.Second,
+		/* maximumPutRetrySizeBytes = */ 100,
+		blobstore.NoCircuitBreaker)
+
+	helloDigest := digest.MustNewDigest("example", "8b1a9953c4611296a827abf8c47804d7", 5)
+	largeDigest := digest.MustNewDigest("example", "3538d378083b9afa5ffad767f7269509", 1000)
+
+	// Backoff should double for every attempt, and have jitter
+	// applied to it.
+	expectBackoff := func(minimum, maximum time.Duration) {
+		clock.EXPECT().NewTimer(gomock.Any()).DoAndReturn(func(d time.Duration) (*mock.MockTimer, <-chan time.Time) {
+			require.LessOrEqual(t, int64(minimum), int64(d))
+			require.LessOrEqual(t, int64(d), int64(maximum))
+			timerChannel := make(chan time.Time, 1)
+			timerChannel <- time.Unix(0, 0)
+			return mock.NewMockTimer(ctrl), timerChannel
+		})
+	}
+
+	t.Run("GetSuccessAfterRetry", func(t *testing.T) {
+		gomock.InOrder(
+			baseBlobAccess.EXPECT().Get(ctx, helloDigest).
+				Return(buffer.NewBufferFromError(status.Error(codes.Unavailable, "Server offline"))),
+			baseBlobAccess.EXPECT().Get(ctx, helloDigest).
+				Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello"))))
+		expectBackoff(500*time.Millisecond, time.Second)
+
+		data, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+	})
+
+	t.Run("GetNonRetryableError", func(t *testing.T) {
+		baseBlobAccess.EXPECT().Get(ctx, helloDigest).
+			Return(buffer.NewBufferFromError(status.Error(codes.NotFound, "Object not found")))
+
+		_, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		testutil.RequireEqualStatus(t, status.Error(codes.NotFound, "Object not found"), err)
+	})
+
+	t.Run("FindMissingAttemptsExhausted", func(t *testing.T) {
+		baseBlobAccess.EXPECT().FindMissing(ctx, helloDigest.ToSingletonSet()).
+			Return(digest.EmptySet, status.Error(codes.Unavailable, "Server offline")).
+			Times(3)
+		expectBackoff(500*time.Millisecond, time.Second)
+		expectBackoff(time.Second, 2*time.Second)
+
+		_, err := blobAccess.FindMissing(ctx, helloDigest.ToSingletonSet())
+		testutil.RequireEqualStatus(t, status.Error(codes.Unavailable, "Server offline"), err)
+	})
+
+	t.Run("PutSuccessAfterRetry", func(t *testing.T) {
+		// Small objects should be retained in memory, so that
+		// they can be written once more.
+		baseBlobAccess.EXPECT().Put(ctx, helloDigest, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+				data, err := b.ToByteSlice(1000)
+				require.NoError(t, err)
+				require.Equal(t, []byte("Hello"), data)
+				return status.Error(codes.Unavailable, "Server offline")
+			})
+		expectBackoff(500*time.Millisecond, time.Second)
+		baseBlobAccess.EXPECT().Put(ctx, helloDigest, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+				data, err := b.ToByteSlice(1000)
+				require.NoError(t, err)
+				require.Equal(t, []byte("Hello"), data)
+				return nil
+			})
+
+		require.NoError(t, blobAccess.Put(ctx, helloDigest, buffer.NewValidatedBufferFromByteSlice([]byte("Hello"))))
+	})
+
+	t.Run("PutLargeObject", func(t *testing.T) {
+		// Large objects cannot be retained, meaning only a
+		// single attempt is made.
+		baseBlobAccess.EXPECT().Put(ctx, largeDigest, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+				b.Discard()
+				return status.Error(codes.Unavailable, "Server offline")
+			})
+
+		testutil.RequireEqualStatus(
+			t,
+			status.Error(codes.Unavailable, "Server offline"),
+			blobAccess.Put(ctx, largeDigest, buffer.NewValidatedBufferFromByteSlice(make([]byte, 1000))))
+	})
+}
+
+func TestRetryingBlobAccessCircuitBreaker(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	baseBlobAccess := mock.NewMockBlobAccess(ctrl)
+	clock := mock.NewMockClock(ctrl)
+	blobAccess := blobstore.NewRetryingBlobAccess(
+		baseBlobAccess,
+		clock,
+		random.FastThreadSafeGenerator,
+		/* maximumAttempts = */ 1,
+		/* initialBackoff = */ time.Second,
+		/* maximumBackoff = */ 10*time.Second,
+		/* maximumPutRetrySizeBytes = */ 0,
+		blobstore.NewConsecutiveFailuresCircuitBreaker(clock, 2, time.Minute, "cas", "grpc"))
+
+	helloDigest := digest.MustNewDigest("example", "8b1a9953c4611296a827abf8c47804d7", 5)
+
+	// Repeated failures should cause the circuit breaker to open,
+	// causing requests to fail without contacting the backend.
+	baseBlobAccess.EXPECT().Get(ctx, helloDigest).
+		Return(buffer.NewBufferFromError(status.Error(codes.Unavailable, "Server offline"))).
+		Times(2)
+	clock.EXPECT().Now().Return(time.Unix(1000, 0))
+	for i := 0; i < 2; i++ {
+		_, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		testutil.RequireEqualStatus(t, status.Error(codes.Unavailable, "Server offline"), err)
+	}
+
+	clock.EXPECT().Now().Return(time.Unix(1030, 0))
+	_, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+	testutil.RequireEqualStatus(t, status.Error(codes.Unavailable, "Circuit breaker is open after 2 consecutive failures"), err)
+
+	clock.EXPECT().Now().Return(time.Unix(1031, 0))
+	_, err = blobAccess.FindMissing(ctx, helloDigest.ToSingletonSet())
+	testutil.RequireEqualStatus(t, status.Error(codes.Unavailable, "Circuit breaker is open after 2 consecutive failures"), err)
+
+	// Once the circuit breaker is half-open, a successful request
+	// should close it.
+	clock.EXPECT().Now().Return(time.Unix(1060, 0))
+	baseBlobAccess.EXPECT().Get(ctx, helloDigest).
+		Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello")))
+	data, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+	require.NoError(t, err)
+	require.Equal(t, []byte("Hello"), data)
+
+	baseBlobAccess.EXPECT().FindMissing(ctx, helloDigest.ToSingletonSet()).
+		Return(digest.EmptySet, nil)
+	missing, err := blobAccess.FindMissing(ctx, helloDigest.ToSingletonSet())
+	require.NoError(t, err)
+	require.Equal(t, digest.EmptySet, missing)
+}
@@ -0,0 +1,86 @@
+package sharding
+
+import (
+	"sync"
+	"time"
+
+	"github.com/buildbarn/bb-storage/pkg/clock"
+)
+
+type backoffShardState struct {
+	consecutiveFailures int
+	unhealthyUntil      time.Time
+	nextBackoff         time.Duration
+}
+
+type backoffShardHealthTracker struct {
+	clock                      clock.Clock
+	maximumConsecutiveFailures int
+	minimumBackoff             time.Duration
+	maximumBackoff             time.Duration
+
+	lock   sync.Mutex
+	shards []backoffShardState
+}
+
+// NewBackoffShardHealthTracker creates a ShardHealthTracker that marks
+// backends as unhealthy after they have failed a given number of
+// consecutive requests. Backends remain unhealthy for a back-off
+// period, after which requests are sent to them again. Every time a
+// backend becomes unhealthy without having processed a request
+// successfully in the meantime, the back-off period is doubled, up to
+// a given maximum.
+func NewBackoffShardHealthTracker(clock clock.Clock, shardCount, maximumConsecutiveFailures int, minimumBackoff, maximumBackoff time.Duration) ShardHealthTracker {
+	shards := make([]backoffShardState, shardCount)
+	for i := range shards {
+		shards[i].nextBackoff = minimumBackoff
+	}
+	return &backoffShardHealthTracker{
+		clock:                      clock,
+		maximumConsecutiveFailures: maximumConsecutiveFailures,
+		minimumBackoff:             minimumBackoff,
+		maximumBackoff:             maximumBackoff,
+		shards:                     shards,
+	}
+}
+
+func (ht *backoffShardHealthTracker) IsHealthy(index int) bool {
+	ht.lock.Lock()
+	defer ht.lock.Unlock()
+
+	return !ht.clock.Now().Before(ht.shards[index].unhealthyUntil)
+}
+
+func (ht *backoffShardHealthTracker) ReportSuccess(index int) {
+	ht.lock.Lock()
+	defer ht.lock.Unlock()
+
+	s := &ht.shards[index]
+	s.consecutiveFailures = 0
+	s.nextBackoff = ht.minimumBackoff
+}
+
+func (ht *backoffShardHealthTracker) ReportFailure(index int) {
+	ht.lock.Lock()
+	defer ht.lock.Unlock()
+
+	// Don't extend the back-off period of backends that are
+	// already unhealthy, as failures may still be reported by
+	// requests that were issued before the backend was marked
+	// unhealthy.
+	s := &ht.shards[index]
+	now := ht.clock.Now()
+	if now.Before(s.unhealthyUntil) {
+		return
+	}
+
+	s.consecutiveFailures++
+	if s.consecutiveFailures >= ht.maximumConsecutiveFailures {
+		s.consecutiveFailures = 0
+		s.unhealthyUntil = now.Add(s.nextBackoff)
+		s.nextBackoff *= 2
+		if s.nextBackoff > ht.maximumBackoff {
+			s.nextBackoff = ht.maximumBackoff
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package sharding_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/buildbarn/bb-storage/internal/mock"
+	"github.com/buildbarn/bb-storage/pkg/blobstore/sharding"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoffShardHealthTracker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	clock := mock.NewMockClock(ctrl)
+	healthTracker := sharding.NewBackoffShardHealthTracker(clock, 2, 2, 10*time.Second, 30*time.Second)
+
+	// Shards should initially be healthy.
+	clock.EXPECT().Now().Return(time.Unix(1000, 0))
+	require.True(t, healthTracker.IsHealthy(0))
+
+	// A single failure should not cause the shard to become
+	// unhealthy, especially if it's followed by a success.
+	clock.EXPECT().Now().Return(time.Unix(1001, 0))
+	healthTracker.ReportFailure(0)
+	healthTracker.ReportSuccess(0)
+	clock.EXPECT().Now().Return(time.Unix(1002, 0))
+	healthTracker.ReportFailure(0)
+	clock.EXPECT().Now().Return(time.Unix(1003, 0))
+	require.True(t, healthTracker.IsHealthy(0))
+
+	// A second consecutive failure should cause the shard to
+	// become unhealthy for the minimum back-off period. Other
+	// shards should not be affected.
+	clock.EXPECT().Now().Return(time.Unix(1004, 0))
+	healthTracker.ReportFailure(0)
+	clock.EXPECT().Now().Return(time.Unix(1005, 0))
+	require.False(t, healthTracker.IsHealthy(0))
+	clock.EXPECT().Now().Return(time.Unix(1005, 0))
+	require.True(t, healthTracker.IsHealthy(1))
+
+	// Failures reported while unhealthy should be ignored.
+	clock.EXPECT().Now().Return(time.Unix(1006, 0))
+	healthTracker.ReportFailure(0)
+	clock.EXPECT().Now().Return(time.Unix(1013, 0))
+	require.False(t, healthTracker.IsHealthy(0))
+	clock.EXPECT().Now().Return(time.Unix(1014, 0))
+	require.True(t, healthTracker.IsHealthy(0))
+
+	// Repeated failures should double the back-off period, up to
+	// the configured maximum.
+	clock.EXPECT().Now().Return(time.Unix(1020, 0))
+	healthTracker.ReportFailure(0)
+	clock.EXPECT().Now().Return(time.Unix(1020, 0))
+	healthTracker.ReportFailure(0)
+	clock.EXPECT().Now().Return(time.Unix(1039, 0))
+	require.False(t, healthTracker.IsHealthy(0))
+	clock.EXPECT().Now().Return(time.Unix(1040, 0))
+	require.True(t, healthTracker.IsHealthy(0))
+
+	clock.EXPECT().Now().Return(time.Unix(1040, 0))
+	healthTracker.ReportFailure(0)
+	clock.EXPECT().Now().Return(time.Unix(1040, 0))
+	healthTracker.ReportFailure(0)
+	clock.EXPECT().Now().Return(time.Unix(1069, 0))
+	require.False(t, healthTracker.IsHealthy(0))
+	clock.EXPECT().Now().Return(time.Unix(1070, 0))
+	require.True(t, healthTracker.IsHealthy(0))
+
+	// A success should reset the back-off period.
+	healthTracker.ReportSuccess(0)
+	clock.EXPECT().Now().Return(time.Unix(1080, 0))
+	healthTracker.ReportFailure(0)
+	clock.EXPECT().Now().Return(time.Unix(1080, 0))
+	healthTracker.ReportFailure(0)
+	clock.EXPECT().Now().Return(time.Unix(1089, 0))
+	require.False(t, healthTracker.IsHealthy(0))
+	clock.EXPECT().Now().Return(time.Unix(1090, 0))
+	require.True(t, healthTracker.IsHealthy(0))
+}
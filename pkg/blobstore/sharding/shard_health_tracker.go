@@ -0,0 +1,18 @@
+package sharding
+
+// ShardHealthTracker keeps track of which backends used by
+// ShardingBlobAccess are healthy. Backends that are unhealthy are
+// treated as if they were drained, causing their key space to be
+// spread out across the other backends.
+type ShardHealthTracker interface {
+	// IsHealthy returns whether requests may be sent to the
+	// backend with a given index.
+	IsHealthy(index int) bool
+	// ReportSuccess is called when a request against the backend
+	// with a given index completed, meaning the backend is
+	// reachable.
+	ReportSuccess(index int)
+	// ReportFailure is called when a request against the backend
+	// with a given index failed with code UNAVAILABLE.
+	ReportFailure(index int)
+}
@@ -2,33 +2,88 @@ package sharding
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/buildbarn/bb-storage/pkg/blobstore"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/buildbarn/bb-storage/pkg/capabilities"
+	"github.com/buildbarn/bb-storage/pkg/clock"
 	"github.com/buildbarn/bb-storage/pkg/digest"
 	"github.com/buildbarn/bb-storage/pkg/util"
 
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type shardingBlobAccess struct {
+	capabilities.Provider
+
 	backends           []blobstore.BlobAccess
 	shardPermuter      ShardPermuter
 	hashInitialization uint64
+	replicationFactor  int
+	readRepair         bool
+	healthTracker      ShardHealthTracker
+	errorLogger        util.ErrorLogger
+
+	repairsLock       sync.Mutex
+	repairsInProgress map[digest.Digest]struct{}
 }
 
+// readRepairTimeout is the maximum amount of time copying a blob
+// between shards as part of FindMissing() may take.
+const readRepairTimeout = time.Minute
+
 // NewShardingBlobAccess is an adapter for BlobAccess that partitions
 // requests across backends by hashing the digest. A ShardPermuter is
 // used to map hashes to backends.
-func NewShardingBlobAccess(backends []blobstore.BlobAccess, shardPermuter ShardPermuter, hashInitialization uint64) blobstore.BlobAccess {
+//
+// Every blob is stored in the first replicationFactor undrained
+// backends returned by the ShardPermuter. Reads are attempted against
+// these backends in order. If readRepair is set, blobs are copied into
+// backends that are found not to contain them, as long as another
+// backend still holds a copy. Copying performed as part of
+// FindMissing() happens asynchronously, with failures being reported
+// through the ErrorLogger. The caller must ensure that
+// replicationFactor does not exceed the number of undrained backends.
+//
+// If a ShardHealthTracker is provided, backends that fail with
+// UNAVAILABLE are reported to it, and backends that it considers
+// unhealthy are temporarily treated as if they were drained. Get() and
+// FindMissing() requests that fail with UNAVAILABLE are retried
+// against the backends that take over the key space. This is not done
+// for Put(), as the data to be written has already been consumed.
+//
+// The capabilities reported by this backend are the intersection of
+// those of all undrained backends, as any object may be stored in any
+// of them.
+func NewShardingBlobAccess(backends []blobstore.BlobAccess, shardPermuter ShardPermuter, hashInitialization uint64, replicationFactor int, readRepair bool, healthTracker ShardHealthTracker, errorLogger util.ErrorLogger) blobstore.BlobAccess {
+	var capabilitiesProviders []capabilities.Provider
+	for _, backend := range backends {
+		if backend != nil {
+			capabilitiesProviders = append(capabilitiesProviders, backend)
+		}
+	}
 	return &shardingBlobAccess{
+		Provider:           capabilities.NewIntersectingProvider(capabilitiesProviders, clock.SystemClock, time.Minute),
 		backends:           backends,
 		shardPermuter:      shardPermuter,
 		hashInitialization: hashInitialization,
+		replicationFactor:  replicationFactor,
+		readRepair:         readRepair,
+		healthTracker:      healthTracker,
+		errorLogger:        errorLogger,
+		repairsInProgress:  map[digest.Digest]struct{}{},
 	}
 }
 
-func (ba *shardingBlobAccess) getBackendIndex(blobDigest digest.Digest) int {
+// getBackendIndices returns the indices of the backends in which a
+// blob is stored, in the order in which they should be consulted.
+// Backends that are part of the exclusion list are skipped. The
+// resulting list may be empty if all backends are excluded.
+func (ba *shardingBlobAccess) getBackendIndices(blobDigest digest.Digest, excluded []int) []int {
 	// Hash the key using FNV-1a.
 	h := ba.hashInitialization
 	for _, c := range blobDigest.GetKey(digest.KeyWithoutInstance) {
@@ -36,75 +91,330 @@ func (ba *shardingBlobAccess) getBackendIndex(blobDigest digest.Digest) int {
 		h *= 1099511628211
 	}
 
-	// Keep requesting shards until matching one that is undrained.
-	var selectedIndex int
+	// Keep requesting shards until having obtained enough distinct
+	// ones that are undrained and healthy, or until all shards
+	// have been observed. The permuter is permitted to return the
+	// same index multiple times.
+	selectedIndices := make([]int, 0, ba.replicationFactor)
+	var unhealthyIndices []int
+	observed := make([]bool, len(ba.backends))
+	observedCount := 0
 	ba.shardPermuter.GetShard(h, func(index int) bool {
-		if ba.backends[index] == nil {
-			return true
+		if !observed[index] {
+			observed[index] = true
+			observedCount++
+			if ba.backends[index] != nil && !containsIndex(excluded, index) {
+				if ba.healthTracker == nil || ba.healthTracker.IsHealthy(index) {
+					selectedIndices = append(selectedIndices, index)
+				} else {
+					unhealthyIndices = append(unhealthyIndices, index)
+				}
+			}
 		}
-		selectedIndex = index
-		return false
+		return len(selectedIndices) < ba.replicationFactor && observedCount < len(ba.backends)
 	})
-	return selectedIndex
+
+	// If there are not enough healthy shards, fall back to using
+	// unhealthy ones. This prevents requests from failing
+	// entirely during network partitions.
+	for len(selectedIndices) < ba.replicationFactor && len(unhealthyIndices) > 0 {
+		selectedIndices = append(selectedIndices, unhealthyIndices[0])
+		unhealthyIndices = unhealthyIndices[1:]
+	}
+	return selectedIndices
+}
+
+// reportResult forwards the outcome of a request against a backend to
+// the ShardHealthTracker. It returns whether the request should be
+// retried against another backend.
+func (ba *shardingBlobAccess) reportResult(index int, err error) bool {
+	if ba.healthTracker == nil {
+		return false
+	}
+	if err == nil {
+		ba.healthTracker.ReportSuccess(index)
+		return false
+	}
+	if status.Code(err) == codes.Unavailable {
+		ba.healthTracker.ReportFailure(index)
+		return true
+	}
+	return false
 }
 
 func (ba *shardingBlobAccess) Get(ctx context.Context, digest digest.Digest) buffer.Buffer {
-	index := ba.getBackendIndex(digest)
+	indices := ba.getBackendIndices(digest, nil)
 	return buffer.WithErrorHandler(
-		ba.backends[index].Get(ctx, digest),
-		shardIndexAddingErrorHandler{index: index})
+		ba.backends[indices[0]].Get(ctx, digest),
+		&shardingErrorHandler{
+			blobAccess: ba,
+			context:    ctx,
+			digest:     digest,
+			indices:    indices,
+			index:      indices[0],
+		})
 }
 
-func (ba *shardingBlobAccess) Put(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
-	index := ba.getBackendIndex(digest)
-	if err := ba.backends[index].Put(ctx, digest, b); err != nil {
+func (ba *shardingBlobAccess) putToShard(ctx context.Context, index int, digest digest.Digest, b buffer.Buffer) error {
+	err := ba.backends[index].Put(ctx, digest, b)
+	ba.reportResult(index, err)
+	if err != nil {
 		return util.StatusWrapf(err, "Shard %d", index)
 	}
 	return nil
 }
 
+func (ba *shardingBlobAccess) Put(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+	indices := ba.getBackendIndices(digest, nil)
+	if len(indices) == 1 {
+		return ba.putToShard(ctx, indices[0], digest, b)
+	}
+
+	// Store the object in all shards.
+	var group errgroup.Group
+	for _, indexIter := range indices[:len(indices)-1] {
+		index := indexIter
+		var bReplica buffer.Buffer
+		b, bReplica = b.CloneStream()
+		group.Go(func() error {
+			return ba.putToShard(ctx, index, digest, bReplica)
+		})
+	}
+	lastIndex := indices[len(indices)-1]
+	group.Go(func() error {
+		return ba.putToShard(ctx, lastIndex, digest, b)
+	})
+	return group.Wait()
+}
+
 func (ba *shardingBlobAccess) FindMissing(ctx context.Context, digests digest.Set) (digest.Set, error) {
-	// Partition all digests by shard.
-	digestsPerBackend := make([]digest.SetBuilder, 0, len(ba.backends))
-	for range ba.backends {
-		digestsPerBackend = append(digestsPerBackend, digest.NewSetBuilder())
-	}
-	for _, blobDigest := range digests.Items() {
-		digestsPerBackend[ba.getBackendIndex(blobDigest)].Add(blobDigest)
-	}
-
-	// Asynchronously call FindMissing() on backends.
-	missingPerBackend := make([]digest.Set, 0, len(ba.backends))
-	group, ctxWithCancel := errgroup.WithContext(ctx)
-	for indexIter, digestsIter := range digestsPerBackend {
-		index, digests := indexIter, digestsIter
-		if digests.Length() > 0 {
-			missingPerBackend = append(missingPerBackend, digest.EmptySet)
-			missingOut := &missingPerBackend[len(missingPerBackend)-1]
-			group.Go(func() error {
-				missing, err := ba.backends[index].FindMissing(ctxWithCancel, digests.Build())
-				if err != nil {
-					return util.StatusWrapf(err, "Shard %d", index)
+	var unavailableIndices []int
+	for {
+		// Partition all digests by shard. When replication is
+		// enabled, digests are sent to every shard in which
+		// they are stored.
+		digestsPerBackend := make([]digest.SetBuilder, 0, len(ba.backends))
+		for range ba.backends {
+			digestsPerBackend = append(digestsPerBackend, digest.NewSetBuilder())
+		}
+		indicesPerDigest := make(map[digest.Digest][]int, digests.Length())
+		for _, blobDigest := range digests.Items() {
+			indices := ba.getBackendIndices(blobDigest, unavailableIndices)
+			if len(indices) == 0 {
+				return digest.EmptySet, status.Error(codes.Unavailable, "All shards are unavailable")
+			}
+			indicesPerDigest[blobDigest] = indices
+			for _, index := range indices {
+				digestsPerBackend[index].Add(blobDigest)
+			}
+		}
+
+		// Asynchronously call FindMissing() on backends.
+		// Shards that are unavailable are not reported through
+		// the errgroup, as that would cancel the requests
+		// against the other shards.
+		missingPerBackend := make([]digest.Set, len(ba.backends))
+		retryPerBackend := make([]bool, len(ba.backends))
+		group, ctxWithCancel := errgroup.WithContext(ctx)
+		for indexIter, digestsIter := range digestsPerBackend {
+			index, digests := indexIter, digestsIter
+			if digests.Length() > 0 {
+				group.Go(func() error {
+					missing, err := ba.backends[index].FindMissing(ctxWithCancel, digests.Build())
+					if ba.reportResult(index, err) {
+						retryPerBackend[index] = true
+						return nil
+					}
+					if err != nil {
+						return util.StatusWrapf(err, "Shard %d", index)
+					}
+					missingPerBackend[index] = missing
+					return nil
+				})
+			}
+		}
+		if err := group.Wait(); err != nil {
+			return digest.EmptySet, err
+		}
+
+		// If one or more shards were unavailable, repeat the
+		// process with the key space of those shards spread
+		// out across the remaining ones. Every iteration
+		// excludes at least one more shard, meaning this
+		// process terminates.
+		retry := false
+		for index, r := range retryPerBackend {
+			if r {
+				unavailableIndices = append(unavailableIndices, index)
+				retry = true
+			}
+		}
+		if !retry {
+			return ba.combineFindMissingResults(missingPerBackend, indicesPerDigest), nil
+		}
+	}
+}
+
+func (ba *shardingBlobAccess) combineFindMissingResults(missingPerBackend []digest.Set, indicesPerDigest map[digest.Digest][]int) digest.Set {
+	if ba.replicationFactor <= 1 {
+		return digest.GetUnion(missingPerBackend)
+	}
+
+	// Objects are only missing if they are absent from all shards
+	// in which they should be stored.
+	missingFromShards := make(map[digest.Digest][]int, len(indicesPerDigest))
+	for index, missing := range missingPerBackend {
+		for _, blobDigest := range missing.Items() {
+			missingFromShards[blobDigest] = append(missingFromShards[blobDigest], index)
+		}
+	}
+	missingFromAll := digest.NewSetBuilder()
+	for blobDigest, missingIndices := range missingFromShards {
+		indices := indicesPerDigest[blobDigest]
+		if len(missingIndices) == len(indices) {
+			missingFromAll.Add(blobDigest)
+		} else if ba.readRepair {
+			// The object is only present in some of the
+			// shards. Copy it into the ones lacking it.
+			ba.repairInBackground(blobDigest, getFirstIndexNotIn(indices, missingIndices), missingIndices)
+		}
+	}
+	return missingFromAll.Build()
+}
+
+// repairInBackground copies a blob from one shard into one or more
+// shards lacking it. Copying is performed asynchronously, so that the
+// latency of FindMissing() is not affected. Failures are not fatal, as
+// the blob is still present in the source shard.
+func (ba *shardingBlobAccess) repairInBackground(blobDigest digest.Digest, sourceIndex int, sinkIndices []int) {
+	// Don't copy blobs for which a repair is already in progress,
+	// as clients tend to call FindMissing() repeatedly.
+	ba.repairsLock.Lock()
+	if _, ok := ba.repairsInProgress[blobDigest]; ok {
+		ba.repairsLock.Unlock()
+		return
+	}
+	ba.repairsInProgress[blobDigest] = struct{}{}
+	ba.repairsLock.Unlock()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), readRepairTimeout)
+		defer cancel()
+
+		var wg sync.WaitGroup
+		for _, sinkIndexIter := range sinkIndices {
+			sinkIndex := sinkIndexIter
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := ba.backends[sinkIndex].Put(
+					ctx,
+					blobDigest,
+					ba.backends[sourceIndex].Get(ctx, blobDigest),
+				); err != nil {
+					ba.errorLogger.Log(util.StatusWrapf(err, "Failed to replicate %s from shard %d to shard %d", blobDigest, sourceIndex, sinkIndex))
 				}
-				*missingOut = missing
-				return nil
-			})
+			}()
+		}
+		wg.Wait()
+
+		ba.repairsLock.Lock()
+		delete(ba.repairsInProgress, blobDigest)
+		ba.repairsLock.Unlock()
+	}()
+}
+
+// containsIndex returns whether a list of shard indices contains a
+// given index.
+func containsIndex(indices []int, index int) bool {
+	for _, i := range indices {
+		if i == index {
+			return true
 		}
 	}
+	return false
+}
 
-	// Recombine results.
-	if err := group.Wait(); err != nil {
-		return digest.EmptySet, err
+// getFirstIndexNotIn returns the first element of a list of shard
+// indices that is not part of another list of shard indices.
+func getFirstIndexNotIn(indices, excluded []int) int {
+	for _, index := range indices {
+		if !containsIndex(excluded, index) {
+			return index
+		}
 	}
-	return digest.GetUnion(missingPerBackend), nil
+	panic("All indices are excluded")
 }
 
-type shardIndexAddingErrorHandler struct {
-	index int
+// shardingErrorHandler is used by ShardingBlobAccess.Get() to prefix
+// errors with the index of the shard that generated them. When
+// replication is enabled, it falls back to subsequent shards in case
+// the object cannot be found. When health tracking is enabled, it
+// falls back to other shards in case a shard is unavailable.
+type shardingErrorHandler struct {
+	blobAccess         *shardingBlobAccess
+	context            context.Context
+	digest             digest.Digest
+	indices            []int
+	index              int
+	notFoundIndices    []int
+	unavailableIndices []int
+	failed             bool
 }
 
-func (eh shardIndexAddingErrorHandler) OnError(err error) (buffer.Buffer, error) {
-	return nil, util.StatusWrapf(err, "Shard %d", eh.index)
+func (eh *shardingErrorHandler) OnError(err error) (buffer.Buffer, error) {
+	ba := eh.blobAccess
+	if ba.reportResult(eh.index, err) {
+		// Shard is unavailable. Recompute the list of shards
+		// in which the object is stored, as other shards take
+		// over its key space.
+		eh.unavailableIndices = append(eh.unavailableIndices, eh.index)
+		eh.indices = ba.getBackendIndices(eh.digest, eh.unavailableIndices)
+	} else if status.Code(err) == codes.NotFound {
+		eh.notFoundIndices = append(eh.notFoundIndices, eh.index)
+	} else {
+		eh.failed = true
+		return nil, util.StatusWrapf(err, "Shard %d", eh.index)
+	}
+
+	// Consult the next shard that has not been attempted yet.
+	nextIndex := -1
+	for _, index := range eh.indices {
+		if !containsIndex(eh.notFoundIndices, index) {
+			nextIndex = index
+			break
+		}
+	}
+	if nextIndex < 0 {
+		eh.failed = status.Code(err) != codes.NotFound
+		return nil, util.StatusWrapf(err, "Shard %d", eh.index)
+	}
+	eh.index = nextIndex
+	b := ba.backends[nextIndex].Get(eh.context, eh.digest)
+
+	// If read repair is enabled, copy the object into the shards
+	// that were attempted previously.
+	if ba.readRepair {
+		for _, sinkIndexIter := range eh.notFoundIndices {
+			sinkIndex := sinkIndexIter
+			var bReplica buffer.Buffer
+			b, bReplica = b.CloneStream()
+			var t *buffer.BackgroundTask
+			b, t = buffer.WithBackgroundTask(b)
+			go func() {
+				err := ba.backends[sinkIndex].Put(eh.context, eh.digest, bReplica)
+				if err != nil {
+					err = util.StatusWrapf(err, "Replication to shard %d failed", sinkIndex)
+				}
+				t.Finish(err)
+			}()
+		}
+	}
+	return b, nil
 }
 
-func (eh shardIndexAddingErrorHandler) Done() {}
+func (eh *shardingErrorHandler) Done() {
+	if !eh.failed {
+		eh.blobAccess.reportResult(eh.index, nil)
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"testing"
 
+	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
 	"github.com/buildbarn/bb-storage/internal/mock"
 	"github.com/buildbarn/bb-storage/pkg/blobstore"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
 	"github.com/buildbarn/bb-storage/pkg/blobstore/sharding"
 	"github.com/buildbarn/bb-storage/pkg/digest"
 	"github.com/buildbarn/bb-storage/pkg/testutil"
+	"github.com/buildbarn/bb-storage/pkg/util"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
@@ -27,7 +29,7 @@ func TestShardingBlobAccess(t *testing.T) {
 		shard0,
 		shard1,
 		nil, // Shard that is explicitly drained.
-	}, shardPermuter, 0x62994904405896a1)
+	}, shardPermuter, 0x62994904405896a1, 1, false, nil, util.DefaultErrorLogger)
 
 	helloDigest := digest.MustNewDigest("example", "8b1a9953c4611296a827abf8c47804d7", 5)
 
@@ -168,4 +170,434 @@ func TestShardingBlobAccess(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, digest.NewSetBuilder().Add(digest1).Add(digest3).Build(), missing)
 	})
+
+	t.Run("GetCapabilities", func(t *testing.T) {
+		// Capabilities should be intersected across all
+		// undrained shards.
+		shard0.EXPECT().GetCapabilities(ctx, digest.MustNewInstanceName("example")).
+			Return(&remoteexecution.ServerCapabilities{
+				CacheCapabilities: &remoteexecution.CacheCapabilities{
+					DigestFunctions: []remoteexecution.DigestFunction_Value{
+						remoteexecution.DigestFunction_MD5,
+						remoteexecution.DigestFunction_SHA256,
+					},
+				},
+			}, nil)
+		shard1.EXPECT().GetCapabilities(ctx, digest.MustNewInstanceName("example")).
+			Return(&remoteexecution.ServerCapabilities{
+				CacheCapabilities: &remoteexecution.CacheCapabilities{
+					DigestFunctions: []remoteexecution.DigestFunction_Value{
+						remoteexecution.DigestFunction_SHA256,
+					},
+				},
+			}, nil)
+
+		capabilities, err := blobAccess.GetCapabilities(ctx, digest.MustNewInstanceName("example"))
+		require.NoError(t, err)
+		testutil.RequireEqualProto(t, &remoteexecution.ServerCapabilities{
+			CacheCapabilities: &remoteexecution.CacheCapabilities{
+				DigestFunctions: []remoteexecution.DigestFunction_Value{
+					remoteexecution.DigestFunction_SHA256,
+				},
+				ActionCacheUpdateCapabilities: &remoteexecution.ActionCacheUpdateCapabilities{},
+			},
+		}, capabilities)
+	})
+}
+
+func TestShardingBlobAccessReplication(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	shard0 := mock.NewMockBlobAccess(ctrl)
+	shard1 := mock.NewMockBlobAccess(ctrl)
+	shard2 := mock.NewMockBlobAccess(ctrl)
+	shardPermuter := mock.NewMockShardPermuter(ctrl)
+	errorLogger := mock.NewMockErrorLogger(ctrl)
+	blobAccess := sharding.NewShardingBlobAccess([]blobstore.BlobAccess{
+		shard0,
+		shard1,
+		shard2,
+		nil, // Shard that is explicitly drained.
+	}, shardPermuter, 0x62994904405896a1, 2, true, nil, errorLogger)
+
+	helloDigest := digest.MustNewDigest("example", "8b1a9953c4611296a827abf8c47804d7", 5)
+	expectHelloShards := func() {
+		// Drained shards and duplicate indices should be
+		// skipped when selecting replicas.
+		shardPermuter.EXPECT().GetShard(uint64(0xa0230a77da24e99d), gomock.Any()).Do(
+			func(hash uint64, selector sharding.ShardSelector) {
+				require.True(t, selector(3))
+				require.True(t, selector(2))
+				require.True(t, selector(2))
+				require.False(t, selector(0))
+			})
+	}
+
+	t.Run("GetFailure", func(t *testing.T) {
+		// Errors other than NotFound should not cause the
+		// request to be retried against other replicas.
+		expectHelloShards()
+		shard2.EXPECT().Get(ctx, helloDigest).
+			Return(buffer.NewBufferFromError(status.Error(codes.Unavailable, "Server offline")))
+
+		_, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		testutil.RequireEqualStatus(t, status.Error(codes.Unavailable, "Shard 2: Server offline"), err)
+	})
+
+	t.Run("GetNotFound", func(t *testing.T) {
+		// If none of the replicas contain the object, the
+		// error of the last replica should be returned. Read
+		// repair will be attempted, but should fail as well.
+		expectHelloShards()
+		shard2.EXPECT().Get(ctx, helloDigest).
+			Return(buffer.NewBufferFromError(status.Error(codes.NotFound, "Object not found")))
+		shard0.EXPECT().Get(ctx, helloDigest).
+			Return(buffer.NewBufferFromError(status.Error(codes.NotFound, "Object not found")))
+		shard2.EXPECT().Put(ctx, helloDigest, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+				_, err := b.ToByteSlice(1000)
+				return err
+			})
+
+		_, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		testutil.RequireEqualStatus(t, status.Error(codes.NotFound, "Shard 0: Object not found"), err)
+	})
+
+	t.Run("GetReadRepair", func(t *testing.T) {
+		// If the first replica does not contain the object,
+		// it should be read from the second replica and be
+		// copied into the first one.
+		expectHelloShards()
+		shard2.EXPECT().Get(ctx, helloDigest).
+			Return(buffer.NewBufferFromError(status.Error(codes.NotFound, "Object not found")))
+		shard0.EXPECT().Get(ctx, helloDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello")))
+		shard2.EXPECT().Put(ctx, helloDigest, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+				data, err := b.ToByteSlice(1000)
+				require.NoError(t, err)
+				require.Equal(t, []byte("Hello"), data)
+				return nil
+			})
+
+		data, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+	})
+
+	t.Run("PutFailure", func(t *testing.T) {
+		expectHelloShards()
+		shard2.EXPECT().Put(ctx, helloDigest, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+				b.Discard()
+				return status.Error(codes.Unavailable, "Server offline")
+			})
+		shard0.EXPECT().Put(ctx, helloDigest, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+				b.Discard()
+				return nil
+			})
+
+		testutil.RequireEqualStatus(
+			t,
+			status.Error(codes.Unavailable, "Shard 2: Server offline"),
+			blobAccess.Put(ctx, helloDigest, buffer.NewValidatedBufferFromByteSlice([]byte("Hello"))))
+	})
+
+	t.Run("PutSuccess", func(t *testing.T) {
+		// Objects should be written to all replicas.
+		expectHelloShards()
+		for _, shard := range []*mock.MockBlobAccess{shard0, shard2} {
+			shard.EXPECT().Put(ctx, helloDigest, gomock.Any()).DoAndReturn(
+				func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+					data, err := b.ToByteSlice(1000)
+					require.NoError(t, err)
+					require.Equal(t, []byte("Hello"), data)
+					return nil
+				})
+		}
+
+		require.NoError(t, blobAccess.Put(ctx, helloDigest, buffer.NewValidatedBufferFromByteSlice([]byte("Hello"))))
+	})
+
+	digest1 := digest.MustNewDigest("", "21f843aefbfb88627ec2cad9e8f1f49a", 1)
+	digest2 := digest.MustNewDigest("", "48f2503cf369373b0631da97fb9de1c1", 2)
+	digest3 := digest.MustNewDigest("", "942a5b4164c26ae5d57a4f9526dcfca4", 3)
+
+	expectFindMissingShards := func() {
+		shardPermuter.EXPECT().GetShard(uint64(15126689533404788141), gomock.Any()).Do(
+			func(hash uint64, selector sharding.ShardSelector) {
+				require.True(t, selector(0))
+				require.False(t, selector(1))
+			})
+		shardPermuter.EXPECT().GetShard(uint64(6509308913848440562), gomock.Any()).Do(
+			func(hash uint64, selector sharding.ShardSelector) {
+				require.True(t, selector(1))
+				require.False(t, selector(2))
+			})
+		shardPermuter.EXPECT().GetShard(uint64(15403851060071172425), gomock.Any()).Do(
+			func(hash uint64, selector sharding.ShardSelector) {
+				require.True(t, selector(2))
+				require.False(t, selector(0))
+			})
+	}
+
+	t.Run("FindMissingReadRepair", func(t *testing.T) {
+		// Objects should only be reported as missing if they
+		// are absent from all replicas. Objects that are
+		// only present in some of the replicas should be
+		// copied into the other ones in the background.
+		expectFindMissingShards()
+		shard0.EXPECT().FindMissing(
+			gomock.Any(),
+			digest.NewSetBuilder().Add(digest1).Add(digest3).Build(),
+		).Return(digest1.ToSingletonSet(), nil)
+		shard1.EXPECT().FindMissing(
+			gomock.Any(),
+			digest.NewSetBuilder().Add(digest1).Add(digest2).Build(),
+		).Return(digest.NewSetBuilder().Add(digest1).Add(digest2).Build(), nil)
+		shard2.EXPECT().FindMissing(
+			gomock.Any(),
+			digest.NewSetBuilder().Add(digest2).Add(digest3).Build(),
+		).Return(digest.EmptySet, nil)
+		shard2.EXPECT().Get(gomock.Any(), digest2).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("xy")))
+		repaired := make(chan struct{})
+		shard1.EXPECT().Put(gomock.Any(), digest2, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+				data, err := b.ToByteSlice(1000)
+				require.NoError(t, err)
+				require.Equal(t, []byte("xy"), data)
+				close(repaired)
+				return nil
+			})
+
+		missing, err := blobAccess.FindMissing(
+			ctx,
+			digest.NewSetBuilder().Add(digest1).Add(digest2).Add(digest3).Build(),
+		)
+		require.NoError(t, err)
+		require.Equal(t, digest1.ToSingletonSet(), missing)
+		<-repaired
+	})
+
+	t.Run("FindMissingReadRepairFailure", func(t *testing.T) {
+		// Failures to repair objects should not cause
+		// FindMissing() to fail, as the objects are still
+		// present in one of the replicas. They should be
+		// logged instead.
+		expectFindMissingShards()
+		shard0.EXPECT().FindMissing(
+			gomock.Any(),
+			digest.NewSetBuilder().Add(digest1).Add(digest3).Build(),
+		).Return(digest.EmptySet, nil)
+		shard1.EXPECT().FindMissing(
+			gomock.Any(),
+			digest.NewSetBuilder().Add(digest1).Add(digest2).Build(),
+		).Return(digest2.ToSingletonSet(), nil)
+		shard2.EXPECT().FindMissing(
+			gomock.Any(),
+			digest.NewSetBuilder().Add(digest2).Add(digest3).Build(),
+		).Return(digest.EmptySet, nil)
+		shard2.EXPECT().Get(gomock.Any(), digest2).
+			Return(buffer.NewBufferFromError(status.Error(codes.Unavailable, "Server offline")))
+		shard1.EXPECT().Put(gomock.Any(), digest2, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+				_, err := b.ToByteSlice(1000)
+				return err
+			})
+		logged := make(chan struct{})
+		errorLogger.EXPECT().Log(status.Error(codes.Unavailable, "Failed to replicate 48f2503cf369373b0631da97fb9de1c1-2- from shard 2 to shard 1: Server offline")).
+			Do(func(err error) { close(logged) })
+
+		missing, err := blobAccess.FindMissing(
+			ctx,
+			digest.NewSetBuilder().Add(digest1).Add(digest2).Add(digest3).Build(),
+		)
+		require.NoError(t, err)
+		require.Equal(t, digest.EmptySet, missing)
+		<-logged
+	})
+}
+
+func TestShardingBlobAccessFailover(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	shard0 := mock.NewMockBlobAccess(ctrl)
+	shard1 := mock.NewMockBlobAccess(ctrl)
+	shardPermuter := mock.NewMockShardPermuter(ctrl)
+	healthTracker := mock.NewMockShardHealthTracker(ctrl)
+	blobAccess := sharding.NewShardingBlobAccess([]blobstore.BlobAccess{
+		shard0,
+		shard1,
+		nil, // Shard that is explicitly drained.
+	}, shardPermuter, 0x62994904405896a1, 1, false, healthTracker, util.DefaultErrorLogger)
+
+	helloDigest := digest.MustNewDigest("example", "8b1a9953c4611296a827abf8c47804d7", 5)
+
+	t.Run("GetUnhealthy", func(t *testing.T) {
+		// Shards that are unhealthy should be skipped, as if
+		// they were drained.
+		shardPermuter.EXPECT().GetShard(uint64(0xa0230a77da24e99d), gomock.Any()).Do(
+			func(hash uint64, selector sharding.ShardSelector) {
+				require.True(t, selector(0))
+				require.False(t, selector(1))
+			})
+		healthTracker.EXPECT().IsHealthy(0).Return(false)
+		healthTracker.EXPECT().IsHealthy(1).Return(true)
+		shard1.EXPECT().Get(ctx, helloDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello")))
+		healthTracker.EXPECT().ReportSuccess(1)
+
+		data, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+	})
+
+	t.Run("GetAllUnhealthy", func(t *testing.T) {
+		// If all shards are unhealthy, requests should still
+		// be sent to them, as opposed to failing immediately.
+		shardPermuter.EXPECT().GetShard(uint64(0xa0230a77da24e99d), gomock.Any()).Do(
+			func(hash uint64, selector sharding.ShardSelector) {
+				require.True(t, selector(1))
+				require.True(t, selector(0))
+				require.False(t, selector(2))
+			})
+		healthTracker.EXPECT().IsHealthy(1).Return(false)
+		healthTracker.EXPECT().IsHealthy(0).Return(false)
+		shard1.EXPECT().Get(ctx, helloDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello")))
+		healthTracker.EXPECT().ReportSuccess(1)
+
+		data, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+	})
+
+	t.Run("GetFailover", func(t *testing.T) {
+		// If a shard is unavailable, the request should be
+		// retried against the shard that takes over its key
+		// space.
+		shardPermuter.EXPECT().GetShard(uint64(0xa0230a77da24e99d), gomock.Any()).Do(
+			func(hash uint64, selector sharding.ShardSelector) {
+				require.False(t, selector(0))
+			})
+		healthTracker.EXPECT().IsHealthy(0).Return(true)
+		shard0.EXPECT().Get(ctx, helloDigest).
+			Return(buffer.NewBufferFromError(status.Error(codes.Unavailable, "Server offline")))
+		healthTracker.EXPECT().ReportFailure(0)
+		shardPermuter.EXPECT().GetShard(uint64(0xa0230a77da24e99d), gomock.Any()).Do(
+			func(hash uint64, selector sharding.ShardSelector) {
+				require.True(t, selector(0))
+				require.False(t, selector(1))
+			})
+		healthTracker.EXPECT().IsHealthy(1).Return(true)
+		shard1.EXPECT().Get(ctx, helloDigest).
+			Return(buffer.NewValidatedBufferFromByteSlice([]byte("Hello")))
+		healthTracker.EXPECT().ReportSuccess(1)
+
+		data, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), data)
+	})
+
+	t.Run("GetAllUnavailable", func(t *testing.T) {
+		// If all shards are unavailable, the error of the last
+		// shard should be returned.
+		shardPermuter.EXPECT().GetShard(uint64(0xa0230a77da24e99d), gomock.Any()).Do(
+			func(hash uint64, selector sharding.ShardSelector) {
+				require.False(t, selector(0))
+			})
+		healthTracker.EXPECT().IsHealthy(0).Return(true)
+		shard0.EXPECT().Get(ctx, helloDigest).
+			Return(buffer.NewBufferFromError(status.Error(codes.Unavailable, "Server offline")))
+		healthTracker.EXPECT().ReportFailure(0)
+		shardPermuter.EXPECT().GetShard(uint64(0xa0230a77da24e99d), gomock.Any()).Do(
+			func(hash uint64, selector sharding.ShardSelector) {
+				require.True(t, selector(0))
+				require.False(t, selector(1))
+			})
+		healthTracker.EXPECT().IsHealthy(1).Return(true)
+		shard1.EXPECT().Get(ctx, helloDigest).
+			Return(buffer.NewBufferFromError(status.Error(codes.Unavailable, "Server offline")))
+		healthTracker.EXPECT().ReportFailure(1)
+		shardPermuter.EXPECT().GetShard(uint64(0xa0230a77da24e99d), gomock.Any()).Do(
+			func(hash uint64, selector sharding.ShardSelector) {
+				require.True(t, selector(1))
+				require.True(t, selector(0))
+				require.False(t, selector(2))
+			})
+
+		_, err := blobAccess.Get(ctx, helloDigest).ToByteSlice(1000)
+		testutil.RequireEqualStatus(t, status.Error(codes.Unavailable, "Shard 1: Server offline"), err)
+	})
+
+	t.Run("PutFailure", func(t *testing.T) {
+		// Put() requests cannot be retried, as the buffer has
+		// already been consumed. Failures should still be
+		// reported.
+		shardPermuter.EXPECT().GetShard(uint64(0xa0230a77da24e99d), gomock.Any()).Do(
+			func(hash uint64, selector sharding.ShardSelector) {
+				require.False(t, selector(0))
+			})
+		healthTracker.EXPECT().IsHealthy(0).Return(true)
+		shard0.EXPECT().Put(ctx, helloDigest, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, digest digest.Digest, b buffer.Buffer) error {
+				b.Discard()
+				return status.Error(codes.Unavailable, "Server offline")
+			})
+		healthTracker.EXPECT().ReportFailure(0)
+
+		testutil.RequireEqualStatus(
+			t,
+			status.Error(codes.Unavailable, "Shard 0: Server offline"),
+			blobAccess.Put(ctx, helloDigest, buffer.NewValidatedBufferFromByteSlice([]byte("Hello"))))
+	})
+
+	digest1 := digest.MustNewDigest("", "21f843aefbfb88627ec2cad9e8f1f49a", 1)
+	digest2 := digest.MustNewDigest("", "48f2503cf369373b0631da97fb9de1c1", 2)
+
+	t.Run("FindMissingFailover", func(t *testing.T) {
+		// If a shard is unavailable, the digests that were
+		// sent to it should be sent to other shards instead.
+		shardPermuter.EXPECT().GetShard(uint64(15126689533404788141), gomock.Any()).Do(
+			func(hash uint64, selector sharding.ShardSelector) {
+				require.False(t, selector(0))
+			})
+		shardPermuter.EXPECT().GetShard(uint64(6509308913848440562), gomock.Any()).Do(
+			func(hash uint64, selector sharding.ShardSelector) {
+				require.False(t, selector(1))
+			})
+		healthTracker.EXPECT().IsHealthy(0).Return(true)
+		healthTracker.EXPECT().IsHealthy(1).Return(true)
+		shard0.EXPECT().FindMissing(gomock.Any(), digest1.ToSingletonSet()).
+			Return(digest.EmptySet, status.Error(codes.Unavailable, "Server offline"))
+		healthTracker.EXPECT().ReportFailure(0)
+		shard1.EXPECT().FindMissing(gomock.Any(), digest2.ToSingletonSet()).
+			Return(digest.EmptySet, nil)
+		healthTracker.EXPECT().ReportSuccess(1)
+
+		shardPermuter.EXPECT().GetShard(uint64(15126689533404788141), gomock.Any()).Do(
+			func(hash uint64, selector sharding.ShardSelector) {
+				require.True(t, selector(0))
+				require.False(t, selector(1))
+			})
+		shardPermuter.EXPECT().GetShard(uint64(6509308913848440562), gomock.Any()).Do(
+			func(hash uint64, selector sharding.ShardSelector) {
+				require.False(t, selector(1))
+			})
+		healthTracker.EXPECT().IsHealthy(1).Return(true).Times(2)
+		shard1.EXPECT().FindMissing(
+			gomock.Any(),
+			digest.NewSetBuilder().Add(digest1).Add(digest2).Build(),
+		).Return(digest1.ToSingletonSet(), nil)
+		healthTracker.EXPECT().ReportSuccess(1)
+
+		missing, err := blobAccess.FindMissing(
+			ctx,
+			digest.NewSetBuilder().Add(digest1).Add(digest2).Build(),
+		)
+		require.NoError(t, err)
+		require.Equal(t, digest1.ToSingletonSet(), missing)
+	})
 }
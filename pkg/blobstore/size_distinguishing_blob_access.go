@@ -2,12 +2,17 @@ package blobstore
 
 import (
 	"context"
+	"time"
 
 	"github.com/buildbarn/bb-storage/pkg/blobstore/buffer"
+	"github.com/buildbarn/bb-storage/pkg/capabilities"
+	"github.com/buildbarn/bb-storage/pkg/clock"
 	"github.com/buildbarn/bb-storage/pkg/digest"
 )
 
 type sizeDistinguishingBlobAccess struct {
+	capabilities.Provider
+
 	smallBlobAccess BlobAccess
 	largeBlobAccess BlobAccess
 	cutoffSizeBytes int64
@@ -18,8 +23,13 @@ type sizeDistinguishingBlobAccess struct {
 // specified in the digest. Backends tend to have different performance
 // characteristics based on blob size. This adapter may be used to
 // optimize performance based on that.
+//
+// As it is not known in advance which of the backends is used to
+// store a given object, the capabilities reported by this backend
+// are the intersection of those of the two backends.
 func NewSizeDistinguishingBlobAccess(smallBlobAccess, largeBlobAccess BlobAccess, cutoffSizeBytes int64) BlobAccess {
 	return &sizeDistinguishingBlobAccess{
+		Provider:        capabilities.NewIntersectingProvider([]capabilities.Provider{smallBlobAccess, largeBlobAccess}, clock.SystemClock, time.Minute),
 		smallBlobAccess: smallBlobAccess,
 		largeBlobAccess: largeBlobAccess,
 		cutoffSizeBytes: cutoffSizeBytes,
@@ -2,7 +2,6 @@ package blockdevice
 
 import (
 	"io"
-	"os"
 )
 
 // BlockDevice is an interface for interacting with a block device like
@@ -23,11 +22,18 @@ import (
 // storage medium immediately. This can be problematic in case the order
 // of writes matters. The Sync() function can be used to block execution
 // until all previous writes are persisted.
+//
+// When a region of the storage medium no longer contains any useful
+// data, Discard() may be called to deallocate it. For block devices
+// backed by a regular file, this punches a hole in the file. For
+// physical devices, this issues a discard (TRIM) command. Subsequent
+// reads of the discarded region may return arbitrary data, though
+// most implementations return zero bytes. Implementations that don't
+// support this feature return codes.Unimplemented.
 type BlockDevice interface {
 	io.ReaderAt
 	io.WriterAt
 
 	Sync() error
+	Discard(off, size int64) error
 }
-
-var _ BlockDevice = (*os.File)(nil)
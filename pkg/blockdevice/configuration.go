@@ -2,6 +2,7 @@ package blockdevice
 
 import (
 	pb "github.com/buildbarn/bb-storage/pkg/proto/configuration/blockdevice"
+	"github.com/buildbarn/bb-storage/pkg/util"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -23,3 +24,64 @@ func NewBlockDeviceFromConfiguration(configuration *pb.Configuration, mayZeroIni
 		return nil, 0, 0, status.Error(codes.InvalidArgument, "Configuration did not contain a supported block device source")
 	}
 }
+
+// NewReadOnlyBlockDeviceFromConfiguration creates a BlockDevice based
+// on parameters provided in a configuration file. Unlike
+// NewBlockDeviceFromConfiguration(), files and devices are opened
+// read-only, and files are neither created nor resized. This makes it
+// safe to inspect storage that is in use by another process.
+func NewReadOnlyBlockDeviceFromConfiguration(configuration *pb.Configuration) (BlockDevice, int, int64, error) {
+	if configuration == nil {
+		return nil, 0, 0, status.Error(codes.InvalidArgument, "Block device configuration not specified")
+	}
+
+	switch source := configuration.Source.(type) {
+	case *pb.Configuration_DevicePath:
+		return NewReadOnlyBlockDeviceFromDevice(source.DevicePath)
+	case *pb.Configuration_File:
+		return NewReadOnlyBlockDeviceFromFile(source.File.Path, int(source.File.SizeBytes))
+	default:
+		return nil, 0, 0, status.Error(codes.InvalidArgument, "Configuration did not contain a supported block device source")
+	}
+}
+
+// NewBlockDevicesFromConfiguration creates a list of BlockDevices based
+// on parameters provided in a configuration file. All BlockDevices are
+// required to have the same sector size. The sector size is returned,
+// in addition to the number of sectors of each BlockDevice.
+func NewBlockDevicesFromConfiguration(configurations []*pb.Configuration, mayZeroInitialize bool) ([]BlockDevice, int, []int64, error) {
+	return newBlockDevicesFromConfiguration(configurations, func(configuration *pb.Configuration) (BlockDevice, int, int64, error) {
+		return NewBlockDeviceFromConfiguration(configuration, mayZeroInitialize)
+	})
+}
+
+// NewReadOnlyBlockDevicesFromConfiguration is identical to
+// NewBlockDevicesFromConfiguration(), except that all BlockDevices are
+// opened using NewReadOnlyBlockDeviceFromConfiguration().
+func NewReadOnlyBlockDevicesFromConfiguration(configurations []*pb.Configuration) ([]BlockDevice, int, []int64, error) {
+	return newBlockDevicesFromConfiguration(configurations, NewReadOnlyBlockDeviceFromConfiguration)
+}
+
+func newBlockDevicesFromConfiguration(configurations []*pb.Configuration, newBlockDevice func(*pb.Configuration) (BlockDevice, int, int64, error)) ([]BlockDevice, int, []int64, error) {
+	if len(configurations) == 0 {
+		return nil, 0, nil, status.Error(codes.InvalidArgument, "No block devices specified")
+	}
+
+	blockDevices := make([]BlockDevice, 0, len(configurations))
+	sectorCounts := make([]int64, 0, len(configurations))
+	var sectorSizeBytes int
+	for i, configuration := range configurations {
+		blockDevice, deviceSectorSizeBytes, sectorCount, err := newBlockDevice(configuration)
+		if err != nil {
+			return nil, 0, nil, util.StatusWrapf(err, "Block device %d", i)
+		}
+		if i == 0 {
+			sectorSizeBytes = deviceSectorSizeBytes
+		} else if deviceSectorSizeBytes != sectorSizeBytes {
+			return nil, 0, nil, status.Errorf(codes.InvalidArgument, "Block device %d has sector size %d, while block device 0 has sector size %d", i, deviceSectorSizeBytes, sectorSizeBytes)
+		}
+		blockDevices = append(blockDevices, blockDevice)
+		sectorCounts = append(sectorCounts, sectorCount)
+	}
+	return blockDevices, sectorSizeBytes, sectorCounts, nil
+}
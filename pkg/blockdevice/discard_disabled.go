@@ -0,0 +1,22 @@
+// +build darwin freebsd
+
+package blockdevice
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// discardFileRegion deallocates a region of a regular file. This
+// implementation is a stub for operating systems that don't support
+// punching holes in files.
+func discardFileRegion(fd int, off, size int64) error {
+	return status.Error(codes.Unimplemented, "Punching holes in files is not supported on this platform")
+}
+
+// discardDeviceRegion deallocates a region of a UNIX device node. This
+// implementation is a stub for operating systems that don't support
+// discarding regions of devices.
+func discardDeviceRegion(fd int, off, size int64) error {
+	return status.Error(codes.Unimplemented, "Discarding regions of devices is not supported on this platform")
+}
@@ -0,0 +1,34 @@
+// +build linux
+
+package blockdevice
+
+import (
+	"unsafe"
+
+	"github.com/buildbarn/bb-storage/pkg/util"
+
+	"golang.org/x/sys/unix"
+)
+
+// blkDiscard is the value of BLKDISCARD, as defined in <linux/fs.h>.
+// It is not provided by golang.org/x/sys/unix.
+const blkDiscard = 0x1277
+
+// discardFileRegion deallocates a region of a regular file by punching
+// a hole in it. The size of the file is left unaltered.
+func discardFileRegion(fd int, off, size int64) error {
+	if err := unix.Fallocate(fd, unix.FALLOC_FL_PUNCH_HOLE|unix.FALLOC_FL_KEEP_SIZE, off, size); err != nil {
+		return util.StatusWrapf(err, "Failed to punch hole at offset %d with size %d", off, size)
+	}
+	return nil
+}
+
+// discardDeviceRegion deallocates a region of a UNIX device node by
+// issuing a discard command.
+func discardDeviceRegion(fd int, off, size int64) error {
+	region := [2]uint64{uint64(off), uint64(size)}
+	if _, _, err := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), blkDiscard, uintptr(unsafe.Pointer(&region))); err != 0 {
+		return util.StatusWrapf(err, "Failed to discard region at offset %d with size %d", off, size)
+	}
+	return nil
+}
@@ -14,22 +14,36 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+var errReadOnly = status.Error(codes.PermissionDenied, "Block device is opened read-only")
+
+// discarder is a function that is used by memoryMappedBlockDevice to
+// deallocate a region of storage. Regular files and UNIX device nodes
+// need to be discarded using different system calls.
+type discarder func(fd int, off, size int64) error
+
 type memoryMappedBlockDevice struct {
-	fd   int
-	data []byte
+	fd       int
+	data     []byte
+	discard  discarder
+	readOnly bool
 }
 
 // newMemoryMappedBlockDevice creates a BlockDevice from a file
 // descriptor referring either to a regular file or UNIX device node. To
 // speed up reads, a memory map is used.
-func newMemoryMappedBlockDevice(fd, sizeBytes int) (BlockDevice, error) {
+//
+// If the file descriptor is opened read-only, all attempts to modify
+// the contents of the block device fail.
+func newMemoryMappedBlockDevice(fd, sizeBytes int, discard discarder, readOnly bool) (BlockDevice, error) {
 	data, err := unix.Mmap(fd, 0, sizeBytes, syscall.PROT_READ, syscall.MAP_SHARED)
 	if err != nil {
 		return nil, util.StatusWrap(err, "Failed to memory map block device")
 	}
 	return &memoryMappedBlockDevice{
-		fd:   fd,
-		data: data,
+		fd:       fd,
+		data:     data,
+		discard:  discard,
+		readOnly: readOnly,
 	}, nil
 }
 
@@ -68,9 +82,22 @@ func (bd *memoryMappedBlockDevice) WriteAt(p []byte, off int64) (int, error) {
 	//
 	// TODO: Maybe it makes sense to let unaligned writes that would
 	// trigger reads anyway to go through the memory map?
+	if bd.readOnly {
+		return 0, errReadOnly
+	}
 	return unix.Pwrite(bd.fd, p, off)
 }
 
 func (bd *memoryMappedBlockDevice) Sync() error {
 	return unix.Fsync(bd.fd)
 }
+
+func (bd *memoryMappedBlockDevice) Discard(off, size int64) error {
+	if bd.readOnly {
+		return errReadOnly
+	}
+	if off < 0 || size < 0 || off+size > int64(len(bd.data)) {
+		return status.Errorf(codes.InvalidArgument, "Region at offset %d with size %d lies outside the block device", off, size)
+	}
+	return bd.discard(bd.fd, off, size)
+}
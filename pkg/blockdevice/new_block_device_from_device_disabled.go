@@ -13,3 +13,11 @@ import (
 func NewBlockDeviceFromDevice(path string) (BlockDevice, int, int64, error) {
 	return nil, 0, 0, status.Error(codes.Unimplemented, "Memory mapping block devices is not supported on this platform")
 }
+
+// NewReadOnlyBlockDeviceFromDevice maps the entire contents of a block
+// device into the address space of the current process, without
+// permitting it to be modified. This implementation is a stub for
+// operating systems that don't support block device access.
+func NewReadOnlyBlockDeviceFromDevice(path string) (BlockDevice, int, int64, error) {
+	return nil, 0, 0, status.Error(codes.Unimplemented, "Memory mapping block devices is not supported on this platform")
+}
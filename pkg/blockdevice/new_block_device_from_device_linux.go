@@ -21,7 +21,22 @@ import (
 // Writes may only occur at sector boundaries, as unaligned writes would
 // cause unnecessary read operations against underlying storage.
 func NewBlockDeviceFromDevice(path string) (BlockDevice, int, int64, error) {
-	fd, err := unix.Open(path, unix.O_RDWR, 0)
+	return newBlockDeviceFromDevice(path, false)
+}
+
+// NewReadOnlyBlockDeviceFromDevice is identical to
+// NewBlockDeviceFromDevice, except that the device node is opened
+// read-only. Attempts to modify the contents of the block device fail.
+func NewReadOnlyBlockDeviceFromDevice(path string) (BlockDevice, int, int64, error) {
+	return newBlockDeviceFromDevice(path, true)
+}
+
+func newBlockDeviceFromDevice(path string, readOnly bool) (BlockDevice, int, int64, error) {
+	flags := unix.O_RDWR
+	if readOnly {
+		flags = unix.O_RDONLY
+	}
+	fd, err := unix.Open(path, flags, 0)
 	if err != nil {
 		return nil, 0, 0, util.StatusWrapf(err, "Failed to open device node %#v", path)
 	}
@@ -38,7 +53,7 @@ func NewBlockDeviceFromDevice(path string) (BlockDevice, int, int64, error) {
 		return nil, 0, 0, util.StatusWrapf(err, "Failed to obtain size of device node %#v", path)
 	}
 
-	bd, err := newMemoryMappedBlockDevice(fd, int(deviceSizeBytes))
+	bd, err := newMemoryMappedBlockDevice(fd, int(deviceSizeBytes), discardDeviceRegion, readOnly)
 	if err != nil {
 		unix.Close(fd)
 		return nil, 0, 0, err
@@ -13,3 +13,11 @@ import (
 func NewBlockDeviceFromFile(path string, minimumSizeBytes int, zeroInitialize bool) (BlockDevice, int, int64, error) {
 	return nil, 0, 0, status.Error(codes.Unimplemented, "Memory mapping block devices is not supported on this platform")
 }
+
+// NewReadOnlyBlockDeviceFromFile creates a BlockDevice that is backed
+// by an existing regular file, without permitting it to be modified.
+// This implementation is a stub for operating systems that don't
+// support block device access.
+func NewReadOnlyBlockDeviceFromFile(path string, minimumSizeBytes int) (BlockDevice, int, int64, error) {
+	return nil, 0, 0, status.Error(codes.Unimplemented, "Memory mapping block devices is not supported on this platform")
+}
@@ -108,3 +108,93 @@ func TestNewBlockDeviceFromFileZeroInitialize(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, []byte("\x00\x00\x00\x00\x00"), b[:])
 }
+
+func TestNewBlockDeviceFromFileDiscard(t *testing.T) {
+	blockDevicePath := filepath.Join(t.TempDir(), "blockdevice")
+	blockDevice, sectorSizeBytes, sectorCount, err := blockdevice.NewBlockDeviceFromFile(blockDevicePath, 123456, true)
+	require.NoError(t, err)
+
+	n, err := blockDevice.WriteAt([]byte("Hello"), int64(sectorSizeBytes))
+	require.Equal(t, 5, n)
+	require.NoError(t, err)
+
+	// Discarding regions that lie outside the block device should
+	// not be permitted.
+	require.Equal(
+		t,
+		status.Errorf(codes.InvalidArgument, "Region at offset %d with size 1 lies outside the block device", int64(sectorSizeBytes)*sectorCount),
+		blockDevice.Discard(int64(sectorSizeBytes)*sectorCount, 1))
+
+	// After discarding a region, its contents should read back as
+	// zero bytes. The size of the file should remain unaltered.
+	if err := blockDevice.Discard(int64(sectorSizeBytes), int64(sectorSizeBytes)); status.Code(err) == codes.Unimplemented {
+		t.Skip("Discarding is not supported on this platform")
+	} else {
+		require.NoError(t, err)
+	}
+
+	var b [5]byte
+	n, err = blockDevice.ReadAt(b[:], int64(sectorSizeBytes))
+	require.Equal(t, 5, n)
+	require.NoError(t, err)
+	require.Equal(t, []byte("\x00\x00\x00\x00\x00"), b[:])
+
+	fileInfo, err := os.Stat(blockDevicePath)
+	require.NoError(t, err)
+	require.Equal(t, int64(sectorSizeBytes)*sectorCount, fileInfo.Size())
+}
+
+func TestNewReadOnlyBlockDeviceFromFile(t *testing.T) {
+	blockDevicePath := filepath.Join(t.TempDir(), "blockdevice")
+
+	t.Run("NonExistent", func(t *testing.T) {
+		// Files should not be created.
+		_, _, _, err := blockdevice.NewReadOnlyBlockDeviceFromFile(blockDevicePath, 123456)
+		require.Error(t, err)
+		_, err = os.Stat(blockDevicePath)
+		require.True(t, os.IsNotExist(err))
+	})
+
+	f, err := os.OpenFile(blockDevicePath, os.O_CREATE|os.O_WRONLY, 0o666)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("Hello"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	t.Run("TooSmall", func(t *testing.T) {
+		// Files should not be resized if they are smaller than
+		// expected.
+		_, _, _, err := blockdevice.NewReadOnlyBlockDeviceFromFile(blockDevicePath, 123456)
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+		fileInfo, err := os.Stat(blockDevicePath)
+		require.NoError(t, err)
+		require.Equal(t, int64(5), fileInfo.Size())
+	})
+
+	// Grow the file, so that it can be opened. Existing contents
+	// should remain accessible, but may not be modified.
+	_, sectorSizeBytes, sectorCount, err := blockdevice.NewBlockDeviceFromFile(blockDevicePath, 123456, false)
+	require.NoError(t, err)
+	sizeBytes := int64(sectorSizeBytes) * sectorCount
+
+	t.Run("Success", func(t *testing.T) {
+		blockDevice, readOnlySectorSizeBytes, readOnlySectorCount, err := blockdevice.NewReadOnlyBlockDeviceFromFile(blockDevicePath, 123456)
+		require.NoError(t, err)
+		require.Equal(t, sectorSizeBytes, readOnlySectorSizeBytes)
+		require.Equal(t, sectorCount, readOnlySectorCount)
+
+		var b [5]byte
+		n, err := blockDevice.ReadAt(b[:], 0)
+		require.Equal(t, 5, n)
+		require.NoError(t, err)
+		require.Equal(t, []byte("Hello"), b[:])
+
+		_, err = blockDevice.WriteAt([]byte("Jello"), 0)
+		require.Equal(t, status.Error(codes.PermissionDenied, "Block device is opened read-only"), err)
+		require.Equal(t, status.Error(codes.PermissionDenied, "Block device is opened read-only"), blockDevice.Discard(0, 5))
+
+		fileInfo, err := os.Stat(blockDevicePath)
+		require.NoError(t, err)
+		require.Equal(t, sizeBytes, fileInfo.Size())
+	})
+}
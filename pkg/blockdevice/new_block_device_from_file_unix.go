@@ -6,6 +6,8 @@ import (
 	"github.com/buildbarn/bb-storage/pkg/util"
 
 	"golang.org/x/sys/unix"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // NewBlockDeviceFromFile creates a BlockDevice that is backed by a
@@ -30,6 +32,7 @@ func NewBlockDeviceFromFile(path string, minimumSizeBytes int, zeroInitialize bo
 	// desired amount of space.
 	var stat unix.Stat_t
 	if err := unix.Fstat(fd, &stat); err != nil {
+		unix.Close(fd)
 		return nil, 0, 0, util.StatusWrapf(err, "Failed to obtain size of file %#v", path)
 	}
 	sectorSizeBytes := int(stat.Blksize)
@@ -37,10 +40,45 @@ func NewBlockDeviceFromFile(path string, minimumSizeBytes int, zeroInitialize bo
 	sizeBytes := int64(sectorSizeBytes) * sectorCount
 
 	if err := unix.Ftruncate(fd, sizeBytes); err != nil {
+		unix.Close(fd)
 		return nil, 0, 0, util.StatusWrapf(err, "Failed to truncate file %#v to %d bytes", path, sizeBytes)
 	}
 
-	bd, err := newMemoryMappedBlockDevice(fd, int(sizeBytes))
+	bd, err := newMemoryMappedBlockDevice(fd, int(sizeBytes), discardFileRegion, false)
+	if err != nil {
+		unix.Close(fd)
+		return nil, 0, 0, err
+	}
+	return bd, sectorSizeBytes, sectorCount, nil
+}
+
+// NewReadOnlyBlockDeviceFromFile creates a BlockDevice that is backed
+// by an existing regular file stored in a file system, without
+// permitting it to be modified. Unlike NewBlockDeviceFromFile(), the
+// file is neither created nor resized. Opening fails if the file is
+// smaller than the size that NewBlockDeviceFromFile() would have given
+// it. This makes it safe to inspect files that are in use by another
+// process.
+func NewReadOnlyBlockDeviceFromFile(path string, minimumSizeBytes int) (BlockDevice, int, int64, error) {
+	fd, err := unix.Open(path, unix.O_RDONLY, 0)
+	if err != nil {
+		return nil, 0, 0, util.StatusWrapf(err, "Failed to open file %#v", path)
+	}
+
+	var stat unix.Stat_t
+	if err := unix.Fstat(fd, &stat); err != nil {
+		unix.Close(fd)
+		return nil, 0, 0, util.StatusWrapf(err, "Failed to obtain size of file %#v", path)
+	}
+	sectorSizeBytes := int(stat.Blksize)
+	sectorCount := int64((uint64(minimumSizeBytes) + uint64(stat.Blksize) - 1) / uint64(stat.Blksize))
+	sizeBytes := int64(sectorSizeBytes) * sectorCount
+	if stat.Size < sizeBytes {
+		unix.Close(fd)
+		return nil, 0, 0, status.Errorf(codes.FailedPrecondition, "File %#v is %d bytes in size, while at least %d bytes were expected", path, stat.Size, sizeBytes)
+	}
+
+	bd, err := newMemoryMappedBlockDevice(fd, int(sizeBytes), discardFileRegion, true)
 	if err != nil {
 		unix.Close(fd)
 		return nil, 0, 0, err
@@ -28,24 +28,21 @@ type authorizingBuildQueue struct {
 	authorizer auth.Authorizer
 }
 
-func (bq *authorizingBuildQueue) GetCapabilities(ctx context.Context, request *remoteexecution.GetCapabilitiesRequest) (*remoteexecution.ServerCapabilities, error) {
-	instanceName, err := digest.NewInstanceName(request.InstanceName)
-	if err != nil {
-		return nil, util.StatusWrapf(err, "Invalid instance name %#v", request.InstanceName)
-	}
-
-	caps, err := bq.backend.GetCapabilities(ctx, request)
+func (bq *authorizingBuildQueue) GetCapabilities(ctx context.Context, instanceName digest.InstanceName) (*remoteexecution.ServerCapabilities, error) {
+	caps, err := bq.backend.GetCapabilities(ctx, instanceName)
 	if err != nil {
 		return nil, err
 	}
 
-	switch authErr := auth.AuthorizeSingleInstanceName(ctx, bq.authorizer, instanceName); status.Code(authErr) {
-	case codes.OK:
-		// Nothing to do.
-	case codes.PermissionDenied:
-		caps.ExecutionCapabilities.ExecEnabled = false
-	default:
-		return nil, authErr
+	if executionCapabilities := caps.ExecutionCapabilities; executionCapabilities != nil {
+		switch authErr := auth.AuthorizeSingleInstanceName(ctx, bq.authorizer, instanceName); status.Code(authErr) {
+		case codes.OK:
+			// Nothing to do.
+		case codes.PermissionDenied:
+			executionCapabilities.ExecEnabled = false
+		default:
+			return nil, authErr
+		}
 	}
 	return caps, nil
 }
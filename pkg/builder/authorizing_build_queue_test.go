@@ -23,67 +23,54 @@ func TestAuthorizingBuildQueueGetCapabilities(t *testing.T) {
 	authorizer := mock.NewMockAuthorizer(ctrl)
 	authorizingBuildQueue := builder.NewAuthorizingBuildQueue(buildQueue, authorizer)
 
-	t.Run("InvalidInstanceName", func(t *testing.T) {
-		_, err := authorizingBuildQueue.GetCapabilities(ctx, &remoteexecution.GetCapabilitiesRequest{
-			InstanceName: "hello/blobs/world",
-		})
-		require.Equal(t, status.Error(codes.InvalidArgument, "Invalid instance name \"hello/blobs/world\": Instance name contains reserved keyword \"blobs\""), err)
+	t.Run("NoExecutionCapabilities", func(t *testing.T) {
+		// If the backend does not support remote execution,
+		// there is no need to perform authorization.
+		buildQueue.EXPECT().GetCapabilities(ctx, digest.MustNewInstanceName("hello/world")).
+			Return(&remoteexecution.ServerCapabilities{}, nil)
+		caps, err := authorizingBuildQueue.GetCapabilities(ctx, digest.MustNewInstanceName("hello/world"))
+		require.NoError(t, err)
+		testutil.RequireEqualProto(t, &remoteexecution.ServerCapabilities{}, caps)
 	})
 
 	t.Run("NotAuthorized", func(t *testing.T) {
 		authorizer.EXPECT().Authorize(ctx, []digest.InstanceName{digest.MustNewInstanceName("hello/world")}).Return([]error{status.Error(codes.PermissionDenied, "Permission denied")})
-		buildQueue.EXPECT().GetCapabilities(ctx, &remoteexecution.GetCapabilitiesRequest{
-			InstanceName: "hello/world",
-		}).Return(&remoteexecution.ServerCapabilities{
+		buildQueue.EXPECT().GetCapabilities(ctx, digest.MustNewInstanceName("hello/world")).Return(&remoteexecution.ServerCapabilities{
 			ExecutionCapabilities: &remoteexecution.ExecutionCapabilities{
 				ExecEnabled: true,
 			},
 		}, nil)
-		caps, err := authorizingBuildQueue.GetCapabilities(ctx, &remoteexecution.GetCapabilitiesRequest{
-			InstanceName: "hello/world",
-		})
+		caps, err := authorizingBuildQueue.GetCapabilities(ctx, digest.MustNewInstanceName("hello/world"))
 		require.NoError(t, err)
 		require.False(t, caps.ExecutionCapabilities.ExecEnabled)
 	})
 
 	t.Run("AuthError", func(t *testing.T) {
 		authorizer.EXPECT().Authorize(ctx, []digest.InstanceName{digest.MustNewInstanceName("hello/world")}).Return([]error{status.Error(codes.Internal, "Something went wrong")})
-		buildQueue.EXPECT().GetCapabilities(ctx, &remoteexecution.GetCapabilitiesRequest{
-			InstanceName: "hello/world",
-		}).Return(&remoteexecution.ServerCapabilities{
+		buildQueue.EXPECT().GetCapabilities(ctx, digest.MustNewInstanceName("hello/world")).Return(&remoteexecution.ServerCapabilities{
 			ExecutionCapabilities: &remoteexecution.ExecutionCapabilities{
 				ExecEnabled: true,
 			},
 		}, nil)
-		_, err := authorizingBuildQueue.GetCapabilities(ctx, &remoteexecution.GetCapabilitiesRequest{
-			InstanceName: "hello/world",
-		})
+		_, err := authorizingBuildQueue.GetCapabilities(ctx, digest.MustNewInstanceName("hello/world"))
 		testutil.RequireEqualStatus(t, status.Error(codes.Internal, "Something went wrong"), err)
 	})
 
 	t.Run("BackendFailure", func(t *testing.T) {
 		wantErr := status.Error(codes.Internal, "Something went wrong")
-		buildQueue.EXPECT().GetCapabilities(ctx, &remoteexecution.GetCapabilitiesRequest{
-			InstanceName: "hello/world",
-		}).Return(nil, wantErr)
-		_, err := authorizingBuildQueue.GetCapabilities(ctx, &remoteexecution.GetCapabilitiesRequest{
-			InstanceName: "hello/world",
-		})
+		buildQueue.EXPECT().GetCapabilities(ctx, digest.MustNewInstanceName("hello/world")).Return(nil, wantErr)
+		_, err := authorizingBuildQueue.GetCapabilities(ctx, digest.MustNewInstanceName("hello/world"))
 		testutil.RequireEqualStatus(t, wantErr, err)
 	})
 
 	t.Run("Success", func(t *testing.T) {
 		authorizer.EXPECT().Authorize(ctx, []digest.InstanceName{digest.MustNewInstanceName("hello/world")}).Return([]error{nil})
-		buildQueue.EXPECT().GetCapabilities(ctx, &remoteexecution.GetCapabilitiesRequest{
-			InstanceName: "hello/world",
-		}).Return(&remoteexecution.ServerCapabilities{
+		buildQueue.EXPECT().GetCapabilities(ctx, digest.MustNewInstanceName("hello/world")).Return(&remoteexecution.ServerCapabilities{
 			ExecutionCapabilities: &remoteexecution.ExecutionCapabilities{
 				ExecEnabled: true,
 			},
 		}, nil)
-		caps, err := authorizingBuildQueue.GetCapabilities(ctx, &remoteexecution.GetCapabilitiesRequest{
-			InstanceName: "hello/world",
-		})
+		caps, err := authorizingBuildQueue.GetCapabilities(ctx, digest.MustNewInstanceName("hello/world"))
 		require.NoError(t, err)
 		require.True(t, caps.ExecutionCapabilities.ExecEnabled)
 	})
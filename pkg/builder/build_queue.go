@@ -2,11 +2,16 @@ package builder
 
 import (
 	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
+	"github.com/buildbarn/bb-storage/pkg/capabilities"
 )
 
 // BuildQueue is an interface for the set of operations that a scheduler
 // process must implement.
+//
+// Build queues only report the execution capabilities of the
+// scheduler. Cache capabilities are reported by the storage backends
+// instead.
 type BuildQueue interface {
-	remoteexecution.CapabilitiesServer
+	capabilities.Provider
 	remoteexecution.ExecutionServer
 }
@@ -59,8 +59,8 @@ func NewDemultiplexingBuildQueueFromConfiguration(schedulers map[string]*pb.Sche
 		//
 		// This is used when bb_storage is set up to do
 		// plain remote caching. Because we don't have a
-		// scheduler, we need to handle GetCapabilities()
-		// requests ourselves.
+		// scheduler, GetCapabilities() should only report
+		// the capabilities of the storage backends.
 		return NonExecutableBuildQueue, instanceName, instanceName, nil
 	}), nil
 }
@@ -5,6 +5,7 @@ import (
 	"io"
 
 	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
+	"github.com/buildbarn/bb-storage/pkg/digest"
 
 	"google.golang.org/grpc"
 )
@@ -19,6 +20,10 @@ type forwardingBuildQueue struct {
 // may be used by the frontend processes to forward execution requests to
 // scheduler processes in unmodified form.
 //
+// Only the execution capabilities reported by the scheduler are
+// returned, as the cache capabilities are provided by the storage
+// backends that are configured locally.
+//
 // Details: https://github.com/grpc/grpc-go/issues/2297
 func NewForwardingBuildQueue(client grpc.ClientConnInterface) BuildQueue {
 	return &forwardingBuildQueue{
@@ -27,8 +32,16 @@ func NewForwardingBuildQueue(client grpc.ClientConnInterface) BuildQueue {
 	}
 }
 
-func (bq *forwardingBuildQueue) GetCapabilities(ctx context.Context, in *remoteexecution.GetCapabilitiesRequest) (*remoteexecution.ServerCapabilities, error) {
-	return bq.capabilitiesClient.GetCapabilities(ctx, in)
+func (bq *forwardingBuildQueue) GetCapabilities(ctx context.Context, instanceName digest.InstanceName) (*remoteexecution.ServerCapabilities, error) {
+	serverCapabilities, err := bq.capabilitiesClient.GetCapabilities(ctx, &remoteexecution.GetCapabilitiesRequest{
+		InstanceName: instanceName.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &remoteexecution.ServerCapabilities{
+		ExecutionCapabilities: serverCapabilities.ExecutionCapabilities,
+	}, nil
 }
 
 func forwardOperations(cancel context.CancelFunc, client remoteexecution.Execution_ExecuteClient, server remoteexecution.Execution_ExecuteServer) error {
@@ -7,6 +7,7 @@ import (
 	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
 	"github.com/buildbarn/bb-storage/internal/mock"
 	"github.com/buildbarn/bb-storage/pkg/builder"
+	"github.com/buildbarn/bb-storage/pkg/digest"
 	"github.com/buildbarn/bb-storage/pkg/testutil"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
@@ -77,4 +78,40 @@ func TestForwardingBuildQueue(t *testing.T) {
 			status.Error(codes.Unavailable, "Client has closed connection"),
 			buildQueue.Execute(&executeRequest, out))
 	})
+	t.Run("GetCapabilities", func(t *testing.T) {
+		// Only the execution capabilities of the scheduler
+		// should be returned. Cache capabilities are provided
+		// by the storage backends.
+		client.EXPECT().Invoke(
+			gomock.Any(),
+			"/build.bazel.remote.execution.v2.Capabilities/GetCapabilities",
+			testutil.EqProto(t, &remoteexecution.GetCapabilitiesRequest{
+				InstanceName: "my-scheduler",
+			}),
+			gomock.Any(),
+			gomock.Any(),
+		).DoAndReturn(func(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+			proto.Merge(reply.(proto.Message), &remoteexecution.ServerCapabilities{
+				CacheCapabilities: &remoteexecution.CacheCapabilities{
+					DigestFunctions: []remoteexecution.DigestFunction_Value{
+						remoteexecution.DigestFunction_SHA256,
+					},
+				},
+				ExecutionCapabilities: &remoteexecution.ExecutionCapabilities{
+					DigestFunction: remoteexecution.DigestFunction_SHA256,
+					ExecEnabled:    true,
+				},
+			})
+			return nil
+		})
+
+		capabilities, err := buildQueue.GetCapabilities(ctx, digest.MustNewInstanceName("my-scheduler"))
+		require.NoError(t, err)
+		testutil.RequireEqualProto(t, &remoteexecution.ServerCapabilities{
+			ExecutionCapabilities: &remoteexecution.ExecutionCapabilities{
+				DigestFunction: remoteexecution.DigestFunction_SHA256,
+				ExecEnabled:    true,
+			},
+		}, capabilities)
+	})
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 
 	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
-	"github.com/bazelbuild/remote-apis/build/bazel/semver"
 	"github.com/buildbarn/bb-storage/pkg/digest"
 
 	"google.golang.org/grpc/codes"
@@ -14,26 +13,14 @@ import (
 type nonExecutableBuildQueue struct{}
 
 // NonExecutableBuildQueue is a build queue that is incapable of
-// executing anything. It is merely needed to provide a functional
-// implementation of GetCapabilities() for instances that provide remote
-// caching without the execution.
+// executing anything. It is merely needed to announce the existence of
+// instance names that provide remote caching without the execution.
+// As it reports no execution capabilities, the response of
+// GetCapabilities() is determined by the storage backends entirely.
 var NonExecutableBuildQueue BuildQueue = nonExecutableBuildQueue{}
 
-func (bq nonExecutableBuildQueue) GetCapabilities(ctx context.Context, in *remoteexecution.GetCapabilitiesRequest) (*remoteexecution.ServerCapabilities, error) {
-	return &remoteexecution.ServerCapabilities{
-		CacheCapabilities: &remoteexecution.CacheCapabilities{
-			DigestFunctions: digest.SupportedDigestFunctions,
-			ActionCacheUpdateCapabilities: &remoteexecution.ActionCacheUpdateCapabilities{
-				UpdateEnabled: false,
-			},
-			// CachePriorityCapabilities: Priorities not supported.
-			// MaxBatchTotalSize: Not used by Bazel yet.
-			SymlinkAbsolutePathStrategy: remoteexecution.SymlinkAbsolutePathStrategy_ALLOWED,
-		},
-		// TODO(edsch): DeprecatedApiVersion.
-		LowApiVersion:  &semver.SemVer{Major: 2},
-		HighApiVersion: &semver.SemVer{Major: 2},
-	}, nil
+func (bq nonExecutableBuildQueue) GetCapabilities(ctx context.Context, instanceName digest.InstanceName) (*remoteexecution.ServerCapabilities, error) {
+	return &remoteexecution.ServerCapabilities{}, nil
 }
 
 func (bq nonExecutableBuildQueue) Execute(in *remoteexecution.ExecuteRequest, out remoteexecution.Execution_ExecuteServer) error {
@@ -0,0 +1,197 @@
+package capabilities
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
+	"github.com/buildbarn/bb-storage/pkg/clock"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/util"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type intersectingProvider struct {
+	providers     []Provider
+	clock         clock.Clock
+	cacheDuration time.Duration
+
+	lock  sync.Mutex
+	cache map[digest.InstanceName]cachedCapabilities
+}
+
+type cachedCapabilities struct {
+	capabilities *remoteexecution.ServerCapabilities
+	expiration   time.Time
+}
+
+// NewIntersectingProvider creates a capabilities provider that reports
+// the cache capabilities that are supported by all of the providers.
+// This can be used by storage backends that spread data across
+// multiple backends (e.g., sharding, mirroring), where any request may
+// end up being sent to any of the backends.
+//
+// Backends are queried in parallel. Responses obtained from all
+// backends are cached for the provided duration. Backends that are
+// unavailable are skipped, so that a single unhealthy backend does not
+// prevent clients from starting. In that case the last known response
+// that was obtained from all backends is returned, if any.
+//
+// Only cache capabilities are reported, as storage backends are not
+// capable of executing anything.
+func NewIntersectingProvider(providers []Provider, clock clock.Clock, cacheDuration time.Duration) Provider {
+	return &intersectingProvider{
+		providers:     providers,
+		clock:         clock,
+		cacheDuration: cacheDuration,
+		cache:         map[digest.InstanceName]cachedCapabilities{},
+	}
+}
+
+func (p *intersectingProvider) GetCapabilities(ctx context.Context, instanceName digest.InstanceName) (*remoteexecution.ServerCapabilities, error) {
+	if len(p.providers) == 0 {
+		return nil, status.Error(codes.Unavailable, "No backends available to obtain capabilities from")
+	}
+
+	p.lock.Lock()
+	cached, hasCached := p.cache[instanceName]
+	p.lock.Unlock()
+	if hasCached && p.clock.Now().Before(cached.expiration) {
+		// Return a copy, so that callers may safely alter the
+		// response.
+		return proto.Clone(cached.capabilities).(*remoteexecution.ServerCapabilities), nil
+	}
+
+	// Query all backends in parallel.
+	responses := make([]*remoteexecution.ServerCapabilities, len(p.providers))
+	errs := make([]error, len(p.providers))
+	var wg sync.WaitGroup
+	wg.Add(len(p.providers))
+	for i, provider := range p.providers {
+		go func(i int, provider Provider) {
+			defer wg.Done()
+			responses[i], errs[i] = provider.GetCapabilities(ctx, instanceName)
+		}(i, provider)
+	}
+	wg.Wait()
+
+	var intersected *remoteexecution.CacheCapabilities
+	var firstUnavailableErr error
+	for i, err := range errs {
+		if err != nil {
+			if status.Code(err) != codes.Unavailable {
+				return nil, util.StatusWrapf(err, "Backend %d", i)
+			}
+			if firstUnavailableErr == nil {
+				firstUnavailableErr = util.StatusWrapf(err, "Backend %d", i)
+			}
+			continue
+		}
+		intersected = intersectCacheCapabilities(intersected, responses[i].CacheCapabilities)
+	}
+
+	if firstUnavailableErr != nil {
+		// Not all backends could be queried. Prefer returning
+		// a response that was obtained from all backends
+		// previously, as the current response may announce
+		// features that the unavailable backends lack.
+		if hasCached {
+			return proto.Clone(cached.capabilities).(*remoteexecution.ServerCapabilities), nil
+		}
+		if intersected == nil {
+			return nil, firstUnavailableErr
+		}
+		return &remoteexecution.ServerCapabilities{
+			CacheCapabilities: intersected,
+		}, nil
+	}
+
+	capabilities := &remoteexecution.ServerCapabilities{
+		CacheCapabilities: intersected,
+	}
+	now := p.clock.Now()
+	p.lock.Lock()
+	for cachedInstanceName, cached := range p.cache {
+		if !now.Before(cached.expiration) {
+			delete(p.cache, cachedInstanceName)
+		}
+	}
+	p.cache[instanceName] = cachedCapabilities{
+		capabilities: capabilities,
+		expiration:   now.Add(p.cacheDuration),
+	}
+	p.lock.Unlock()
+	return proto.Clone(capabilities).(*remoteexecution.ServerCapabilities), nil
+}
+
+// intersectCacheCapabilities computes the intersection of the cache
+// capabilities obtained from two backends. If intersected is nil, a
+// copy of cacheCapabilities is returned.
+func intersectCacheCapabilities(intersected, cacheCapabilities *remoteexecution.CacheCapabilities) *remoteexecution.CacheCapabilities {
+	if cacheCapabilities == nil {
+		cacheCapabilities = &remoteexecution.CacheCapabilities{}
+	}
+	if intersected == nil {
+		return &remoteexecution.CacheCapabilities{
+			DigestFunctions:                 append([]remoteexecution.DigestFunction_Value(nil), cacheCapabilities.DigestFunctions...),
+			ActionCacheUpdateCapabilities:   &remoteexecution.ActionCacheUpdateCapabilities{UpdateEnabled: cacheCapabilities.ActionCacheUpdateCapabilities.GetUpdateEnabled()},
+			MaxBatchTotalSizeBytes:          cacheCapabilities.MaxBatchTotalSizeBytes,
+			SymlinkAbsolutePathStrategy:     cacheCapabilities.SymlinkAbsolutePathStrategy,
+			SupportedCompressors:            append([]remoteexecution.Compressor_Value(nil), cacheCapabilities.SupportedCompressors...),
+			SupportedBatchUpdateCompressors: append([]remoteexecution.Compressor_Value(nil), cacheCapabilities.SupportedBatchUpdateCompressors...),
+		}
+	}
+
+	intersected.DigestFunctions = intersectDigestFunctions(intersected.DigestFunctions, cacheCapabilities.DigestFunctions)
+	if !cacheCapabilities.ActionCacheUpdateCapabilities.GetUpdateEnabled() {
+		intersected.ActionCacheUpdateCapabilities.UpdateEnabled = false
+	}
+	// A limit of zero indicates that no limit is imposed.
+	if limit := cacheCapabilities.MaxBatchTotalSizeBytes; limit != 0 && (intersected.MaxBatchTotalSizeBytes == 0 || limit < intersected.MaxBatchTotalSizeBytes) {
+		intersected.MaxBatchTotalSizeBytes = limit
+	}
+	if strategy := cacheCapabilities.SymlinkAbsolutePathStrategy; strategy != intersected.SymlinkAbsolutePathStrategy {
+		if strategy == remoteexecution.SymlinkAbsolutePathStrategy_DISALLOWED || intersected.SymlinkAbsolutePathStrategy == remoteexecution.SymlinkAbsolutePathStrategy_DISALLOWED {
+			intersected.SymlinkAbsolutePathStrategy = remoteexecution.SymlinkAbsolutePathStrategy_DISALLOWED
+		} else {
+			intersected.SymlinkAbsolutePathStrategy = remoteexecution.SymlinkAbsolutePathStrategy_UNKNOWN
+		}
+	}
+	intersected.SupportedCompressors = intersectCompressors(intersected.SupportedCompressors, cacheCapabilities.SupportedCompressors)
+	intersected.SupportedBatchUpdateCompressors = intersectCompressors(intersected.SupportedBatchUpdateCompressors, cacheCapabilities.SupportedBatchUpdateCompressors)
+	return intersected
+}
+
+// intersectDigestFunctions returns the digest functions contained in a
+// that are also contained in b, while retaining the order of a.
+func intersectDigestFunctions(a, b []remoteexecution.DigestFunction_Value) []remoteexecution.DigestFunction_Value {
+	var intersection []remoteexecution.DigestFunction_Value
+	for _, va := range a {
+		for _, vb := range b {
+			if va == vb {
+				intersection = append(intersection, va)
+				break
+			}
+		}
+	}
+	return intersection
+}
+
+// intersectCompressors returns the compressors contained in a that are
+// also contained in b, while retaining the order of a.
+func intersectCompressors(a, b []remoteexecution.Compressor_Value) []remoteexecution.Compressor_Value {
+	var intersection []remoteexecution.Compressor_Value
+	for _, va := range a {
+		for _, vb := range b {
+			if va == vb {
+				intersection = append(intersection, va)
+				break
+			}
+		}
+	}
+	return intersection
+}
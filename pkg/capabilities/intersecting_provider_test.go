@@ -0,0 +1,167 @@
+package capabilities_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
+	"github.com/buildbarn/bb-storage/internal/mock"
+	"github.com/buildbarn/bb-storage/pkg/capabilities"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/testutil"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIntersectingProvider(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	backendA := mock.NewMockProvider(ctrl)
+	backendB := mock.NewMockProvider(ctrl)
+	clock := mock.NewMockClock(ctrl)
+	provider := capabilities.NewIntersectingProvider([]capabilities.Provider{backendA, backendB}, clock, time.Minute)
+	instanceName := digest.MustNewInstanceName("hello")
+
+	t.Run("NoBackends", func(t *testing.T) {
+		_, err := capabilities.NewIntersectingProvider(nil, clock, time.Minute).GetCapabilities(ctx, instanceName)
+		testutil.RequireEqualStatus(t, status.Error(codes.Unavailable, "No backends available to obtain capabilities from"), err)
+	})
+
+	t.Run("BackendFailure", func(t *testing.T) {
+		// Errors other than UNAVAILABLE should be propagated.
+		backendA.EXPECT().GetCapabilities(ctx, instanceName).
+			Return(&remoteexecution.ServerCapabilities{}, nil)
+		backendB.EXPECT().GetCapabilities(ctx, instanceName).
+			Return(nil, status.Error(codes.Internal, "Server on fire"))
+
+		_, err := provider.GetCapabilities(ctx, instanceName)
+		testutil.RequireEqualStatus(t, status.Error(codes.Internal, "Backend 1: Server on fire"), err)
+	})
+
+	t.Run("AllBackendsUnavailable", func(t *testing.T) {
+		backendA.EXPECT().GetCapabilities(ctx, instanceName).
+			Return(nil, status.Error(codes.Unavailable, "Server not reachable"))
+		backendB.EXPECT().GetCapabilities(ctx, instanceName).
+			Return(nil, status.Error(codes.Unavailable, "Server not reachable"))
+
+		_, err := provider.GetCapabilities(ctx, instanceName)
+		testutil.RequireEqualStatus(t, status.Error(codes.Unavailable, "Backend 0: Server not reachable"), err)
+	})
+
+	t.Run("SomeBackendsUnavailable", func(t *testing.T) {
+		// Backends that are unavailable should be skipped. As
+		// the response is not based on all backends, it should
+		// not be cached.
+		backendA.EXPECT().GetCapabilities(ctx, instanceName).
+			Return(&remoteexecution.ServerCapabilities{
+				CacheCapabilities: &remoteexecution.CacheCapabilities{
+					DigestFunctions: []remoteexecution.DigestFunction_Value{
+						remoteexecution.DigestFunction_SHA256,
+					},
+				},
+			}, nil)
+		backendB.EXPECT().GetCapabilities(ctx, instanceName).
+			Return(nil, status.Error(codes.Unavailable, "Server not reachable"))
+
+		serverCapabilities, err := provider.GetCapabilities(ctx, instanceName)
+		require.NoError(t, err)
+		testutil.RequireEqualProto(t, &remoteexecution.ServerCapabilities{
+			CacheCapabilities: &remoteexecution.CacheCapabilities{
+				DigestFunctions: []remoteexecution.DigestFunction_Value{
+					remoteexecution.DigestFunction_SHA256,
+				},
+				ActionCacheUpdateCapabilities: &remoteexecution.ActionCacheUpdateCapabilities{},
+			},
+		}, serverCapabilities)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		backendA.EXPECT().GetCapabilities(ctx, instanceName).
+			Return(&remoteexecution.ServerCapabilities{
+				CacheCapabilities: &remoteexecution.CacheCapabilities{
+					DigestFunctions: []remoteexecution.DigestFunction_Value{
+						remoteexecution.DigestFunction_SHA256,
+						remoteexecution.DigestFunction_SHA1,
+						remoteexecution.DigestFunction_MD5,
+					},
+					ActionCacheUpdateCapabilities: &remoteexecution.ActionCacheUpdateCapabilities{
+						UpdateEnabled: true,
+					},
+					MaxBatchTotalSizeBytes:      4 * 1024 * 1024,
+					SymlinkAbsolutePathStrategy: remoteexecution.SymlinkAbsolutePathStrategy_ALLOWED,
+					SupportedCompressors: []remoteexecution.Compressor_Value{
+						remoteexecution.Compressor_ZSTD,
+						remoteexecution.Compressor_DEFLATE,
+					},
+				},
+			}, nil)
+		backendB.EXPECT().GetCapabilities(ctx, instanceName).
+			Return(&remoteexecution.ServerCapabilities{
+				CacheCapabilities: &remoteexecution.CacheCapabilities{
+					DigestFunctions: []remoteexecution.DigestFunction_Value{
+						remoteexecution.DigestFunction_MD5,
+						remoteexecution.DigestFunction_SHA256,
+					},
+					ActionCacheUpdateCapabilities: &remoteexecution.ActionCacheUpdateCapabilities{
+						UpdateEnabled: false,
+					},
+					MaxBatchTotalSizeBytes:      1024 * 1024,
+					SymlinkAbsolutePathStrategy: remoteexecution.SymlinkAbsolutePathStrategy_DISALLOWED,
+					SupportedCompressors: []remoteexecution.Compressor_Value{
+						remoteexecution.Compressor_ZSTD,
+					},
+				},
+			}, nil)
+
+		// Only features supported by both backends should be
+		// announced.
+		clock.EXPECT().Now().Return(time.Unix(1000, 0))
+		serverCapabilities, err := provider.GetCapabilities(ctx, instanceName)
+		require.NoError(t, err)
+		expectedCapabilities := &remoteexecution.ServerCapabilities{
+			CacheCapabilities: &remoteexecution.CacheCapabilities{
+				DigestFunctions: []remoteexecution.DigestFunction_Value{
+					remoteexecution.DigestFunction_SHA256,
+					remoteexecution.DigestFunction_MD5,
+				},
+				ActionCacheUpdateCapabilities: &remoteexecution.ActionCacheUpdateCapabilities{
+					UpdateEnabled: false,
+				},
+				MaxBatchTotalSizeBytes:      1024 * 1024,
+				SymlinkAbsolutePathStrategy: remoteexecution.SymlinkAbsolutePathStrategy_DISALLOWED,
+				SupportedCompressors: []remoteexecution.Compressor_Value{
+					remoteexecution.Compressor_ZSTD,
+				},
+			},
+		}
+		testutil.RequireEqualProto(t, expectedCapabilities, serverCapabilities)
+
+		// Callers may alter the response (e.g., to deny Action
+		// Cache updates) without affecting the cached response.
+		serverCapabilities.CacheCapabilities.ActionCacheUpdateCapabilities.UpdateEnabled = true
+		serverCapabilities.CacheCapabilities.DigestFunctions = nil
+
+		// Successive calls should be served from the cache.
+		clock.EXPECT().Now().Return(time.Unix(1059, 0))
+		serverCapabilities, err = provider.GetCapabilities(ctx, instanceName)
+		require.NoError(t, err)
+		testutil.RequireEqualProto(t, expectedCapabilities, serverCapabilities)
+		serverCapabilities.CacheCapabilities.MaxBatchTotalSizeBytes = 1
+
+		// Once expired, the backends should be queried again.
+		// If any of them is unavailable, the previously cached
+		// response should be returned.
+		clock.EXPECT().Now().Return(time.Unix(1060, 0))
+		backendA.EXPECT().GetCapabilities(ctx, instanceName).
+			Return(&remoteexecution.ServerCapabilities{}, nil)
+		backendB.EXPECT().GetCapabilities(ctx, instanceName).
+			Return(nil, status.Error(codes.Unavailable, "Server not reachable"))
+		serverCapabilities, err = provider.GetCapabilities(ctx, instanceName)
+		require.NoError(t, err)
+		testutil.RequireEqualProto(t, expectedCapabilities, serverCapabilities)
+	})
+}
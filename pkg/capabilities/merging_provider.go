@@ -0,0 +1,103 @@
+package capabilities
+
+import (
+	"context"
+
+	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type mergingProvider struct {
+	providers []Provider
+}
+
+// NewMergingProvider creates a capabilities provider that combines the
+// responses of multiple providers that are each responsible for a
+// different part of the protocol (e.g., the Action Cache, the Content
+// Addressable Storage and the scheduler). Fields that are set by
+// multiple providers are merged using proto.Merge(), meaning that
+// scalar fields provided by later providers take precedence. Values of
+// repeated fields (e.g., digest functions and compressors) are
+// concatenated, omitting duplicates.
+//
+// Providers that return NOT_FOUND or PERMISSION_DENIED are skipped, as
+// it is valid for an instance name to only support a subset of the
+// services. An error is only returned if none of the providers is
+// able to return a response.
+func NewMergingProvider(providers []Provider) Provider {
+	return &mergingProvider{
+		providers: providers,
+	}
+}
+
+func (p *mergingProvider) GetCapabilities(ctx context.Context, instanceName digest.InstanceName) (*remoteexecution.ServerCapabilities, error) {
+	var merged *remoteexecution.ServerCapabilities
+	var firstErr error
+	for _, provider := range p.providers {
+		capabilities, err := provider.GetCapabilities(ctx, instanceName)
+		if err != nil {
+			if code := status.Code(err); code != codes.NotFound && code != codes.PermissionDenied {
+				return nil, err
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if merged == nil {
+			merged = &remoteexecution.ServerCapabilities{}
+		}
+		proto.Merge(merged, capabilities)
+	}
+	if merged == nil {
+		if firstErr == nil {
+			return nil, status.Error(codes.NotFound, "No capabilities providers are available for this instance name")
+		}
+		return nil, firstErr
+	}
+
+	// proto.Merge() concatenates repeated fields. Remove values
+	// announced by more than one provider.
+	if cacheCapabilities := merged.CacheCapabilities; cacheCapabilities != nil {
+		cacheCapabilities.DigestFunctions = deduplicateDigestFunctions(cacheCapabilities.DigestFunctions)
+		cacheCapabilities.SupportedCompressors = deduplicateCompressors(cacheCapabilities.SupportedCompressors)
+		cacheCapabilities.SupportedBatchUpdateCompressors = deduplicateCompressors(cacheCapabilities.SupportedBatchUpdateCompressors)
+	}
+	if executionCapabilities := merged.ExecutionCapabilities; executionCapabilities != nil {
+		executionCapabilities.DigestFunctions = deduplicateDigestFunctions(executionCapabilities.DigestFunctions)
+	}
+	return merged, nil
+}
+
+// deduplicateDigestFunctions removes duplicate values from a list of
+// digest functions, while retaining the order in which they first
+// occur.
+func deduplicateDigestFunctions(digestFunctions []remoteexecution.DigestFunction_Value) []remoteexecution.DigestFunction_Value {
+	seen := map[remoteexecution.DigestFunction_Value]struct{}{}
+	deduplicated := digestFunctions[:0]
+	for _, digestFunction := range digestFunctions {
+		if _, ok := seen[digestFunction]; !ok {
+			seen[digestFunction] = struct{}{}
+			deduplicated = append(deduplicated, digestFunction)
+		}
+	}
+	return deduplicated
+}
+
+// deduplicateCompressors removes duplicate values from a list of
+// compressors, while retaining the order in which they first occur.
+func deduplicateCompressors(compressors []remoteexecution.Compressor_Value) []remoteexecution.Compressor_Value {
+	seen := map[remoteexecution.Compressor_Value]struct{}{}
+	deduplicated := compressors[:0]
+	for _, compressor := range compressors {
+		if _, ok := seen[compressor]; !ok {
+			seen[compressor] = struct{}{}
+			deduplicated = append(deduplicated, compressor)
+		}
+	}
+	return deduplicated
+}
@@ -0,0 +1,157 @@
+package capabilities_test
+
+import (
+	"context"
+	"testing"
+
+	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
+	"github.com/buildbarn/bb-storage/internal/mock"
+	"github.com/buildbarn/bb-storage/pkg/capabilities"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/testutil"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMergingProvider(t *testing.T) {
+	ctrl, ctx := gomock.WithContext(context.Background(), t)
+
+	contentAddressableStorage := mock.NewMockProvider(ctrl)
+	actionCache := mock.NewMockProvider(ctrl)
+	buildQueue := mock.NewMockProvider(ctrl)
+	provider := capabilities.NewMergingProvider([]capabilities.Provider{
+		contentAddressableStorage,
+		actionCache,
+		buildQueue,
+	})
+	instanceName := digest.MustNewInstanceName("hello")
+
+	t.Run("BackendFailure", func(t *testing.T) {
+		// Errors other than NOT_FOUND and PERMISSION_DENIED
+		// should be propagated.
+		contentAddressableStorage.EXPECT().GetCapabilities(ctx, instanceName).
+			Return(nil, status.Error(codes.Unavailable, "Server not reachable"))
+
+		_, err := provider.GetCapabilities(ctx, instanceName)
+		testutil.RequireEqualStatus(t, status.Error(codes.Unavailable, "Server not reachable"), err)
+	})
+
+	t.Run("AllFailed", func(t *testing.T) {
+		// If none of the providers are able to return
+		// capabilities, the first error should be returned.
+		contentAddressableStorage.EXPECT().GetCapabilities(ctx, instanceName).
+			Return(nil, status.Error(codes.PermissionDenied, "Not authorized to access the CAS"))
+		actionCache.EXPECT().GetCapabilities(ctx, instanceName).
+			Return(nil, status.Error(codes.PermissionDenied, "Not authorized to access the AC"))
+		buildQueue.EXPECT().GetCapabilities(ctx, instanceName).
+			Return(nil, status.Error(codes.NotFound, "No scheduler for this instance name"))
+
+		_, err := provider.GetCapabilities(ctx, instanceName)
+		testutil.RequireEqualStatus(t, status.Error(codes.PermissionDenied, "Not authorized to access the CAS"), err)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		// Responses of the individual providers should be
+		// combined. Providers that cannot be used should be
+		// ignored.
+		contentAddressableStorage.EXPECT().GetCapabilities(ctx, instanceName).
+			Return(&remoteexecution.ServerCapabilities{
+				CacheCapabilities: &remoteexecution.CacheCapabilities{
+					DigestFunctions: []remoteexecution.DigestFunction_Value{
+						remoteexecution.DigestFunction_SHA256,
+					},
+					MaxBatchTotalSizeBytes: 4 * 1024 * 1024,
+				},
+			}, nil)
+		actionCache.EXPECT().GetCapabilities(ctx, instanceName).
+			Return(&remoteexecution.ServerCapabilities{
+				CacheCapabilities: &remoteexecution.CacheCapabilities{
+					ActionCacheUpdateCapabilities: &remoteexecution.ActionCacheUpdateCapabilities{
+						UpdateEnabled: true,
+					},
+				},
+			}, nil)
+		buildQueue.EXPECT().GetCapabilities(ctx, instanceName).
+			Return(nil, status.Error(codes.NotFound, "No scheduler for this instance name"))
+
+		serverCapabilities, err := provider.GetCapabilities(ctx, instanceName)
+		require.NoError(t, err)
+		testutil.RequireEqualProto(t, &remoteexecution.ServerCapabilities{
+			CacheCapabilities: &remoteexecution.CacheCapabilities{
+				DigestFunctions: []remoteexecution.DigestFunction_Value{
+					remoteexecution.DigestFunction_SHA256,
+				},
+				ActionCacheUpdateCapabilities: &remoteexecution.ActionCacheUpdateCapabilities{
+					UpdateEnabled: true,
+				},
+				MaxBatchTotalSizeBytes: 4 * 1024 * 1024,
+			},
+		}, serverCapabilities)
+	})
+	t.Run("Deduplication", func(t *testing.T) {
+		// Digest functions and compressors announced by
+		// multiple providers should only be reported once.
+		contentAddressableStorage.EXPECT().GetCapabilities(ctx, instanceName).
+			Return(&remoteexecution.ServerCapabilities{
+				CacheCapabilities: &remoteexecution.CacheCapabilities{
+					DigestFunctions: []remoteexecution.DigestFunction_Value{
+						remoteexecution.DigestFunction_SHA256,
+						remoteexecution.DigestFunction_MD5,
+					},
+					SupportedCompressors: []remoteexecution.Compressor_Value{
+						remoteexecution.Compressor_ZSTD,
+					},
+				},
+			}, nil)
+		actionCache.EXPECT().GetCapabilities(ctx, instanceName).
+			Return(&remoteexecution.ServerCapabilities{
+				CacheCapabilities: &remoteexecution.CacheCapabilities{
+					DigestFunctions: []remoteexecution.DigestFunction_Value{
+						remoteexecution.DigestFunction_MD5,
+						remoteexecution.DigestFunction_SHA1,
+					},
+					SupportedCompressors: []remoteexecution.Compressor_Value{
+						remoteexecution.Compressor_ZSTD,
+						remoteexecution.Compressor_DEFLATE,
+					},
+				},
+			}, nil)
+		buildQueue.EXPECT().GetCapabilities(ctx, instanceName).
+			Return(&remoteexecution.ServerCapabilities{
+				ExecutionCapabilities: &remoteexecution.ExecutionCapabilities{
+					DigestFunction: remoteexecution.DigestFunction_SHA256,
+					DigestFunctions: []remoteexecution.DigestFunction_Value{
+						remoteexecution.DigestFunction_SHA256,
+						remoteexecution.DigestFunction_SHA256,
+					},
+					ExecEnabled: true,
+				},
+			}, nil)
+
+		serverCapabilities, err := provider.GetCapabilities(ctx, instanceName)
+		require.NoError(t, err)
+		testutil.RequireEqualProto(t, &remoteexecution.ServerCapabilities{
+			CacheCapabilities: &remoteexecution.CacheCapabilities{
+				DigestFunctions: []remoteexecution.DigestFunction_Value{
+					remoteexecution.DigestFunction_SHA256,
+					remoteexecution.DigestFunction_MD5,
+					remoteexecution.DigestFunction_SHA1,
+				},
+				SupportedCompressors: []remoteexecution.Compressor_Value{
+					remoteexecution.Compressor_ZSTD,
+					remoteexecution.Compressor_DEFLATE,
+				},
+			},
+			ExecutionCapabilities: &remoteexecution.ExecutionCapabilities{
+				DigestFunction: remoteexecution.DigestFunction_SHA256,
+				DigestFunctions: []remoteexecution.DigestFunction_Value{
+					remoteexecution.DigestFunction_SHA256,
+				},
+				ExecEnabled: true,
+			},
+		}, serverCapabilities)
+	})
+}
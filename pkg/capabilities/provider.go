@@ -0,0 +1,18 @@
+package capabilities
+
+import (
+	"context"
+
+	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+)
+
+// Provider of REv2 ServerCapabilities messages.
+//
+// Instead of letting the Capabilities service return a fixed response,
+// components such as storage backends and build queues may each report
+// the capabilities they support. These responses can then be combined
+// to obtain the capabilities of the server as a whole.
+type Provider interface {
+	GetCapabilities(ctx context.Context, instanceName digest.InstanceName) (*remoteexecution.ServerCapabilities, error)
+}
@@ -0,0 +1,40 @@
+package capabilities
+
+import (
+	"context"
+
+	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
+	"github.com/bazelbuild/remote-apis/build/bazel/semver"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+	"github.com/buildbarn/bb-storage/pkg/util"
+)
+
+type server struct {
+	provider Provider
+}
+
+// NewServer creates a gRPC server object for the REv2 Capabilities
+// service. Instead of returning a fixed response, it obtains the
+// capabilities from a provider, typically consisting of the storage
+// backends and build queues that are configured.
+func NewServer(provider Provider) remoteexecution.CapabilitiesServer {
+	return &server{
+		provider: provider,
+	}
+}
+
+func (s *server) GetCapabilities(ctx context.Context, in *remoteexecution.GetCapabilitiesRequest) (*remoteexecution.ServerCapabilities, error) {
+	instanceName, err := digest.NewInstanceName(in.InstanceName)
+	if err != nil {
+		return nil, util.StatusWrapf(err, "Invalid instance name %#v", in.InstanceName)
+	}
+
+	capabilities, err := s.provider.GetCapabilities(ctx, instanceName)
+	if err != nil {
+		return nil, err
+	}
+	// TODO: DeprecatedApiVersion.
+	capabilities.LowApiVersion = &semver.SemVer{Major: 2}
+	capabilities.HighApiVersion = &semver.SemVer{Major: 2}
+	return capabilities, nil
+}
@@ -0,0 +1,27 @@
+package capabilities
+
+import (
+	"context"
+
+	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
+	"github.com/buildbarn/bb-storage/pkg/digest"
+
+	"google.golang.org/protobuf/proto"
+)
+
+type staticProvider struct {
+	capabilities *remoteexecution.ServerCapabilities
+}
+
+// NewStaticProvider creates a capabilities provider that returns a
+// fixed response, regardless of the instance name that is provided.
+func NewStaticProvider(capabilities *remoteexecution.ServerCapabilities) Provider {
+	return &staticProvider{
+		capabilities: capabilities,
+	}
+}
+
+func (p *staticProvider) GetCapabilities(ctx context.Context, instanceName digest.InstanceName) (*remoteexecution.ServerCapabilities, error) {
+	// Return a copy, so that callers may safely alter the response.
+	return proto.Clone(p.capabilities).(*remoteexecution.ServerCapabilities), nil
+}
@@ -0,0 +1,229 @@
+package blake3
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+const (
+	// Size of a BLAKE3 checksum in bytes, using the default output
+	// length.
+	Size = 32
+	// BlockSize of BLAKE3 in bytes.
+	BlockSize = 64
+
+	// Size of the leaves of the tree.
+	chunkSize = 1024
+	// Maximum depth of the tree, assuming objects are at most 2^64
+	// bytes in size.
+	maximumStackDepth = 64 - 10
+
+	// Domain separation flags.
+	flagChunkStart = 1 << 0
+	flagChunkEnd   = 1 << 1
+	flagParent     = 1 << 2
+	flagRoot       = 1 << 3
+)
+
+var iv = [8]uint32{
+	0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a,
+	0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19,
+}
+
+var messagePermutation = [16]int{2, 6, 3, 10, 7, 0, 4, 13, 1, 11, 12, 5, 9, 14, 15, 8}
+
+// g is BLAKE3's quarter-round function.
+func g(state *[16]uint32, a, b, c, d int, mx, my uint32) {
+	state[a] += state[b] + mx
+	state[d] = bits.RotateLeft32(state[d]^state[a], -16)
+	state[c] += state[d]
+	state[b] = bits.RotateLeft32(state[b]^state[c], -12)
+	state[a] += state[b] + my
+	state[d] = bits.RotateLeft32(state[d]^state[a], -8)
+	state[c] += state[d]
+	state[b] = bits.RotateLeft32(state[b]^state[c], -7)
+}
+
+// compress is BLAKE3's compression function. As this implementation
+// only provides outputs having the default length, only the first half
+// of the state is returned.
+func compress(chainingValue *[8]uint32, block *[16]uint32, counter uint64, blockLength, flags uint32) (out [8]uint32) {
+	state := [16]uint32{
+		chainingValue[0], chainingValue[1], chainingValue[2], chainingValue[3],
+		chainingValue[4], chainingValue[5], chainingValue[6], chainingValue[7],
+		iv[0], iv[1], iv[2], iv[3],
+		uint32(counter), uint32(counter >> 32), blockLength, flags,
+	}
+	m := *block
+	for round := 0; ; round++ {
+		g(&state, 0, 4, 8, 12, m[0], m[1])
+		g(&state, 1, 5, 9, 13, m[2], m[3])
+		g(&state, 2, 6, 10, 14, m[4], m[5])
+		g(&state, 3, 7, 11, 15, m[6], m[7])
+		g(&state, 0, 5, 10, 15, m[8], m[9])
+		g(&state, 1, 6, 11, 12, m[10], m[11])
+		g(&state, 2, 7, 8, 13, m[12], m[13])
+		g(&state, 3, 4, 9, 14, m[14], m[15])
+		if round == 6 {
+			break
+		}
+		var permuted [16]uint32
+		for i, j := range messagePermutation {
+			permuted[i] = m[j]
+		}
+		m = permuted
+	}
+	for i := range out {
+		out[i] = state[i] ^ state[i+8]
+	}
+	return
+}
+
+// output holds the input of the compression function that yields
+// either a chaining value or the root hash of the tree.
+type output struct {
+	chainingValue [8]uint32
+	block         [16]uint32
+	counter       uint64
+	blockLength   uint32
+	flags         uint32
+}
+
+func (o *output) getChainingValue() [8]uint32 {
+	return compress(&o.chainingValue, &o.block, o.counter, o.blockLength, o.flags)
+}
+
+func (o *output) getRootHash() [8]uint32 {
+	return compress(&o.chainingValue, &o.block, 0, o.blockLength, o.flags|flagRoot)
+}
+
+func getParentOutput(left, right *[8]uint32) output {
+	o := output{
+		chainingValue: iv,
+		blockLength:   BlockSize,
+		flags:         flagParent,
+	}
+	copy(o.block[:8], left[:])
+	copy(o.block[8:], right[:])
+	return o
+}
+
+type digest struct {
+	// State of the chunk that is currently being hashed.
+	chunkChainingValue [8]uint32
+	chunkCounter       uint64
+	chunkBlocksHashed  int
+	block              [BlockSize]byte
+	blockSizeBytes     int
+
+	// Chaining values of the left subtrees that have been
+	// completed, but whose right siblings have not yet been
+	// completed.
+	stack      [maximumStackDepth][8]uint32
+	stackDepth int
+}
+
+// New creates a hash.Hash that computes BLAKE3 checksums, using the
+// default output length of 32 bytes.
+func New() hash.Hash {
+	return &digest{
+		chunkChainingValue: iv,
+	}
+}
+
+func (d *digest) getBlockWords() (words [16]uint32) {
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint32(d.block[i*4:])
+	}
+	return
+}
+
+func (d *digest) getChunkStartFlag() uint32 {
+	if d.chunkBlocksHashed == 0 {
+		return flagChunkStart
+	}
+	return 0
+}
+
+// getChunkOutput returns the output of the chunk that is currently
+// being hashed, assuming no more data is written into it.
+func (d *digest) getChunkOutput() output {
+	return output{
+		chainingValue: d.chunkChainingValue,
+		block:         d.getBlockWords(),
+		counter:       d.chunkCounter,
+		blockLength:   uint32(d.blockSizeBytes),
+		flags:         d.getChunkStartFlag() | flagChunkEnd,
+	}
+}
+
+func (d *digest) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		if d.blockSizeBytes == BlockSize {
+			if d.chunkBlocksHashed == chunkSize/BlockSize-1 {
+				// The current chunk is full and more
+				// data is provided. Push the chunk onto
+				// the stack, merging it with completed
+				// left subtrees.
+				chunkOutput := d.getChunkOutput()
+				node := chunkOutput.getChainingValue()
+				d.chunkCounter++
+				for chunks := d.chunkCounter; chunks&1 == 0; chunks >>= 1 {
+					d.stackDepth--
+					parentOutput := getParentOutput(&d.stack[d.stackDepth], &node)
+					node = parentOutput.getChainingValue()
+				}
+				d.stack[d.stackDepth] = node
+				d.stackDepth++
+
+				d.chunkChainingValue = iv
+				d.chunkBlocksHashed = 0
+			} else {
+				// The current block is full and more
+				// data is provided. Compress the block.
+				words := d.getBlockWords()
+				d.chunkChainingValue = compress(&d.chunkChainingValue, &words, d.chunkCounter, BlockSize, d.getChunkStartFlag())
+				d.chunkBlocksHashed++
+			}
+			d.block = [BlockSize]byte{}
+			d.blockSizeBytes = 0
+		}
+
+		copied := copy(d.block[d.blockSizeBytes:], p)
+		d.blockSizeBytes += copied
+		p = p[copied:]
+	}
+	return n, nil
+}
+
+func (d *digest) Sum(b []byte) []byte {
+	// Merge the output of the last chunk with all of the left
+	// subtrees on the stack.
+	o := d.getChunkOutput()
+	for i := d.stackDepth - 1; i >= 0; i-- {
+		node := o.getChainingValue()
+		o = getParentOutput(&d.stack[i], &node)
+	}
+
+	var out [Size]byte
+	for i, v := range o.getRootHash() {
+		binary.LittleEndian.PutUint32(out[i*4:], v)
+	}
+	return append(b, out[:]...)
+}
+
+func (d *digest) Reset() {
+	*d = digest{
+		chunkChainingValue: iv,
+	}
+}
+
+func (d *digest) Size() int {
+	return Size
+}
+
+func (d *digest) BlockSize() int {
+	return BlockSize
+}
@@ -0,0 +1,56 @@
+package blake3_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/buildbarn/bb-storage/pkg/digest/blake3"
+	"github.com/stretchr/testify/require"
+)
+
+// Test vectors that are provided by the BLAKE3 reference
+// implementation. Each input is a repeating sequence of bytes 0 to 250.
+var testVectors = []struct {
+	sizeBytes int
+	hash      string
+}{
+	{0, "af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262"},
+	{1, "2d3adedff11b61f14c886e35afa036736dcd87a74d27b5c1510225d0f592e213"},
+	{1024, "42214739f095a406f3fc83deb889744ac00df831c10daa55189b5d121c855af7"},
+	{1025, "d00278ae47eb27b34faecf67b4fe263f82d5412916c1ffd97c8cb7fb814b8444"},
+	{2048, "e776b6028c7cd22a4d0ba182a8bf62205d2ef576467e838ed6f2529b85fba24a"},
+	{3072, "b98cb0ff3623be03326b373de6b9095218513e64f1ee2edd2525c7ad1e5cffd2"},
+	{102400, "bc3e3d41a1146b069abffad3c0d44860cf664390afce4d9661f7902e7943e085"},
+}
+
+func getTestInput(sizeBytes int) []byte {
+	data := make([]byte, sizeBytes)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	return data
+}
+
+func TestBLAKE3(t *testing.T) {
+	for _, testVector := range testVectors {
+		data := getTestInput(testVector.sizeBytes)
+
+		// Write all data at once.
+		hasher := blake3.New()
+		hasher.Write(data)
+		require.Equal(t, testVector.hash, hex.EncodeToString(hasher.Sum(nil)), "Size %d", testVector.sizeBytes)
+
+		// Write data in small increments. Calling Sum() should
+		// not affect the state of the hasher.
+		hasher.Reset()
+		for i := 0; i < len(data); i += 100 {
+			end := i + 100
+			if end > len(data) {
+				end = len(data)
+			}
+			hasher.Write(data[i:end])
+			hasher.Sum(nil)
+		}
+		require.Equal(t, testVector.hash, hex.EncodeToString(hasher.Sum(nil)), "Size %d", testVector.sizeBytes)
+	}
+}
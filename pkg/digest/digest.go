@@ -12,6 +12,8 @@ import (
 	"strings"
 
 	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
+	"github.com/buildbarn/bb-storage/pkg/digest/blake3"
+	"github.com/buildbarn/bb-storage/pkg/digest/sha256tree"
 	"github.com/buildbarn/bb-storage/pkg/util"
 	"github.com/google/uuid"
 
@@ -28,7 +30,83 @@ var (
 	compressorNameToEnum = map[string]remoteexecution.Compressor_Value{}
 )
 
+// DigestFunctionBLAKE3 is the enumeration value of the BLAKE3 digest
+// function in the Remote Execution protocol. It is not declared by the
+// version of the protocol that is used to build this package.
+const DigestFunctionBLAKE3 remoteexecution.DigestFunction_Value = 9
+
+// digestFunctionInfo contains properties of a digest function that is
+// supported by Digest and Function.
+type digestFunctionInfo struct {
+	hasherFactory func() hash.Hash
+	hashLength    int
+
+	// Lowercase name of the digest function, as used in ByteStream
+	// resource names. For digest functions that cannot be inferred
+	// from the hash length, it is also used as a prefix of the key.
+	name                   string
+	inferredFromHashLength bool
+}
+
+var (
+	digestFunctionInfos = map[remoteexecution.DigestFunction_Value]*digestFunctionInfo{
+		remoteexecution.DigestFunction_MD5: {
+			hasherFactory:          md5.New,
+			hashLength:             md5.Size * 2,
+			inferredFromHashLength: true,
+		},
+		remoteexecution.DigestFunction_SHA1: {
+			hasherFactory:          sha1.New,
+			hashLength:             sha1.Size * 2,
+			inferredFromHashLength: true,
+		},
+		remoteexecution.DigestFunction_SHA256: {
+			hasherFactory:          sha256.New,
+			hashLength:             sha256.Size * 2,
+			inferredFromHashLength: true,
+		},
+		remoteexecution.DigestFunction_SHA384: {
+			hasherFactory:          sha512.New384,
+			hashLength:             sha512.Size384 * 2,
+			inferredFromHashLength: true,
+		},
+		remoteexecution.DigestFunction_SHA512: {
+			hasherFactory:          sha512.New,
+			hashLength:             sha512.Size * 2,
+			inferredFromHashLength: true,
+		},
+		remoteexecution.DigestFunction_SHA256TREE: {
+			hasherFactory: sha256tree.New,
+			hashLength:    sha256tree.Size * 2,
+		},
+		DigestFunctionBLAKE3: {
+			hasherFactory: blake3.New,
+			hashLength:    blake3.Size * 2,
+			name:          "blake3",
+		},
+	}
+	hashLengthToDigestFunction = map[int]remoteexecution.DigestFunction_Value{}
+	digestFunctionNameToEnum   = map[string]remoteexecution.DigestFunction_Value{
+		"blake3": DigestFunctionBLAKE3,
+	}
+)
+
 func init() {
+	for value, info := range digestFunctionInfos {
+		if info.inferredFromHashLength {
+			hashLengthToDigestFunction[info.hashLength] = value
+		}
+	}
+	for value, name := range remoteexecution.DigestFunction_Value_name {
+		if enum := remoteexecution.DigestFunction_Value(value); enum != remoteexecution.DigestFunction_UNKNOWN {
+			lowerName := strings.ToLower(name)
+			digestFunctionNameToEnum[lowerName] = enum
+			if info, ok := digestFunctionInfos[enum]; ok {
+				info.name = lowerName
+			}
+		}
+	}
+
 	for value, name := range remoteexecution.Compressor_Value_name {
 		enum := remoteexecution.Compressor_Value(value)
 		if enum != remoteexecution.Compressor_IDENTITY {
@@ -52,6 +130,9 @@ func init() {
 // - They provide utility functions for deriving new digests from them.
 //   This ensures that outputs of build actions automatically use the
 //   same instance name and hashing algorithm.
+// - They keep track of the digest function explicitly. This is
+//   necessary for digest functions that have the same hash length
+//   (e.g., SHA-256 and BLAKE3).
 //
 // Because Digest objects are frequently used as keys (as part of
 // caching data structures or to construct sets without duplicate
@@ -59,6 +140,11 @@ func init() {
 // representation upon creation. All functions that extract individual
 // components (e.g., GetInstanceName(), GetHash*() and GetSizeBytes())
 // operate directly on the key format.
+//
+// The key format is "${hash}-${sizeBytes}-${instanceName}". For digest
+// functions that cannot be inferred from the length of the hash, the
+// key is prefixed with the name of the digest function, followed by a
+// colon. This ensures that keys of existing digests remain unchanged.
 type Digest struct {
 	value string
 }
@@ -68,21 +154,29 @@ type Digest struct {
 var BadDigest Digest
 
 // SupportedDigestFunctions is the list of digest functions supported by
-// digest.Digest, using the enumeration values that are part of the
-// Remote Execution protocol.
+// this implementation, using the enumeration values that are part of
+// the Remote Execution protocol. The first five digest functions can be
+// inferred from the length of the hash, meaning they can also be used
+// by clients that are not capable of specifying the digest function
+// explicitly.
 var SupportedDigestFunctions = []remoteexecution.DigestFunction_Value{
 	remoteexecution.DigestFunction_MD5,
 	remoteexecution.DigestFunction_SHA1,
 	remoteexecution.DigestFunction_SHA256,
 	remoteexecution.DigestFunction_SHA384,
 	remoteexecution.DigestFunction_SHA512,
+	remoteexecution.DigestFunction_SHA256TREE,
+	DigestFunctionBLAKE3,
 }
 
 // Unpack the individual hash, size and instance name fields from the
 // string representation stored inside the Digest object.
-func (d Digest) unpack() (int, int64, int) {
-	// Extract the leading hash.
-	hashEnd := md5.Size * 2
+func (d Digest) unpack() (int, int, int64, int) {
+	// Extract the leading hash, which may be prefixed with the name
+	// of the digest function. Because the shortest hash is longer
+	// than the longest name, the prefix can only be found there.
+	hashStart := strings.IndexByte(d.value[:md5.Size*2], ':') + 1
+	hashEnd := hashStart + md5.Size*2
 	for d.value[hashEnd] != '-' {
 		hashEnd++
 	}
@@ -95,7 +189,27 @@ func (d Digest) unpack() (int, int64, int) {
 		sizeBytesEnd++
 	}
 
-	return hashEnd, sizeBytes, sizeBytesEnd
+	return hashStart, hashEnd, sizeBytes, sizeBytesEnd
+}
+
+// getDigestFunction returns the digest function of the digest, given
+// the bounds of the hash returned by unpack().
+func (d Digest) getDigestFunction(hashStart, hashEnd int) remoteexecution.DigestFunction_Value {
+	if hashStart == 0 {
+		return hashLengthToDigestFunction[hashEnd]
+	}
+	return digestFunctionNameToEnum[d.value[:hashStart-1]]
+}
+
+// getByteStreamDigestFunctionName returns the name of the digest
+// function that needs to be part of ByteStream resource names. The
+// name is omitted for digest functions that can be inferred from the
+// length of the hash.
+func (d Digest) getByteStreamDigestFunctionName(hashStart int) string {
+	if hashStart == 0 {
+		return ""
+	}
+	return d.value[:hashStart-1]
 }
 
 // MustNewDigest constructs a Digest similar to NewDigest, but never
@@ -116,22 +230,27 @@ func MustNewDigest(instanceName, hash string, sizeBytes int64) Digest {
 // NewDigestFromByteStreamReadPath creates a Digest from a string having
 // one of the following formats:
 //
-// - ${instanceName}/blobs/${hash}/${size}
-// - ${instanceName}/compressed-blobs/${compressor}/${hash}/${size}
+// - ${instanceName}/blobs/${digestFunction}/${hash}/${size}
+// - ${instanceName}/compressed-blobs/${compressor}/${digestFunction}/${hash}/${size}
 //
-// This notation is used to read files through the ByteStream service.
+// The ${digestFunction} component is optional for digest functions
+// that can be inferred from the length of the hash. This notation is
+// used to read files through the ByteStream service.
 func NewDigestFromByteStreamReadPath(path string) (Digest, remoteexecution.Compressor_Value, error) {
 	fields := strings.FieldsFunc(path, func(r rune) bool { return r == '/' })
-	if len(fields) < 3 {
-		return BadDigest, remoteexecution.Compressor_IDENTITY, status.Error(codes.InvalidArgument, "Invalid resource naming scheme")
-	}
+	// Determine the end of the instance name by searching for the
+	// "blobs" or "compressed-blobs" component. Instance names are
+	// not permitted to contain these keywords, meaning that this is
+	// unambiguous.
 	split := len(fields) - 3
-	if fields[split] != "blobs" {
-		// Second from last component may be a compression method.
-		if len(fields) < 4 {
+	for {
+		if split < 0 || split < len(fields)-5 {
 			return BadDigest, remoteexecution.Compressor_IDENTITY, status.Error(codes.InvalidArgument, "Invalid resource naming scheme")
 		}
-		split = len(fields) - 4
+		if fields[split] == "blobs" || fields[split] == "compressed-blobs" {
+			break
+		}
+		split--
 	}
 	return newDigestFromByteStreamPathCommon(fields[:split], fields[split:])
 }
@@ -139,10 +258,12 @@ func NewDigestFromByteStreamReadPath(path string) (Digest, remoteexecution.Compr
 // NewDigestFromByteStreamWritePath creates a Digest from a string
 // having one of the following formats:
 //
-// - ${instanceName}/uploads/${uuid}/blobs/${hash}/${size}/${path}
-// - ${instanceName}/uploads/${uuid}/compressed-blobs/${compressor}/${hash}/${size}/${path}
+// - ${instanceName}/uploads/${uuid}/blobs/${digestFunction}/${hash}/${size}/${path}
+// - ${instanceName}/uploads/${uuid}/compressed-blobs/${compressor}/${digestFunction}/${hash}/${size}/${path}
 //
-// This notation is used to write files through the ByteStream service.
+// The ${digestFunction} component is optional for digest functions
+// that can be inferred from the length of the hash. This notation is
+// used to write files through the ByteStream service.
 func NewDigestFromByteStreamWritePath(path string) (Digest, remoteexecution.Compressor_Value, error) {
 	fields := strings.FieldsFunc(path, func(r rune) bool { return r == '/' })
 	if len(fields) < 5 {
@@ -176,9 +297,16 @@ func newDigestFromByteStreamPathCommon(header, trailer []string) (Digest, remote
 			return BadDigest, remoteexecution.Compressor_IDENTITY, status.Errorf(codes.Unimplemented, "Unsupported compression scheme %#v", trailer[1])
 		}
 		trailer = trailer[2:]
-		if len(trailer) < 2 {
-			return BadDigest, remoteexecution.Compressor_IDENTITY, status.Error(codes.InvalidArgument, "Invalid resource naming scheme")
-		}
+	}
+
+	// Remove the optional digest function name. Names of digest
+	// functions never overlap with hashes, as hashes are longer.
+	digestFunction, hasDigestFunction := digestFunctionNameToEnum[trailer[0]]
+	if hasDigestFunction {
+		trailer = trailer[1:]
+	}
+	if len(trailer) < 2 {
+		return BadDigest, remoteexecution.Compressor_IDENTITY, status.Error(codes.InvalidArgument, "Invalid resource naming scheme")
 	}
 
 	sizeBytes, err := strconv.ParseInt(trailer[1], 10, 64)
@@ -189,41 +317,55 @@ func newDigestFromByteStreamPathCommon(header, trailer []string) (Digest, remote
 	if err != nil {
 		return BadDigest, remoteexecution.Compressor_IDENTITY, util.StatusWrapf(err, "Invalid instance name %#v", strings.Join(header, "/"))
 	}
-	d, err := instanceName.NewDigest(trailer[0], sizeBytes)
+	if !hasDigestFunction {
+		d, err := instanceName.NewDigest(trailer[0], sizeBytes)
+		return d, compressor, err
+	}
+	f, err := instanceName.GetDigestFunction(digestFunction)
+	if err != nil {
+		return BadDigest, remoteexecution.Compressor_IDENTITY, err
+	}
+	d, err := f.NewDigest(trailer[0], sizeBytes)
 	return d, compressor, err
 }
 
 // GetByteStreamReadPath converts the Digest to a string having
 // one of the following formats:
 //
-// - ${instanceName}/blobs/${hash}/${size}
-// - ${instanceName}/compressed-blobs/${compressor}/${hash}/${size}
+// - ${instanceName}/blobs/${digestFunction}/${hash}/${size}
+// - ${instanceName}/compressed-blobs/${compressor}/${digestFunction}/${hash}/${size}
 //
-// This notation is used to read files through the ByteStream service.
+// The ${digestFunction} component is omitted for digest functions that
+// can be inferred from the length of the hash. This notation is used to
+// read files through the ByteStream service.
 func (d Digest) GetByteStreamReadPath(compressor remoteexecution.Compressor_Value) string {
-	hashEnd, sizeBytes, sizeBytesEnd := d.unpack()
+	hashStart, hashEnd, sizeBytes, sizeBytesEnd := d.unpack()
 	return path.Join(
 		d.value[sizeBytesEnd+1:],
 		compressorEnumToMidfix[compressor],
-		d.value[:hashEnd],
+		d.getByteStreamDigestFunctionName(hashStart),
+		d.value[hashStart:hashEnd],
 		strconv.FormatInt(sizeBytes, 10))
 }
 
 // GetByteStreamWritePath converts the Digest to a string having one of
 // the following formats:
 //
-// - ${instanceName}/uploads/${uuid}/blobs/${hash}/${size}
-// - ${instanceName}/uploads/${uuid}/compressed-blobs/${compressor}/${hash}/${size}
+// - ${instanceName}/uploads/${uuid}/blobs/${digestFunction}/${hash}/${size}
+// - ${instanceName}/uploads/${uuid}/compressed-blobs/${compressor}/${digestFunction}/${hash}/${size}
 //
-// This notation is used to write files through the ByteStream service.
+// The ${digestFunction} component is omitted for digest functions that
+// can be inferred from the length of the hash. This notation is used to
+// write files through the ByteStream service.
 func (d Digest) GetByteStreamWritePath(uuid uuid.UUID, compressor remoteexecution.Compressor_Value) string {
-	hashEnd, sizeBytes, sizeBytesEnd := d.unpack()
+	hashStart, hashEnd, sizeBytes, sizeBytesEnd := d.unpack()
 	return path.Join(
 		d.value[sizeBytesEnd+1:],
 		"uploads",
 		uuid.String(),
 		compressorEnumToMidfix[compressor],
-		d.value[:hashEnd],
+		d.getByteStreamDigestFunctionName(hashStart),
+		d.value[hashStart:hashEnd],
 		strconv.FormatInt(sizeBytes, 10))
 }
 
@@ -231,16 +373,16 @@ func (d Digest) GetByteStreamWritePath(uuid uuid.UUID, compressor remoteexecutio
 // execution protocol, so that it may be stored in messages returned to
 // the client.
 func (d Digest) GetProto() *remoteexecution.Digest {
-	hashEnd, sizeBytes, _ := d.unpack()
+	hashStart, hashEnd, sizeBytes, _ := d.unpack()
 	return &remoteexecution.Digest{
-		Hash:      d.value[:hashEnd],
+		Hash:      d.value[hashStart:hashEnd],
 		SizeBytes: sizeBytes,
 	}
 }
 
 // GetInstanceName returns the instance name of the object.
 func (d Digest) GetInstanceName() InstanceName {
-	_, _, sizeBytesEnd := d.unpack()
+	_, _, _, sizeBytesEnd := d.unpack()
 	return InstanceName{
 		value: d.value[sizeBytesEnd+1:],
 	}
@@ -257,13 +399,13 @@ func (d Digest) GetHashBytes() []byte {
 
 // GetHashString returns the hash of the object as a string.
 func (d Digest) GetHashString() string {
-	hashEnd, _, _ := d.unpack()
-	return d.value[:hashEnd]
+	hashStart, hashEnd, _, _ := d.unpack()
+	return d.value[hashStart:hashEnd]
 }
 
 // GetSizeBytes returns the size of the object, in bytes.
 func (d Digest) GetSizeBytes() int64 {
-	_, sizeBytes, _ := d.unpack()
+	_, _, sizeBytes, _ := d.unpack()
 	return sizeBytes
 }
 
@@ -299,7 +441,7 @@ const (
 func (d Digest) GetKey(format KeyFormat) string {
 	switch format {
 	case KeyWithoutInstance:
-		_, _, sizeBytesEnd := d.unpack()
+		_, _, _, sizeBytesEnd := d.unpack()
 		return d.value[:sizeBytesEnd]
 	case KeyWithInstance:
 		return d.value
@@ -320,30 +462,13 @@ func (d Digest) ToSingletonSet() Set {
 	}
 }
 
-func getHasherFactory(hashLength int) func() hash.Hash {
-	switch hashLength {
-	case md5.Size * 2:
-		return md5.New
-	case sha1.Size * 2:
-		return sha1.New
-	case sha256.Size * 2:
-		return sha256.New
-	case sha512.Size384 * 2:
-		return sha512.New384
-	case sha512.Size * 2:
-		return sha512.New
-	default:
-		panic("Digest hash is of unknown type")
-	}
-}
-
 // NewHasher creates a standard hash.Hash object that may be used to
 // compute a checksum of data. The hash.Hash object uses the same
 // algorithm as the one that was used to create the digest, making it
 // possible to validate data against a digest.
 func (d Digest) NewHasher() hash.Hash {
-	hashEnd, _, _ := d.unpack()
-	return getHasherFactory(hashEnd)()
+	hashStart, hashEnd, _, _ := d.unpack()
+	return digestFunctionInfos[d.getDigestFunction(hashStart, hashEnd)].hasherFactory()
 }
 
 // GetDigestFunction returns a Function object that can be used to
@@ -352,13 +477,12 @@ func (d Digest) NewHasher() hash.Hash {
 // to be derived based on an existing instance. For example, to generate
 // a digest of an output file of a build action, given an action digest.
 func (d Digest) GetDigestFunction() Function {
-	hashEnd, _, sizeBytesEnd := d.unpack()
+	hashStart, hashEnd, _, sizeBytesEnd := d.unpack()
 	return Function{
 		instanceName: InstanceName{
 			value: d.value[sizeBytesEnd+1:],
 		},
-		hasherFactory: getHasherFactory(hashEnd),
-		hashLength:    hashEnd,
+		digestFunction: d.getDigestFunction(hashStart, hashEnd),
 	}
 }
 
@@ -366,8 +490,8 @@ func (d Digest) GetDigestFunction() Function {
 // name and uses the same hashing algorithm as a provided Function
 // object.
 func (d Digest) UsesDigestFunction(f Function) bool {
-	hashEnd, _, sizeBytesEnd := d.unpack()
-	return hashEnd == f.hashLength && d.value[sizeBytesEnd+1:] == f.instanceName.value
+	hashStart, hashEnd, _, sizeBytesEnd := d.unpack()
+	return d.getDigestFunction(hashStart, hashEnd) == f.digestFunction && d.value[sizeBytesEnd+1:] == f.instanceName.value
 }
 
 // GetDigestsWithParentInstanceNames returns a list of Digest objects
@@ -379,7 +503,7 @@ func (d Digest) UsesDigestFunction(f Function) bool {
 // list of six digests, having instance names "", "this", "this/is",
 // "this/is/an", "this/is/an/instance" and "this/is/an/instance/name".
 func (d Digest) GetDigestsWithParentInstanceNames() []Digest {
-	_, _, sizeBytesEnd := d.unpack()
+	_, _, _, sizeBytesEnd := d.unpack()
 	instanceNameStart := sizeBytesEnd + 1
 	digestWithoutInstanceName := Digest{
 		value: d.value[:instanceNameStart],
@@ -79,6 +79,34 @@ func TestNewDigestFromByteStreamReadPath(t *testing.T) {
 		require.Equal(t, digest.MustNewDigest("hello/world", "8b1a9953c4611296a827abf8c47804d7", 123), d)
 		require.Equal(t, remoteexecution.Compressor_DEFLATE, compressor)
 	})
+
+	t.Run("UnsupportedDigestFunction", func(t *testing.T) {
+		_, _, err := digest.NewDigestFromByteStreamReadPath("hello/blobs/murmur3/8b1a9953c4611296a827abf8c47804d7/123")
+		require.Equal(t, err, status.Error(codes.InvalidArgument, "Unknown digest function"))
+	})
+
+	t.Run("DigestFunctionHashLengthMismatch", func(t *testing.T) {
+		_, _, err := digest.NewDigestFromByteStreamReadPath("hello/blobs/blake3/8b1a9953c4611296a827abf8c47804d7/123")
+		require.Equal(t, err, status.Error(codes.InvalidArgument, "Digest hash has length 32, while 64 characters were expected"))
+	})
+
+	t.Run("ExplicitSHA256", func(t *testing.T) {
+		// Digest functions that can be inferred from the hash
+		// length may still be provided explicitly.
+		d, compressor, err := digest.NewDigestFromByteStreamReadPath("hello/blobs/sha256/6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85/123")
+		require.NoError(t, err)
+		require.Equal(t, digest.MustNewDigest("hello", "6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85", 123), d)
+		require.Equal(t, remoteexecution.Compressor_IDENTITY, compressor)
+	})
+
+	t.Run("BLAKE3", func(t *testing.T) {
+		d, compressor, err := digest.NewDigestFromByteStreamReadPath("hello/world/compressed-blobs/zstd/blake3/6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85/123")
+		require.NoError(t, err)
+		expectedDigest, err := digest.MustNewFunction("hello/world", digest.DigestFunctionBLAKE3).NewDigest("6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85", 123)
+		require.NoError(t, err)
+		require.Equal(t, expectedDigest, d)
+		require.Equal(t, remoteexecution.Compressor_ZSTD, compressor)
+	})
 }
 
 func TestNewDigestFromByteStreamWritePath(t *testing.T) {
@@ -158,6 +186,15 @@ func TestNewDigestFromByteStreamWritePath(t *testing.T) {
 		require.Equal(t, digest.MustNewDigest("hello/world", "8b1a9953c4611296a827abf8c47804d7", 123), d)
 		require.Equal(t, remoteexecution.Compressor_DEFLATE, compressor)
 	})
+
+	t.Run("BLAKE3", func(t *testing.T) {
+		d, compressor, err := digest.NewDigestFromByteStreamWritePath("hello/world/uploads/da2f1135-326b-4956-b920-1646cdd6cb53/blobs/blake3/6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85/123/foo.txt")
+		require.NoError(t, err)
+		expectedDigest, err := digest.MustNewFunction("hello/world", digest.DigestFunctionBLAKE3).NewDigest("6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85", 123)
+		require.NoError(t, err)
+		require.Equal(t, expectedDigest, d)
+		require.Equal(t, remoteexecution.Compressor_IDENTITY, compressor)
+	})
 }
 
 func TestDigestGetByteStreamReadPath(t *testing.T) {
@@ -200,6 +237,15 @@ func TestDigestGetByteStreamReadPath(t *testing.T) {
 			"hello/world/compressed-blobs/deflate/8b1a9953c4611296a827abf8c47804d7/123",
 			d.GetByteStreamReadPath(remoteexecution.Compressor_DEFLATE))
 	})
+
+	t.Run("BLAKE3", func(t *testing.T) {
+		d, err := digest.MustNewFunction("hello", digest.DigestFunctionBLAKE3).NewDigest("6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85", 123)
+		require.NoError(t, err)
+		require.Equal(
+			t,
+			"hello/compressed-blobs/zstd/blake3/6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85/123",
+			d.GetByteStreamReadPath(remoteexecution.Compressor_ZSTD))
+	})
 }
 
 func TestDigestGetByteStreamWritePath(t *testing.T) {
@@ -379,3 +425,46 @@ func TestDigestGetDigestsWithParentInstanceNames(t *testing.T) {
 		},
 		digest.MustNewDigest("hello/world/cup", "3d6b0f4e4ba25243c43e045dfe23845a", 123).GetDigestsWithParentInstanceNames())
 }
+
+func TestDigestGetDigestFunction(t *testing.T) {
+	t.Run("InferredFromHashLength", func(t *testing.T) {
+		d := digest.MustNewDigest("hello", "6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85", 3)
+		f := d.GetDigestFunction()
+		require.Equal(t, remoteexecution.DigestFunction_SHA256, f.GetEnumValue())
+		require.True(t, d.UsesDigestFunction(digest.MustNewFunction("hello", remoteexecution.DigestFunction_SHA256)))
+		require.False(t, d.UsesDigestFunction(digest.MustNewFunction("hello", digest.DigestFunctionBLAKE3)))
+
+		// Digests that are created with a digest function that
+		// can be inferred from the hash length should be
+		// identical to ones for which it is inferred.
+		explicitDigest, err := f.NewDigest("6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85", 3)
+		require.NoError(t, err)
+		require.Equal(t, d, explicitDigest)
+	})
+
+	t.Run("BLAKE3", func(t *testing.T) {
+		// The digest function should be retained, even though
+		// the hash has the same length as SHA-256.
+		g := digest.MustNewFunction("hello", digest.DigestFunctionBLAKE3).NewGenerator()
+		g.Write([]byte("abc"))
+		d := g.Sum()
+		require.Equal(t, "6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85", d.GetHashString())
+		require.Equal(t, "blake3:6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85-3-hello", d.GetKey(digest.KeyWithInstance))
+		require.Equal(t, "blake3:6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85-3", d.GetKey(digest.KeyWithoutInstance))
+		require.Equal(t, digest.MustNewInstanceName("hello"), d.GetInstanceName())
+		require.Equal(t, int64(3), d.GetSizeBytes())
+		require.Equal(t, digest.DigestFunctionBLAKE3, d.GetDigestFunction().GetEnumValue())
+		require.True(t, d.UsesDigestFunction(digest.MustNewFunction("hello", digest.DigestFunctionBLAKE3)))
+		require.False(t, d.UsesDigestFunction(digest.MustNewFunction("hello", remoteexecution.DigestFunction_SHA256)))
+		require.NotEqual(t, digest.MustNewDigest("hello", "6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85", 3), d)
+
+		hasher := d.NewHasher()
+		hasher.Write([]byte("abc"))
+		require.Equal(t, d.GetHashBytes(), hasher.Sum(nil))
+	})
+
+	t.Run("UnknownDigestFunction", func(t *testing.T) {
+		_, err := digest.MustNewInstanceName("hello").GetDigestFunction(remoteexecution.DigestFunction_VSO)
+		require.Equal(t, err, status.Error(codes.InvalidArgument, "Unknown digest function"))
+	})
+}
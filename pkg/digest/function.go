@@ -5,14 +5,16 @@ import (
 	"hash"
 
 	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Function for computing new Digest objects. Function is a tuple of the
 // REv2 instance name and hashing algorithm.
 type Function struct {
-	instanceName  InstanceName
-	hasherFactory func() hash.Hash
-	hashLength    int
+	instanceName   InstanceName
+	digestFunction remoteexecution.DigestFunction_Value
 }
 
 // MustNewFunction constructs a Function similar to
@@ -37,21 +39,50 @@ func (f Function) GetInstanceName() InstanceName {
 	return f.instanceName
 }
 
+// GetEnumValue returns the REv2 enumeration value of the hashing
+// algorithm used by this Function.
+func (f Function) GetEnumValue() remoteexecution.DigestFunction_Value {
+	return f.digestFunction
+}
+
+// NewDigest constructs a Digest object that uses the instance name and
+// hashing algorithm of this Function. Unlike InstanceName.NewDigest(),
+// the hashing algorithm is not inferred from the length of the hash.
+// The object returned by this function is guaranteed to be
+// non-degenerate.
+func (f Function) NewDigest(hash string, sizeBytes int64) (Digest, error) {
+	if l, expectedLength := len(hash), digestFunctionInfos[f.digestFunction].hashLength; l != expectedLength {
+		return BadDigest, status.Errorf(codes.InvalidArgument, "Digest hash has length %d, while %d characters were expected", l, expectedLength)
+	}
+	return f.instanceName.newDigest(f.digestFunction, hash, sizeBytes)
+}
+
+// NewDigestFromProto constructs a Digest object that uses the instance
+// name and hashing algorithm of this Function from a protocol-level
+// digest object. The object returned by this function is guaranteed to
+// be non-degenerate.
+func (f Function) NewDigestFromProto(digest *remoteexecution.Digest) (Digest, error) {
+	if digest == nil {
+		return BadDigest, status.Error(codes.InvalidArgument, "No digest provided")
+	}
+	return f.NewDigest(digest.Hash, digest.SizeBytes)
+}
+
 // NewGenerator creates a writer that may be used to compute digests of
 // newly created files.
 func (f Function) NewGenerator() *Generator {
 	return &Generator{
-		instanceName: f.instanceName,
-		partialHash:  f.hasherFactory(),
+		function:    f,
+		partialHash: digestFunctionInfos[f.digestFunction].hasherFactory(),
 	}
 }
 
 // Generator is a writer that may be used to compute digests of newly
 // created files.
 type Generator struct {
-	instanceName InstanceName
-	partialHash  hash.Hash
-	sizeBytes    int64
+	function    Function
+	partialHash hash.Hash
+	sizeBytes   int64
 }
 
 // Write a chunk of data from a newly created file into the state of the
@@ -65,7 +96,8 @@ func (dg *Generator) Write(p []byte) (int, error) {
 // Sum creates a new digest based on the data written into the
 // Generator.
 func (dg *Generator) Sum() Digest {
-	return dg.instanceName.newDigestUnchecked(
+	return dg.function.instanceName.newDigestUnchecked(
+		dg.function.digestFunction,
 		hex.EncodeToString(dg.partialHash.Sum(nil)),
 		dg.sizeBytes)
 }
@@ -1,12 +1,7 @@
 package digest
 
 import (
-	"crypto/md5"
-	"crypto/sha1"
-	"crypto/sha256"
-	"crypto/sha512"
 	"fmt"
-	"hash"
 	"strings"
 
 	remoteexecution "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
@@ -92,14 +87,22 @@ func MustNewInstanceName(value string) InstanceName {
 }
 
 // NewDigest constructs a Digest object from an instance name, hash and
-// object size. The object returned by this function is guaranteed to be
+// object size. The digest function is inferred from the length of the
+// hash. The object returned by this function is guaranteed to be
 // non-degenerate.
 func (in InstanceName) NewDigest(hash string, sizeBytes int64) (Digest, error) {
-	// Validate the hash.
-	if l := len(hash); l != md5.Size*2 && l != sha1.Size*2 &&
-		l != sha256.Size*2 && l != sha512.Size384*2 && l != sha512.Size*2 {
-		return BadDigest, status.Errorf(codes.InvalidArgument, "Unknown digest hash length: %d characters", l)
+	digestFunction, ok := hashLengthToDigestFunction[len(hash)]
+	if !ok {
+		return BadDigest, status.Errorf(codes.InvalidArgument, "Unknown digest hash length: %d characters", len(hash))
 	}
+	return in.newDigest(digestFunction, hash, sizeBytes)
+}
+
+// newDigest constructs a Digest object from an instance name, digest
+// function, hash and object size. The hash is assumed to have a length
+// that corresponds with the digest function.
+func (in InstanceName) newDigest(digestFunction remoteexecution.DigestFunction_Value, hash string, sizeBytes int64) (Digest, error) {
+	// Validate the hash.
 	for _, c := range hash {
 		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
 			return BadDigest, status.Errorf(codes.InvalidArgument, "Non-hexadecimal character in digest hash: %#U", c)
@@ -111,7 +114,7 @@ func (in InstanceName) NewDigest(hash string, sizeBytes int64) (Digest, error) {
 		return BadDigest, status.Errorf(codes.InvalidArgument, "Invalid digest size: %d bytes", sizeBytes)
 	}
 
-	return in.newDigestUnchecked(hash, sizeBytes), nil
+	return in.newDigestUnchecked(digestFunction, hash, sizeBytes), nil
 }
 
 // NewDigestFromProto constructs a Digest object from an instance name
@@ -124,9 +127,34 @@ func (in InstanceName) NewDigestFromProto(digest *remoteexecution.Digest) (Diges
 	return in.NewDigest(digest.Hash, digest.SizeBytes)
 }
 
+// NewDigestFromProtoAndDigestFunction constructs a Digest object from
+// an instance name, an REv2 digest function enumeration value and a
+// protocol-level digest object. It can be used to parse digests
+// contained in requests that have a 'digest_function' field. Clients
+// that don't set this field leave it set to DigestFunction_UNKNOWN, in
+// which case the digest function is inferred from the length of the
+// hash. The object returned by this function is guaranteed to be
+// non-degenerate.
+func (in InstanceName) NewDigestFromProtoAndDigestFunction(digestFunction remoteexecution.DigestFunction_Value, digest *remoteexecution.Digest) (Digest, error) {
+	if digestFunction == remoteexecution.DigestFunction_UNKNOWN {
+		return in.NewDigestFromProto(digest)
+	}
+	f, err := in.GetDigestFunction(digestFunction)
+	if err != nil {
+		return BadDigest, err
+	}
+	return f.NewDigestFromProto(digest)
+}
+
 // newDigestUnchecked constructs a Digest object from an instance name,
-// hash and object size without validating its contents.
-func (in InstanceName) newDigestUnchecked(hash string, sizeBytes int64) Digest {
+// digest function, hash and object size without validating its
+// contents.
+func (in InstanceName) newDigestUnchecked(digestFunction remoteexecution.DigestFunction_Value, hash string, sizeBytes int64) Digest {
+	if info := digestFunctionInfos[digestFunction]; !info.inferredFromHashLength {
+		return Digest{
+			value: fmt.Sprintf("%s:%s-%d-%s", info.name, hash, sizeBytes, in.value),
+		}
+	}
 	return Digest{
 		value: fmt.Sprintf("%s-%d-%s", hash, sizeBytes, in.value),
 	}
@@ -153,30 +181,11 @@ func (in InstanceName) GetComponents() []string {
 // (e.g., on a client that is uploading actions into the Content
 // Addressable Storage).
 func (in InstanceName) GetDigestFunction(digestFunction remoteexecution.DigestFunction_Value) (Function, error) {
-	var hasherFactory func() hash.Hash
-	var hashLength int
-	switch digestFunction {
-	case remoteexecution.DigestFunction_MD5:
-		hasherFactory = md5.New
-		hashLength = md5.Size * 2
-	case remoteexecution.DigestFunction_SHA1:
-		hasherFactory = sha1.New
-		hashLength = sha1.Size * 2
-	case remoteexecution.DigestFunction_SHA256:
-		hasherFactory = sha256.New
-		hashLength = sha256.Size * 2
-	case remoteexecution.DigestFunction_SHA384:
-		hasherFactory = sha512.New384
-		hashLength = sha512.Size384 * 2
-	case remoteexecution.DigestFunction_SHA512:
-		hasherFactory = sha512.New
-		hashLength = sha512.Size * 2
-	default:
+	if _, ok := digestFunctionInfos[digestFunction]; !ok {
 		return Function{}, status.Error(codes.InvalidArgument, "Unknown digest function")
 	}
 	return Function{
-		instanceName:  in,
-		hasherFactory: hasherFactory,
-		hashLength:    hashLength,
+		instanceName:   in,
+		digestFunction: digestFunction,
 	}, nil
 }
@@ -51,6 +51,55 @@ func TestInstanceNameNewDigest(t *testing.T) {
 	require.Equal(t, status.Error(codes.InvalidArgument, "Invalid digest size: -1 bytes"), err)
 }
 
+func TestInstanceNameNewDigestFromProtoAndDigestFunction(t *testing.T) {
+	instanceName := digest.MustNewInstanceName("hello")
+
+	t.Run("InferredFromHashLength", func(t *testing.T) {
+		d, err := instanceName.NewDigestFromProtoAndDigestFunction(
+			remoteexecution.DigestFunction_UNKNOWN,
+			&remoteexecution.Digest{
+				Hash:      "8b1a9953c4611296a827abf8c47804d7",
+				SizeBytes: 5,
+			})
+		require.NoError(t, err)
+		require.Equal(t, digest.MustNewDigest("hello", "8b1a9953c4611296a827abf8c47804d7", 5), d)
+	})
+
+	t.Run("Explicit", func(t *testing.T) {
+		// BLAKE3 hashes have the same length as SHA-256 hashes,
+		// meaning the digest function must be provided explicitly.
+		d, err := instanceName.NewDigestFromProtoAndDigestFunction(
+			digest.DigestFunctionBLAKE3,
+			&remoteexecution.Digest{
+				Hash:      "6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85",
+				SizeBytes: 123,
+			})
+		require.NoError(t, err)
+		require.Equal(t, digest.DigestFunctionBLAKE3, d.GetDigestFunction().GetEnumValue())
+		require.Equal(t, "6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85", d.GetHashString())
+	})
+
+	t.Run("HashLengthMismatch", func(t *testing.T) {
+		_, err := instanceName.NewDigestFromProtoAndDigestFunction(
+			remoteexecution.DigestFunction_SHA256TREE,
+			&remoteexecution.Digest{
+				Hash:      "8b1a9953c4611296a827abf8c47804d7",
+				SizeBytes: 5,
+			})
+		require.Equal(t, status.Error(codes.InvalidArgument, "Digest hash has length 32, while 64 characters were expected"), err)
+	})
+
+	t.Run("UnknownDigestFunction", func(t *testing.T) {
+		_, err := instanceName.NewDigestFromProtoAndDigestFunction(
+			remoteexecution.DigestFunction_VSO,
+			&remoteexecution.Digest{
+				Hash:      "8b1a9953c4611296a827abf8c47804d7",
+				SizeBytes: 5,
+			})
+		require.Equal(t, status.Error(codes.InvalidArgument, "Unknown digest function"), err)
+	})
+}
+
 func TestInstanceNameGetDigestFunction(t *testing.T) {
 	instanceName := digest.MustNewInstanceName("hello")
 
@@ -0,0 +1,156 @@
+package sha256tree
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+const (
+	// Size of a SHA256TREE checksum in bytes.
+	Size = sha256.Size
+	// BlockSize of SHA256TREE in bytes.
+	BlockSize = sha256.BlockSize
+
+	// Size of the leaves of the tree. Objects that are this size or
+	// smaller are hashed using plain SHA-256.
+	chunkSize = 1024
+	// Maximum depth of the tree, assuming objects are at most 2^64
+	// bytes in size.
+	maximumStackDepth = 64 - 10
+)
+
+// Initial hash values used to compute parent nodes. These are the
+// leading fractional parts of the square roots of the 9th to 16th
+// prime number.
+var parentIV = [8]uint32{
+	0xcbbb9d5d, 0x629a292a, 0x9159015a, 0x152fecd8,
+	0x67332667, 0x8eb44a87, 0xdb0c2e0d, 0x47b5481d,
+}
+
+// Round constants of the SHA-256 block cipher.
+var roundConstants = [64]uint32{
+	0x428a2f98, 0x71374491, 0xb5c0fbcf, 0xe9b5dba5, 0x3956c25b, 0x59f111f1, 0x923f82a4, 0xab1c5ed5,
+	0xd807aa98, 0x12835b01, 0x243185be, 0x550c7dc3, 0x72be5d74, 0x80deb1fe, 0x9bdc06a7, 0xc19bf174,
+	0xe49b69c1, 0xefbe4786, 0x0fc19dc6, 0x240ca1cc, 0x2de92c6f, 0x4a7484aa, 0x5cb0a9dc, 0x76f988da,
+	0x983e5152, 0xa831c66d, 0xb00327c8, 0xbf597fc7, 0xc6e00bf3, 0xd5a79147, 0x06ca6351, 0x14292967,
+	0x27b70a85, 0x2e1b2138, 0x4d2c6dfc, 0x53380d13, 0x650a7354, 0x766a0abb, 0x81c2c92e, 0x92722c85,
+	0xa2bfe8a1, 0xa81a664b, 0xc24b8b70, 0xc76c51a3, 0xd192e819, 0xd6990624, 0xf40e3585, 0x106aa070,
+	0x19a4c116, 0x1e376c08, 0x2748774c, 0x34b0bcb5, 0x391c0cb3, 0x4ed8aa4a, 0x5b9cca4f, 0x682e6ff3,
+	0x748f82ee, 0x78a5636f, 0x84c87814, 0x8cc70208, 0x90befffa, 0xa4506ceb, 0xbef9a3f7, 0xc67178f2,
+}
+
+// computeParent computes the hash of a parent node in the tree, by
+// invoking the SHA-256 block cipher on the concatenation of the hashes
+// of its children. Unlike SHA-256's compression function, the original
+// hash values are not added to the output.
+func computeParent(left, right *[Size]byte) (out [Size]byte) {
+	var w [64]uint32
+	for i := 0; i < 8; i++ {
+		w[i] = binary.BigEndian.Uint32(left[i*4:])
+		w[i+8] = binary.BigEndian.Uint32(right[i*4:])
+	}
+	for i := 16; i < 64; i++ {
+		v1, v2 := w[i-2], w[i-15]
+		s1 := bits.RotateLeft32(v1, -17) ^ bits.RotateLeft32(v1, -19) ^ (v1 >> 10)
+		s0 := bits.RotateLeft32(v2, -7) ^ bits.RotateLeft32(v2, -18) ^ (v2 >> 3)
+		w[i] = s1 + w[i-7] + s0 + w[i-16]
+	}
+
+	a, b, c, d, e, f, g, h := parentIV[0], parentIV[1], parentIV[2], parentIV[3], parentIV[4], parentIV[5], parentIV[6], parentIV[7]
+	for i := 0; i < 64; i++ {
+		t1 := h + (bits.RotateLeft32(e, -6) ^ bits.RotateLeft32(e, -11) ^ bits.RotateLeft32(e, -25)) + ((e & f) ^ (^e & g)) + roundConstants[i] + w[i]
+		t2 := (bits.RotateLeft32(a, -2) ^ bits.RotateLeft32(a, -13) ^ bits.RotateLeft32(a, -22)) + ((a & b) ^ (a & c) ^ (b & c))
+		h, g, f, e, d, c, b, a = g, f, e, d+t1, c, b, a, t1+t2
+	}
+
+	for i, v := range [8]uint32{a, b, c, d, e, f, g, h} {
+		binary.BigEndian.PutUint32(out[i*4:], v)
+	}
+	return
+}
+
+type digest struct {
+	// State of the chunk that is currently being hashed.
+	chunk          hash.Hash
+	chunkSizeBytes int
+	chunksHashed   uint64
+
+	// Hashes of the left subtrees that have been completed, but
+	// whose right siblings have not yet been completed.
+	stack      [maximumStackDepth][Size]byte
+	stackDepth int
+}
+
+// New creates a hash.Hash that computes SHA256TREE checksums.
+//
+// SHA256TREE is a digest function that is part of the Remote Execution
+// protocol. Objects that are 1024 bytes or smaller are hashed using
+// plain SHA-256. Larger objects are split into a tree of 1024 byte
+// chunks, whose hashes are combined by invoking the SHA-256 block
+// cipher with alternative initial hash values.
+func New() hash.Hash {
+	return &digest{
+		chunk: sha256.New(),
+	}
+}
+
+func (d *digest) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		if d.chunkSizeBytes == chunkSize {
+			// The current chunk is full and more data is
+			// provided. Push the chunk onto the stack,
+			// merging it with completed left subtrees.
+			var node [Size]byte
+			d.chunk.Sum(node[:0])
+			d.chunksHashed++
+			for chunks := d.chunksHashed; chunks&1 == 0; chunks >>= 1 {
+				d.stackDepth--
+				node = computeParent(&d.stack[d.stackDepth], &node)
+			}
+			d.stack[d.stackDepth] = node
+			d.stackDepth++
+
+			d.chunk.Reset()
+			d.chunkSizeBytes = 0
+		}
+
+		chunkData := p
+		if remaining := chunkSize - d.chunkSizeBytes; len(chunkData) > remaining {
+			chunkData = chunkData[:remaining]
+		}
+		d.chunk.Write(chunkData)
+		d.chunkSizeBytes += len(chunkData)
+		p = p[len(chunkData):]
+	}
+	return n, nil
+}
+
+func (d *digest) Sum(b []byte) []byte {
+	// Merge the hash of the last chunk with all of the left
+	// subtrees on the stack. If the object consists of a single
+	// chunk, its hash is returned directly.
+	var node [Size]byte
+	d.chunk.Sum(node[:0])
+	for i := d.stackDepth - 1; i >= 0; i-- {
+		node = computeParent(&d.stack[i], &node)
+	}
+	return append(b, node[:]...)
+}
+
+func (d *digest) Reset() {
+	d.chunk.Reset()
+	d.chunkSizeBytes = 0
+	d.chunksHashed = 0
+	d.stackDepth = 0
+}
+
+func (d *digest) Size() int {
+	return Size
+}
+
+func (d *digest) BlockSize() int {
+	return BlockSize
+}
@@ -76,6 +76,61 @@ func (x *HistoricalExecuteResponse) GetExecuteResponse() *v2.ExecuteResponse {
 	return nil
 }
 
+type GetTreePageToken struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PendingDirectoryDigests  []*v2.Digest `protobuf:"bytes,1,rep,name=pending_directory_digests,json=pendingDirectoryDigests,proto3" json:"pending_directory_digests,omitempty"`
+	ReturnedDirectoryDigests []*v2.Digest `protobuf:"bytes,2,rep,name=returned_directory_digests,json=returnedDirectoryDigests,proto3" json:"returned_directory_digests,omitempty"`
+}
+
+func (x *GetTreePageToken) Reset() {
+	*x = GetTreePageToken{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_cas_cas_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTreePageToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTreePageToken) ProtoMessage() {}
+
+func (x *GetTreePageToken) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_cas_cas_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTreePageToken.ProtoReflect.Descriptor instead.
+func (*GetTreePageToken) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_cas_cas_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetTreePageToken) GetPendingDirectoryDigests() []*v2.Digest {
+	if x != nil {
+		return x.PendingDirectoryDigests
+	}
+	return nil
+}
+
+func (x *GetTreePageToken) GetReturnedDirectoryDigests() []*v2.Digest {
+	if x != nil {
+		return x.ReturnedDirectoryDigests
+	}
+	return nil
+}
+
 var File_pkg_proto_cas_cas_proto protoreflect.FileDescriptor
 
 var file_pkg_proto_cas_cas_proto_rawDesc = []byte{
@@ -97,11 +152,25 @@ var file_pkg_proto_cas_cas_proto_rawDesc = []byte{
 	0x61, 0x7a, 0x65, 0x6c, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x65, 0x78, 0x65, 0x63,
 	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x32, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65,
 	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x0f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x42,
-	0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x75,
-	0x69, 0x6c, 0x64, 0x62, 0x61, 0x72, 0x6e, 0x2f, 0x62, 0x62, 0x2d, 0x73, 0x74, 0x6f, 0x72, 0x61,
-	0x67, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x61, 0x73,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x22,
+	0xde, 0x01, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x54, 0x72, 0x65, 0x65, 0x50, 0x61, 0x67, 0x65, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x63, 0x0a, 0x19, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f,
+	0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x2e,
+	0x62, 0x61, 0x7a, 0x65, 0x6c, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x65, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x32, 0x2e, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x52, 0x17, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x79, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x12, 0x65, 0x0a, 0x1a, 0x72, 0x65, 0x74,
+	0x75, 0x72, 0x6e, 0x65, 0x64, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x5f,
+	0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x2e, 0x62, 0x61, 0x7a, 0x65, 0x6c, 0x2e, 0x72, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x2e, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x32, 0x2e,
+	0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x52, 0x18, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x65, 0x64,
+	0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73,
+	0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62,
+	0x75, 0x69, 0x6c, 0x64, 0x62, 0x61, 0x72, 0x6e, 0x2f, 0x62, 0x62, 0x2d, 0x73, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x61,
+	0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -116,20 +185,23 @@ func file_pkg_proto_cas_cas_proto_rawDescGZIP() []byte {
 	return file_pkg_proto_cas_cas_proto_rawDescData
 }
 
-var file_pkg_proto_cas_cas_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_pkg_proto_cas_cas_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_pkg_proto_cas_cas_proto_goTypes = []interface{}{
 	(*HistoricalExecuteResponse)(nil), // 0: buildbarn.cas.HistoricalExecuteResponse
-	(*v2.Digest)(nil),                 // 1: build.bazel.remote.execution.v2.Digest
-	(*v2.ExecuteResponse)(nil),        // 2: build.bazel.remote.execution.v2.ExecuteResponse
+	(*GetTreePageToken)(nil),          // 1: buildbarn.cas.GetTreePageToken
+	(*v2.Digest)(nil),                 // 2: build.bazel.remote.execution.v2.Digest
+	(*v2.ExecuteResponse)(nil),        // 3: build.bazel.remote.execution.v2.ExecuteResponse
 }
 var file_pkg_proto_cas_cas_proto_depIdxs = []int32{
-	1, // 0: buildbarn.cas.HistoricalExecuteResponse.action_digest:type_name -> build.bazel.remote.execution.v2.Digest
-	2, // 1: buildbarn.cas.HistoricalExecuteResponse.execute_response:type_name -> build.bazel.remote.execution.v2.ExecuteResponse
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	2, // 0: buildbarn.cas.HistoricalExecuteResponse.action_digest:type_name -> build.bazel.remote.execution.v2.Digest
+	3, // 1: buildbarn.cas.HistoricalExecuteResponse.execute_response:type_name -> build.bazel.remote.execution.v2.ExecuteResponse
+	2, // 2: buildbarn.cas.GetTreePageToken.pending_directory_digests:type_name -> build.bazel.remote.execution.v2.Digest
+	2, // 3: buildbarn.cas.GetTreePageToken.returned_directory_digests:type_name -> build.bazel.remote.execution.v2.Digest
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_pkg_proto_cas_cas_proto_init() }
@@ -150,6 +222,18 @@ func file_pkg_proto_cas_cas_proto_init() {
 				return nil
 			}
 		}
+		file_pkg_proto_cas_cas_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTreePageToken); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -157,7 +241,7 @@ func file_pkg_proto_cas_cas_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_proto_cas_cas_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -237,8 +237,9 @@ type GetPreviousExecutionStatsRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	InstanceName        string     `protobuf:"bytes,1,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty"`
-	ReducedActionDigest *v2.Digest `protobuf:"bytes,2,opt,name=reduced_action_digest,json=reducedActionDigest,proto3" json:"reduced_action_digest,omitempty"`
+	InstanceName        string                  `protobuf:"bytes,1,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty"`
+	ReducedActionDigest *v2.Digest              `protobuf:"bytes,2,opt,name=reduced_action_digest,json=reducedActionDigest,proto3" json:"reduced_action_digest,omitempty"`
+	DigestFunction      v2.DigestFunction_Value `protobuf:"varint,3,opt,name=digest_function,json=digestFunction,proto3,enum=build.bazel.remote.execution.v2.DigestFunction_Value" json:"digest_function,omitempty"`
 }
 
 func (x *GetPreviousExecutionStatsRequest) Reset() {
@@ -287,6 +288,13 @@ func (x *GetPreviousExecutionStatsRequest) GetReducedActionDigest() *v2.Digest {
 	return nil
 }
 
+func (x *GetPreviousExecutionStatsRequest) GetDigestFunction() v2.DigestFunction_Value {
+	if x != nil {
+		return x.DigestFunction
+	}
+	return v2.DigestFunction_Value(0)
+}
+
 type UpdatePreviousExecutionStatsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -295,6 +303,7 @@ type UpdatePreviousExecutionStatsRequest struct {
 	InstanceName           string                  `protobuf:"bytes,1,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty"`
 	ReducedActionDigest    *v2.Digest              `protobuf:"bytes,2,opt,name=reduced_action_digest,json=reducedActionDigest,proto3" json:"reduced_action_digest,omitempty"`
 	PreviousExecutionStats *PreviousExecutionStats `protobuf:"bytes,3,opt,name=previous_execution_stats,json=previousExecutionStats,proto3" json:"previous_execution_stats,omitempty"`
+	DigestFunction         v2.DigestFunction_Value `protobuf:"varint,4,opt,name=digest_function,json=digestFunction,proto3,enum=build.bazel.remote.execution.v2.DigestFunction_Value" json:"digest_function,omitempty"`
 }
 
 func (x *UpdatePreviousExecutionStatsRequest) Reset() {
@@ -350,6 +359,13 @@ func (x *UpdatePreviousExecutionStatsRequest) GetPreviousExecutionStats() *Previ
 	return nil
 }
 
+func (x *UpdatePreviousExecutionStatsRequest) GetDigestFunction() v2.DigestFunction_Value {
+	if x != nil {
+		return x.DigestFunction
+	}
+	return v2.DigestFunction_Value(0)
+}
+
 var File_pkg_proto_iscc_iscc_proto protoreflect.FileDescriptor
 
 var file_pkg_proto_iscc_iscc_proto_rawDesc = []byte{
@@ -406,8 +422,8 @@ var file_pkg_proto_iscc_iscc_proto_rawDesc = []byte{
 	0x79, 0x12, 0x37, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x21, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x62, 0x61, 0x72, 0x6e, 0x2e, 0x69, 0x73, 0x63,
 	0x63, 0x2e, 0x50, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x53, 0x74,
-	0x61, 0x74, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xa4,
-	0x01, 0x0a, 0x20, 0x47, 0x65, 0x74, 0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x45, 0x78,
+	0x61, 0x74, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x84,
+	0x02, 0x0a, 0x20, 0x47, 0x65, 0x74, 0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x45, 0x78,
 	0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f,
 	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74,
@@ -417,7 +433,13 @@ var file_pkg_proto_iscc_iscc_proto_rawDesc = []byte{
 	0x62, 0x61, 0x7a, 0x65, 0x6c, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x65, 0x78, 0x65,
 	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x32, 0x2e, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74,
 	0x52, 0x13, 0x72, 0x65, 0x64, 0x75, 0x63, 0x65, 0x64, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44,
-	0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x89, 0x02, 0x0a, 0x23, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x5e, 0x0a, 0x0f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x5f,
+	0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x35,
+	0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x2e, 0x62, 0x61, 0x7a, 0x65, 0x6c, 0x2e, 0x72, 0x65, 0x6d,
+	0x6f, 0x74, 0x65, 0x2e, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x32,
+	0x2e, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0e, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x46, 0x75, 0x6e,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xe9, 0x02, 0x0a, 0x23, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
 	0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
 	0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a,
 	0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
@@ -434,7 +456,13 @@ var file_pkg_proto_iscc_iscc_proto_rawDesc = []byte{
 	0x63, 0x63, 0x2e, 0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x45, 0x78, 0x65, 0x63, 0x75,
 	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x16, 0x70, 0x72, 0x65, 0x76, 0x69,
 	0x6f, 0x75, 0x73, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74,
-	0x73, 0x32, 0xfb, 0x01, 0x0a, 0x15, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x53, 0x69, 0x7a,
+	0x73, 0x12, 0x5e, 0x0a, 0x0f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x5f, 0x66, 0x75, 0x6e, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x35, 0x2e, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x2e, 0x62, 0x61, 0x7a, 0x65, 0x6c, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e,
+	0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x32, 0x2e, 0x44, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x52, 0x0e, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x32, 0xfb, 0x01, 0x0a, 0x15, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x53, 0x69, 0x7a,
 	0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x43, 0x61, 0x63, 0x68, 0x65, 0x12, 0x75, 0x0a, 0x19, 0x47,
 	0x65, 0x74, 0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
 	0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x30, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64,
@@ -480,6 +508,7 @@ var file_pkg_proto_iscc_iscc_proto_goTypes = []interface{}{
 	(*durationpb.Duration)(nil),   // 7: google.protobuf.Duration
 	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
 	(*v2.Digest)(nil),             // 9: build.bazel.remote.execution.v2.Digest
+	(v2.DigestFunction_Value)(0),  // 10: build.bazel.remote.execution.v2.DigestFunction.Value
 }
 var file_pkg_proto_iscc_iscc_proto_depIdxs = []int32{
 	6,  // 0: buildbarn.iscc.PreviousExecution.failed:type_name -> google.protobuf.Empty
@@ -489,18 +518,20 @@ var file_pkg_proto_iscc_iscc_proto_depIdxs = []int32{
 	5,  // 4: buildbarn.iscc.PreviousExecutionStats.size_classes:type_name -> buildbarn.iscc.PreviousExecutionStats.SizeClassesEntry
 	8,  // 5: buildbarn.iscc.PreviousExecutionStats.last_seen_failure:type_name -> google.protobuf.Timestamp
 	9,  // 6: buildbarn.iscc.GetPreviousExecutionStatsRequest.reduced_action_digest:type_name -> build.bazel.remote.execution.v2.Digest
-	9,  // 7: buildbarn.iscc.UpdatePreviousExecutionStatsRequest.reduced_action_digest:type_name -> build.bazel.remote.execution.v2.Digest
-	2,  // 8: buildbarn.iscc.UpdatePreviousExecutionStatsRequest.previous_execution_stats:type_name -> buildbarn.iscc.PreviousExecutionStats
-	1,  // 9: buildbarn.iscc.PreviousExecutionStats.SizeClassesEntry.value:type_name -> buildbarn.iscc.PerSizeClassStats
-	3,  // 10: buildbarn.iscc.InitialSizeClassCache.GetPreviousExecutionStats:input_type -> buildbarn.iscc.GetPreviousExecutionStatsRequest
-	4,  // 11: buildbarn.iscc.InitialSizeClassCache.UpdatePreviousExecutionStats:input_type -> buildbarn.iscc.UpdatePreviousExecutionStatsRequest
-	2,  // 12: buildbarn.iscc.InitialSizeClassCache.GetPreviousExecutionStats:output_type -> buildbarn.iscc.PreviousExecutionStats
-	6,  // 13: buildbarn.iscc.InitialSizeClassCache.UpdatePreviousExecutionStats:output_type -> google.protobuf.Empty
-	12, // [12:14] is the sub-list for method output_type
-	10, // [10:12] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	10, // 7: buildbarn.iscc.GetPreviousExecutionStatsRequest.digest_function:type_name -> build.bazel.remote.execution.v2.DigestFunction.Value
+	9,  // 8: buildbarn.iscc.UpdatePreviousExecutionStatsRequest.reduced_action_digest:type_name -> build.bazel.remote.execution.v2.Digest
+	2,  // 9: buildbarn.iscc.UpdatePreviousExecutionStatsRequest.previous_execution_stats:type_name -> buildbarn.iscc.PreviousExecutionStats
+	10, // 10: buildbarn.iscc.UpdatePreviousExecutionStatsRequest.digest_function:type_name -> build.bazel.remote.execution.v2.DigestFunction.Value
+	1,  // 11: buildbarn.iscc.PreviousExecutionStats.SizeClassesEntry.value:type_name -> buildbarn.iscc.PerSizeClassStats
+	3,  // 12: buildbarn.iscc.InitialSizeClassCache.GetPreviousExecutionStats:input_type -> buildbarn.iscc.GetPreviousExecutionStatsRequest
+	4,  // 13: buildbarn.iscc.InitialSizeClassCache.UpdatePreviousExecutionStats:input_type -> buildbarn.iscc.UpdatePreviousExecutionStatsRequest
+	2,  // 14: buildbarn.iscc.InitialSizeClassCache.GetPreviousExecutionStats:output_type -> buildbarn.iscc.PreviousExecutionStats
+	6,  // 15: buildbarn.iscc.InitialSizeClassCache.UpdatePreviousExecutionStats:output_type -> google.protobuf.Empty
+	14, // [14:16] is the sub-list for method output_type
+	12, // [12:14] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_pkg_proto_iscc_iscc_proto_init() }